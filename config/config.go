@@ -11,6 +11,7 @@ import (
 // App config struct
 type Config struct {
 	Server   ServerConfig
+	Jwt      JwtConfig
 	Postgres PostgresConfig
 	Cors     CorsConfig
 }
@@ -22,6 +23,27 @@ type ServerConfig struct {
 	CtxUserKey   string
 	ReadTimeout  time.Duration
 	WriteTimeout time.Duration
+	// Lifetimes of tokens in seconds
+	AccessTokenTTL  time.Duration
+	RefreshTokenTTL time.Duration
+}
+
+// Keys of access tokens. Tokens are signed with key of SigningKid and verified with any key,
+// so key is rotated by adding new one, switching SigningKid to it and removing old one after access token TTL.
+// JwtSecretKey of server is used as the only HS256 key, if no keys are set.
+type JwtConfig struct {
+	SigningKid string
+	Keys       []JwtKeyConfig
+}
+
+type JwtKeyConfig struct {
+	Kid string
+	// HS256, RS256 or EdDSA
+	Alg string
+	// Secret of HS256 key
+	Secret string
+	// Path to PEM private key of RS256 and EdDSA keys, or to public key of keys only verifying tokens
+	KeyFile string
 }
 
 type PostgresConfig struct {
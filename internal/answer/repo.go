@@ -13,9 +13,22 @@ type Repo interface {
 	// Returns nil & other err else.
 	Create(ctx context.Context, answer *models.Answer) (*models.Answer, error)
 
+	// Inserts all answers in one round trip.
+	// Returns created models & nil, if created.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	CreateBatch(ctx context.Context, answers []*models.Answer) ([]*models.Answer, error)
+
 	// Returns slice & nil, if get smth.
 	// Returns empty slice & nil, if get nothing.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & other err else.
 	GetByPoolAnswerId(ctx context.Context, pool_answer_id string, sets types.GetSets) ([]*models.Answer, error)
+
+	// Returns number of deleted models & nil, if deleted or nothing to delete.
+	// Returns 0 & ErrInvalidContent, if invalid inputs.
+	// Returns 0 & ErrForbidden, if permission denied.
+	// Returns 0 & other err else.
+	DeleteByPoolAnswerId(ctx context.Context, pool_answer_id string) (int64, error)
 }
@@ -12,6 +12,7 @@ import (
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 )
 
 type AnswerDB struct {
@@ -43,7 +44,7 @@ func (a *answerRepo) Create(ctx context.Context, answer *models.Answer) (*models
 		return nil, err
 	}
 
-	err = a.Pool.QueryRow(ctx, sql, args...).Scan(&answerDB.Id)
+	err = a.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&answerDB.Id)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
@@ -56,6 +57,55 @@ func (a *answerRepo) Create(ctx context.Context, answer *models.Answer) (*models
 	return answerDBToBL(answerDB)
 }
 
+func (a *answerRepo) CreateBatch(ctx context.Context, answers []*models.Answer) ([]*models.Answer, error) {
+	answersDB := make([]*AnswerDB, len(answers))
+	batch := new(pgx.Batch)
+
+	for i, answer := range answers {
+		answerDB, err := answerBLToDB(answer)
+		if err != nil {
+			return nil, errs.ErrInvalidContent
+		}
+
+		sql, args, err := a.Builder.
+			Insert("answer_").
+			Columns("question_id_, pool_answer_id_, value_").
+			Values(answerDB.QuestionId, answerDB.PoolAnswerId, answerDB.Value).
+			Suffix("RETURNING \"id_\"").
+			ToSql()
+		if err != nil {
+			return nil, err
+		}
+
+		answersDB[i] = answerDB
+		batch.Queue(sql, args...)
+	}
+
+	results := a.Conn(ctx).SendBatch(ctx, batch)
+	defer results.Close()
+
+	res := make([]*models.Answer, len(answersDB))
+
+	for i, answerDB := range answersDB {
+		err := results.QueryRow().Scan(&answerDB.Id)
+		if err != nil {
+			var pgErr *pgconn.PgError
+			if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+				return nil, errs.ErrForbidden
+			}
+
+			return nil, err
+		}
+
+		res[i], err = answerDBToBL(answerDB)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return res, nil
+}
+
 func (a *answerRepo) GetByPoolAnswerId(ctx context.Context, pool_answer_id string, sets types.GetSets) ([]*models.Answer, error) {
 	intid, err := strconv.Atoi(pool_answer_id)
 	if err != nil {
@@ -73,7 +123,7 @@ func (a *answerRepo) GetByPoolAnswerId(ctx context.Context, pool_answer_id strin
 		return nil, err
 	}
 
-	rows, err := a.Pool.Query(ctx, sql, args...)
+	rows, err := a.Conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -100,6 +150,33 @@ func (a *answerRepo) GetByPoolAnswerId(ctx context.Context, pool_answer_id strin
 	return res, nil
 }
 
+func (a *answerRepo) DeleteByPoolAnswerId(ctx context.Context, pool_answer_id string) (int64, error) {
+	intid, err := strconv.Atoi(pool_answer_id)
+	if err != nil {
+		return 0, errs.ErrInvalidContent
+	}
+
+	sql, args, err := a.Builder.
+		Delete("answer_").
+		Where(squirrel.Eq{"pool_answer_id_": intid}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	res, err := a.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return 0, errs.ErrForbidden
+		}
+
+		return 0, err
+	}
+
+	return res.RowsAffected(), nil
+}
+
 func answerDBToBL(answerDB *AnswerDB) (*models.Answer, error) {
 	return &models.Answer{
 		Id:             strconv.Itoa(answerDB.Id),
@@ -14,6 +14,9 @@ import (
 	"github.com/Masterminds/squirrel"
 	"github.com/driftprogramming/pgxpoolmock"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -125,6 +128,150 @@ func TestAnswerRepo_Create(t *testing.T) {
 	}
 }
 
+// replays prepared rows for each query of batch
+type batchResultsMock struct {
+	rows []pgx.Rows
+	pos  int
+}
+
+func (b *batchResultsMock) Exec() (pgconn.CommandTag, error) { return nil, nil }
+
+func (b *batchResultsMock) Query() (pgx.Rows, error) { return nil, nil }
+
+func (b *batchResultsMock) QueryFunc(scans []interface{}, f func(pgx.QueryFuncRow) error) (pgconn.CommandTag, error) {
+	return nil, nil
+}
+
+func (b *batchResultsMock) QueryRow() pgx.Row {
+	row := b.rows[b.pos]
+	b.pos++
+	return row
+}
+
+func (b *batchResultsMock) Close() error { return nil }
+
+func TestAnswerRepo_CreateBatch(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, answers []*models.Answer)
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		answers         []*models.Answer
+		mockBehavior    mockBehavior
+		expectedAnswers []*models.Answer
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			answers: []*models.Answer{
+				{
+					Question_id:    "12",
+					Pool_answer_id: "14",
+					Value:          "answer1",
+				},
+				{
+					Question_id:    "13",
+					Pool_answer_id: "14",
+					Value:          "answer2",
+				},
+			},
+			mockBehavior: func(ctx context.Context, answers []*models.Answer) {
+				results := &batchResultsMock{
+					rows: []pgx.Rows{
+						pgxpoolmock.NewRows([]string{"id_"}).AddRow(345).ToPgxRows(),
+						pgxpoolmock.NewRows([]string{"id_"}).AddRow(346).ToPgxRows(),
+					},
+				}
+				for _, row := range results.rows {
+					row.Next()
+				}
+				mockPool.EXPECT().SendBatch(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, b *pgx.Batch) pgx.BatchResults {
+					assert.Equal(t, len(answers), b.Len())
+					return results
+				})
+			},
+			expectedAnswers: []*models.Answer{
+				{
+					Id:             "345",
+					Question_id:    "12",
+					Pool_answer_id: "14",
+					Value:          "answer1",
+				},
+				{
+					Id:             "346",
+					Question_id:    "13",
+					Pool_answer_id: "14",
+					Value:          "answer2",
+				},
+			},
+		},
+		{
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			answers: []*models.Answer{
+				{
+					Question_id:    "1r2",
+					Pool_answer_id: "14",
+					Value:          "answer",
+				},
+			},
+			mockBehavior: func(ctx context.Context, answers []*models.Answer) {},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			answers: []*models.Answer{
+				{
+					Question_id:    "12",
+					Pool_answer_id: "14",
+					Value:          "answer",
+				},
+			},
+			mockBehavior: func(ctx context.Context, answers []*models.Answer) {
+				results := &batchResultsMock{
+					rows: []pgx.Rows{
+						pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows(),
+					},
+				}
+				mockPool.EXPECT().SendBatch(ctx, gomock.Any()).Return(results)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.answers)
+
+			got, err := r.CreateBatch(testCase.ctx, testCase.answers)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedAnswers, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
 func TestAnswerRepo_GetByPoolAnswerId(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -229,3 +376,74 @@ func TestAnswerRepo_GetByPoolAnswerId(t *testing.T) {
 		})
 	}
 }
+
+func TestAnswerRepo_DeleteByPoolAnswerId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, pool_answer_id string)
+
+	testTable := []struct {
+		nameTest       string
+		ctx            context.Context
+		pool_answer_id string
+		mockBehavior   mockBehavior
+		expectedCount  int64
+	}{
+		{
+			nameTest:       "ok",
+			ctx:            context.Background(),
+			pool_answer_id: "12",
+			mockBehavior: func(ctx context.Context, pool_answer_id string) {
+				idint, _ := strconv.Atoi(pool_answer_id)
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM answer_ WHERE pool_answer_id_ = $1", idint).Return(pgxmock.NewResult("DELETE", 3), nil)
+			},
+			expectedCount: 3,
+		},
+		{
+			nameTest:       "invalid_inputs",
+			ctx:            context.Background(),
+			pool_answer_id: "5r4",
+			mockBehavior:   func(ctx context.Context, pool_answer_id string) {},
+		},
+		{
+			nameTest:       "exec_error",
+			ctx:            context.Background(),
+			pool_answer_id: "12",
+			mockBehavior: func(ctx context.Context, pool_answer_id string) {
+				idint, _ := strconv.Atoi(pool_answer_id)
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM answer_ WHERE pool_answer_id_ = $1", idint).Return(nil, errors.New("exec_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.pool_answer_id)
+
+			got, err := r.DeleteByPoolAnswerId(testCase.ctx, testCase.pool_answer_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedCount, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "exec_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
@@ -7,12 +7,12 @@ import (
 )
 
 type UseCase interface {
-	// Returns created model & nil, if created.
+	// Returns slice with questions of answered version & nil, if get smth.
 	// Returns empty slice & nil, if get nothing.
 	// Returns nil & ErrContentNotFound, if no such form.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & ErrUnauthorized, if user unauthorized.
-	// Returns nil & ErrForbidden, if user is not form owner.
+	// Returns nil & ErrForbidden, if user is neither pool answer author nor form owner, member, moderator or admin.
 	// Returns nil & other err else.
 	GetByPoolAnswerId(ctx context.Context, pool_answer_id string, sets types.GetSets) ([]*models.Answer, error)
 }
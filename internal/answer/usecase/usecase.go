@@ -3,23 +3,29 @@ package usecase
 import (
 	"context"
 	"quizapp/internal/answer"
-	"quizapp/internal/form"
+	"quizapp/internal/authz"
 	"quizapp/internal/poolanswer"
+	"quizapp/internal/version"
 	"quizapp/models"
+	"quizapp/pkg/errs"
 	"quizapp/pkg/types"
 )
 
 type answerUseCase struct {
-	answerRepo answer.Repo
-	formRepo   form.Repo
-	paRepo     poolanswer.Repo
+	answerRepo  answer.Repo
+	authorizer  authz.Authorizer
+	paRepo      poolanswer.Repo
+	versionRepo version.Repo
+	ctxUserKey  string
 }
 
-func NewAnswerUseCase(answerRepo answer.Repo, formRepo form.Repo, paRepo poolanswer.Repo) answer.UseCase {
+func NewAnswerUseCase(answerRepo answer.Repo, authorizer authz.Authorizer, paRepo poolanswer.Repo, versionRepo version.Repo, ctxUserKey string) answer.UseCase {
 	return &answerUseCase{
-		answerRepo: answerRepo,
-		formRepo:   formRepo,
-		paRepo:     paRepo,
+		answerRepo:  answerRepo,
+		authorizer:  authorizer,
+		paRepo:      paRepo,
+		versionRepo: versionRepo,
+		ctxUserKey:  ctxUserKey,
 	}
 }
 
@@ -29,10 +35,35 @@ func (answerUC *answerUseCase) GetByPoolAnswerId(ctx context.Context, pool_answe
 		return nil, err
 	}
 
-	err = answerUC.formRepo.ValidateIsOwner(ctx, foundpa.Form_id)
+	currentuser, ok := ctx.Value(answerUC.ctxUserKey).(*models.User)
+	if !ok {
+		return nil, errs.ErrUnauthorized
+	}
+
+	// respondent may read own answers, anyone else must be allowed to view form responses
+	if foundpa.User_id != currentuser.Id {
+		err = answerUC.authorizer.AuthorizeForm(ctx, foundpa.Form_id, models.ActionResponseView)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	foundanswers, err := answerUC.answerRepo.GetByPoolAnswerId(ctx, pool_answer_id, sets)
 	if err != nil {
 		return nil, err
 	}
 
-	return answerUC.answerRepo.GetByPoolAnswerId(ctx, pool_answer_id, sets)
+	// questions may be changed since, so render answers with version and bank questions shown to respondent
+	answeredversion, err := answerUC.versionRepo.GetById(ctx, foundpa.Version_id)
+	if err != nil {
+		return nil, err
+	}
+
+	shown := answeredversion.WithDrawn(foundpa.Questions)
+
+	for _, a := range foundanswers {
+		a.Question, _ = shown.GetQuestion(a.Question_id)
+	}
+
+	return foundanswers, nil
 }
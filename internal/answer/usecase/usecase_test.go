@@ -3,15 +3,16 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	mocka "quizapp/internal/answer/mock"
 	"quizapp/internal/answer/usecase"
+	mockauthz "quizapp/internal/authz/mock"
+	mockpa "quizapp/internal/poolanswer/mock"
+	mockv "quizapp/internal/version/mock"
 	"quizapp/models"
+	"quizapp/pkg/errs"
 	"quizapp/pkg/types"
 	"testing"
 
-	mocka "quiz-app/internal/answer/mock"
-	mockf "quiz-app/internal/form/mock"
-	mockpa "quiz-app/internal/poolanswer/mock"
-
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
@@ -21,14 +22,43 @@ func TestAnswerUseCase_GetByPoolAnswerId(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
-	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
 	mockRepoA := mocka.NewMockRepo(ctrl)
 	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewAnswerUseCase(mockRepoA, mockAuthz, mockRepoPA, mockRepoV, ctxUserKey)
 
-	uc := usecase.NewAnswerUseCase(mockRepoA, mockRepoF, mockRepoPA)
+	ownerctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "1"})
+	authorctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "46"})
 
 	type mockBehavior func(ctx context.Context, pool_answer_id string, sets types.GetSets)
 
+	foundpa := models.PoolAnswer{
+		Id:         "5",
+		Form_id:    "45",
+		Version_id: "3",
+		User_id:    "46",
+	}
+
+	shownquestion := &models.Question{
+		Id:      "7",
+		Form_id: "45",
+		Header:  "header shown",
+		Type:    models.QuestionTypeText,
+	}
+
+	drawnquestion := &models.Question{
+		Id:     "901",
+		Header: "header drawn",
+		Type:   models.QuestionTypeText,
+	}
+
+	drawnpa := foundpa
+	drawnpa.Questions = []*models.Question{drawnquestion}
+
 	testTable := []struct {
 		nameTest        string
 		ctx             context.Context
@@ -39,17 +69,12 @@ func TestAnswerUseCase_GetByPoolAnswerId(t *testing.T) {
 	}{
 		{
 			nameTest:       "ok",
-			ctx:            context.Background(),
+			ctx:            ownerctx,
 			pool_answer_id: "5",
 			sets:           types.GetSets{},
 			mockBehavior: func(ctx context.Context, pool_answer_id string, sets types.GetSets) {
-				foundpa := models.PoolAnswer{
-					Id:      pool_answer_id,
-					Form_id: "45",
-					User_id: "46",
-				}
 				mockRepoPA.EXPECT().GetById(ctx, pool_answer_id).Return(&foundpa, nil)
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, foundpa.Form_id).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, foundpa.Form_id, models.ActionResponseView).Return(nil)
 				mockRepoA.EXPECT().GetByPoolAnswerId(ctx, pool_answer_id, sets).Return([]*models.Answer{
 					{
 						Question_id:    "7",
@@ -64,6 +89,11 @@ func TestAnswerUseCase_GetByPoolAnswerId(t *testing.T) {
 						Id:             "1",
 					},
 				}, nil)
+				mockRepoV.EXPECT().GetById(ctx, foundpa.Version_id).Return(&models.FormVersion{
+					Id:        foundpa.Version_id,
+					Form_id:   foundpa.Form_id,
+					Questions: []*models.Question{shownquestion},
+				}, nil)
 			},
 			expectedAnswers: []*models.Answer{
 				{
@@ -71,6 +101,7 @@ func TestAnswerUseCase_GetByPoolAnswerId(t *testing.T) {
 					Value:          "ans1",
 					Pool_answer_id: "5",
 					Id:             "0",
+					Question:       shownquestion,
 				},
 				{
 					Question_id:    "8",
@@ -80,6 +111,90 @@ func TestAnswerUseCase_GetByPoolAnswerId(t *testing.T) {
 				},
 			},
 		},
+		{
+			nameTest:       "author",
+			ctx:            authorctx,
+			pool_answer_id: "5",
+			sets:           types.GetSets{},
+			mockBehavior: func(ctx context.Context, pool_answer_id string, sets types.GetSets) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer_id).Return(&foundpa, nil)
+				mockRepoA.EXPECT().GetByPoolAnswerId(ctx, pool_answer_id, sets).Return([]*models.Answer{
+					{
+						Question_id:    "7",
+						Value:          "ans1",
+						Pool_answer_id: pool_answer_id,
+						Id:             "0",
+					},
+				}, nil)
+				mockRepoV.EXPECT().GetById(ctx, foundpa.Version_id).Return(&models.FormVersion{
+					Id:        foundpa.Version_id,
+					Form_id:   foundpa.Form_id,
+					Questions: []*models.Question{shownquestion},
+				}, nil)
+			},
+			expectedAnswers: []*models.Answer{
+				{
+					Question_id:    "7",
+					Value:          "ans1",
+					Pool_answer_id: "5",
+					Id:             "0",
+					Question:       shownquestion,
+				},
+			},
+		},
+		{
+			nameTest:       "ok_drawn",
+			ctx:            authorctx,
+			pool_answer_id: "5",
+			sets:           types.GetSets{},
+			mockBehavior: func(ctx context.Context, pool_answer_id string, sets types.GetSets) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer_id).Return(&drawnpa, nil)
+				mockRepoA.EXPECT().GetByPoolAnswerId(ctx, pool_answer_id, sets).Return([]*models.Answer{
+					{
+						Question_id:    "7",
+						Value:          "ans1",
+						Pool_answer_id: pool_answer_id,
+						Id:             "0",
+					},
+					{
+						Question_id:    "901",
+						Value:          "ans2",
+						Pool_answer_id: pool_answer_id,
+						Id:             "1",
+					},
+				}, nil)
+				mockRepoV.EXPECT().GetById(ctx, foundpa.Version_id).Return(&models.FormVersion{
+					Id:        foundpa.Version_id,
+					Form_id:   foundpa.Form_id,
+					Questions: []*models.Question{shownquestion},
+				}, nil)
+			},
+			expectedAnswers: []*models.Answer{
+				{
+					Question_id:    "7",
+					Value:          "ans1",
+					Pool_answer_id: "5",
+					Id:             "0",
+					Question:       shownquestion,
+				},
+				{
+					Question_id:    "901",
+					Value:          "ans2",
+					Pool_answer_id: "5",
+					Id:             "1",
+					Question:       drawnquestion,
+				},
+			},
+		},
+		{
+			nameTest:       "unauthorized",
+			ctx:            context.Background(),
+			pool_answer_id: "5",
+			sets:           types.GetSets{},
+			mockBehavior: func(ctx context.Context, pool_answer_id string, sets types.GetSets) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer_id).Return(&foundpa, nil)
+			},
+		},
 		{
 			nameTest:       "paRepo_getbyid_error",
 			ctx:            context.Background(),
@@ -91,17 +206,24 @@ func TestAnswerUseCase_GetByPoolAnswerId(t *testing.T) {
 		},
 		{
 			nameTest:       "user_not_an_owner",
-			ctx:            context.Background(),
+			ctx:            ownerctx,
+			pool_answer_id: "5",
+			sets:           types.GetSets{},
+			mockBehavior: func(ctx context.Context, pool_answer_id string, sets types.GetSets) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer_id).Return(&foundpa, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, foundpa.Form_id, models.ActionResponseView).Return(errors.New("user_not_an_owner"))
+			},
+		},
+		{
+			nameTest:       "vRepo_getbyid_error",
+			ctx:            ownerctx,
 			pool_answer_id: "5",
 			sets:           types.GetSets{},
 			mockBehavior: func(ctx context.Context, pool_answer_id string, sets types.GetSets) {
-				foundpa := models.PoolAnswer{
-					Id:      pool_answer_id,
-					Form_id: "45",
-					User_id: "46",
-				}
 				mockRepoPA.EXPECT().GetById(ctx, pool_answer_id).Return(&foundpa, nil)
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, foundpa.Form_id).Return(errors.New("user_not_an_owner"))
+				mockAuthz.EXPECT().AuthorizeForm(ctx, foundpa.Form_id, models.ActionResponseView).Return(nil)
+				mockRepoA.EXPECT().GetByPoolAnswerId(ctx, pool_answer_id, sets).Return([]*models.Answer{}, nil)
+				mockRepoV.EXPECT().GetById(ctx, foundpa.Version_id).Return(nil, errors.New("vRepo_getbyid_error"))
 			},
 		},
 	}
@@ -113,10 +235,12 @@ func TestAnswerUseCase_GetByPoolAnswerId(t *testing.T) {
 			got, err := uc.GetByPoolAnswerId(testCase.ctx, testCase.pool_answer_id, testCase.sets)
 
 			switch testCase.nameTest {
-			case "ok":
+			case "ok", "author", "ok_drawn":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedAnswers, got)
-			case "paRepo_getbyid_error", "user_not_an_owner":
+			case "unauthorized":
+				assert.Equal(t, errs.ErrUnauthorized, err)
+			case "paRepo_getbyid_error", "user_not_an_owner", "vRepo_getbyid_error":
 				assert.NotEqual(t, nil, err)
 			default:
 				assert.Error(t, errors.New("No case"), "No case")
@@ -6,5 +6,9 @@ import "github.com/gin-gonic/gin"
 type Handlers interface {
 	SignUp() gin.HandlerFunc
 	SignIn() gin.HandlerFunc
+	Refresh() gin.HandlerFunc
+	Logout() gin.HandlerFunc
 	GetById() gin.HandlerFunc
+	SetRole() gin.HandlerFunc
+	JWKS() gin.HandlerFunc
 }
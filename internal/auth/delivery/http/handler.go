@@ -5,6 +5,8 @@ import (
 	"quizapp/internal/auth"
 	"quizapp/models"
 	"quizapp/pkg/errs"
+	"quizapp/pkg/jwter"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
@@ -21,11 +23,41 @@ type SignUpResponse struct {
 }
 
 type SignInResponse struct {
-	Token string `json:"token"`
+	Token         string `json:"token"`
+	Refresh_token string `json:"refresh_token"`
+	// Seconds till access token expires
+	Expires_in int `json:"expires_in"`
+}
+
+type RefreshRequest struct {
+	Refresh_token string `json:"refresh_token" binding:"required"`
 }
 
 type GetResponse struct {
 	Login string `json:"login"`
+	Role  string `json:"role" enums:"user,moderator,admin"`
+}
+
+type SetRoleRequest struct {
+	Role string `json:"role" binding:"required" enums:"user,moderator,admin"`
+}
+
+// Public key as JSON Web Key, RFC 7517
+type JWKResponse struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// Modulus and exponent of RSA key
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// Curve and public key of OKP key
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+type JWKSResponse struct {
+	Keys []*JWKResponse `json:"keys"`
 }
 
 type authHandlers struct {
@@ -92,13 +124,78 @@ func (h *authHandlers) SignIn() gin.HandlerFunc {
 			return
 		}
 
-		token, err := h.authUC.SignIn(c.Request.Context(), requestToBL(request))
+		tokens, err := h.authUC.SignIn(c.Request.Context(), requestToBL(request))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, blToSignInResponse(tokens))
+	}
+}
+
+// Refresh godoc
+// @Summary Refresh tokens
+// @Description Exchange refresh token for new access and refresh tokens, refresh token is used once.
+// @Description Reuse of used refresh token revokes its session.
+// @Tags Auth
+// @Accept json
+// @Param token body RefreshRequest true "refresh token"
+// @Success 200 {object} SignInResponse
+// @Failure 400   "Invalid json"
+// @Failure 401   "Invalid, expired, revoked or reused refresh token"
+// @Failure 403   "Permission denied"
+// @Failure 500   "Other err"
+// @Router /auth/refresh [post]
+func (h *authHandlers) Refresh() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(RefreshRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		tokens, err := h.authUC.Refresh(c.Request.Context(), request.Refresh_token)
 		if err != nil {
 			c.AbortWithStatus(errs.MatchHttpErr(err))
 			return
 		}
 
-		c.JSON(http.StatusOK, SignInResponse{Token: *token})
+		c.JSON(http.StatusOK, blToSignInResponse(tokens))
+	}
+}
+
+// Logout godoc
+// @Summary Log out
+// @Description Revoke session of refresh token, access tokens of session are rejected after
+// @Tags Auth
+// @Accept json
+// @Param token body RefreshRequest true "refresh token"
+// @Success 200
+// @Failure 400   "Invalid json"
+// @Failure 401   "Invalid, expired or revoked refresh token"
+// @Failure 403   "Permission denied"
+// @Failure 500   "Other err"
+// @Router /auth/logout [post]
+func (h *authHandlers) Logout() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(RefreshRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		err = h.authUC.Logout(c.Request.Context(), request.Refresh_token)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.Status(http.StatusOK)
 	}
 }
 
@@ -121,7 +218,57 @@ func (h *authHandlers) GetById() gin.HandlerFunc {
 			return
 		}
 
-		c.JSON(http.StatusOK, GetResponse{user.Login})
+		c.JSON(http.StatusOK, GetResponse{user.Login, user.Role})
+	}
+}
+
+// SetRole godoc
+// @Summary Set user role
+// @Description Grant role to user, available to admins. Access tokens issued before are rejected, so user has to sign in again
+// @Tags Auth
+// @Security JWTToken
+// @Accept json
+// @Param id path string true "user id"
+// @Param role body SetRoleRequest true "role"
+// @Success 200 {object} GetResponse
+// @Failure 204   "No such user"
+// @Failure 400   "Invalid params or unknown role"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not an admin or permission denied"
+// @Failure 500   "Other err"
+// @Router /users/{id}/role [put]
+func (h *authHandlers) SetRole() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(SetRoleRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		user, err := h.authUC.SetRole(c, c.Param("id"), request.Role)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, GetResponse{user.Login, user.Role})
+	}
+}
+
+// JWKS godoc
+// @Summary Get public keys
+// @Description Get JSON Web Key Set of public keys verifying access tokens, symmetric keys are not listed
+// @Tags Auth
+// @Produce json
+// @Success 200 {object} JWKSResponse
+// @Router /.well-known/jwks.json [get]
+func (h *authHandlers) JWKS() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		keys := h.authUC.Keys(c.Request.Context())
+
+		c.JSON(http.StatusOK, blToJWKSResponse(keys))
 	}
 }
 
@@ -132,6 +279,35 @@ func requestToBL(request *AuthRequest) *models.User {
 	}
 }
 
+func blToSignInResponse(tokens *models.TokenPair) *SignInResponse {
+	return &SignInResponse{
+		Token:         tokens.Access_token,
+		Refresh_token: tokens.Refresh_token,
+		Expires_in:    int(tokens.Expires_in / time.Second),
+	}
+}
+
+func blToJWKSResponse(keys []*jwter.JWK) *JWKSResponse {
+	res := &JWKSResponse{
+		Keys: make([]*JWKResponse, len(keys)),
+	}
+
+	for i, k := range keys {
+		res.Keys[i] = &JWKResponse{
+			Kty: k.Kty,
+			Kid: k.Kid,
+			Use: "sig",
+			Alg: k.Alg,
+			N:   k.N,
+			E:   k.E,
+			Crv: k.Crv,
+			X:   k.X,
+		}
+	}
+
+	return res
+}
+
 func blToSignUpResponse(user *models.User) *SignUpResponse {
 	return &SignUpResponse{
 		Id:       user.Id,
@@ -50,7 +50,8 @@ func (m *authMiddleware) Handle(c *gin.Context) {
 		return
 	}
 
-	if founduser.Login == user.Login {
+	// token issued before role change is rejected
+	if founduser.Login == user.Login && founduser.Role == user.Role {
 		c.Set(m.ctxUserKey, user)
 	} else {
 		c.AbortWithStatus(http.StatusUnauthorized)
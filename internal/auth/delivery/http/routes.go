@@ -10,4 +10,6 @@ import (
 func MapAuthRoutes(authGroup *gin.RouterGroup, h auth.Handlers) {
 	authGroup.POST("/signup", h.SignUp())
 	authGroup.POST("/signin", h.SignIn())
+	authGroup.POST("/refresh", h.Refresh())
+	authGroup.POST("/logout", h.Logout())
 }
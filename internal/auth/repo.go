@@ -22,4 +22,42 @@ type Repo interface {
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & other err else.
 	GetById(ctx context.Context, id string) (*models.User, error)
+
+	// Returns nil, if set.
+	// Returns ErrContentNotFound, if no such user.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	SetRole(ctx context.Context, id, role string) error
+
+	// Returns created model & nil, if created.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	CreateRefreshToken(ctx context.Context, token *models.RefreshToken) (*models.RefreshToken, error)
+
+	// Returns found model & nil, if get.
+	// Returns nil & ErrContentNotFound, if get nothing.
+	// Returns nil & other err else.
+	GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error)
+
+	// Marks token used, if it is neither used nor revoked.
+	// Returns nil, if marked.
+	// Returns ErrContentNotFound, if no such token, or it is used or revoked.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	UseRefreshToken(ctx context.Context, id string) error
+
+	// Revokes all refresh tokens of family and access tokens issued along.
+	// Returns nil, if revoked.
+	// Returns ErrContentNotFound, if no such family.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	RevokeRefreshFamily(ctx context.Context, family_id string) error
+
+	// Returns true & nil, if access token was issued along revoked refresh token.
+	// Returns false & nil, if not revoked.
+	// Returns false & other err else.
+	IsAccessRevoked(ctx context.Context, jti string) (bool, error)
 }
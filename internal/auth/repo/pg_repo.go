@@ -8,6 +8,7 @@ import (
 	"quizapp/pkg/errs"
 	"quizapp/pkg/postgres"
 	"strconv"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgconn"
@@ -15,8 +16,15 @@ import (
 )
 
 type UserDB struct {
-	Id              int
-	Login, Password string
+	Id                    int
+	Login, Password, Role string
+}
+
+type RefreshTokenDB struct {
+	Id, User_id                 int
+	Family_id, Hash, Access_jti string
+	Expires_at                  time.Time
+	Used, Revoked               bool
 }
 
 type authRepo struct {
@@ -35,15 +43,15 @@ func (a *authRepo) Create(ctx context.Context, user *models.User) (*models.User,
 
 	sql, args, err := a.Builder.
 		Insert("user_").
-		Columns("login_, password_").
-		Values(userDB.Login, userDB.Password).
+		Columns("login_, password_, role_").
+		Values(userDB.Login, userDB.Password, userDB.Role).
 		Suffix("RETURNING \"id_\"").
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = a.Pool.QueryRow(ctx, sql, args...).Scan(&userDB.Id)
+	err = a.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&userDB.Id)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
@@ -58,7 +66,7 @@ func (a *authRepo) Create(ctx context.Context, user *models.User) (*models.User,
 
 func (a *authRepo) GetByLogin(ctx context.Context, login string) (*models.User, error) {
 	sql, args, err := a.Builder.
-		Select("id_, password_").
+		Select("id_, password_, role_").
 		From("user_").
 		Where(squirrel.Eq{"login_": login}).
 		ToSql()
@@ -67,7 +75,7 @@ func (a *authRepo) GetByLogin(ctx context.Context, login string) (*models.User,
 	}
 
 	userDB := UserDB{Login: login}
-	err = a.Pool.QueryRow(ctx, sql, args...).Scan(&userDB.Id, &userDB.Password)
+	err = a.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&userDB.Id, &userDB.Password, &userDB.Role)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, errs.ErrContentNotFound
@@ -86,7 +94,7 @@ func (a *authRepo) GetById(ctx context.Context, id string) (*models.User, error)
 	}
 
 	sql, args, err := a.Builder.
-		Select("login_, password_").
+		Select("login_, password_, role_").
 		From("user_").
 		Where(squirrel.Eq{"id_": intid}).
 		ToSql()
@@ -95,7 +103,7 @@ func (a *authRepo) GetById(ctx context.Context, id string) (*models.User, error)
 	}
 
 	userDB := UserDB{Id: intid}
-	err = a.Pool.QueryRow(ctx, sql, args...).Scan(&userDB.Login, &userDB.Password)
+	err = a.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&userDB.Login, &userDB.Password, &userDB.Role)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, errs.ErrContentNotFound
@@ -107,11 +115,190 @@ func (a *authRepo) GetById(ctx context.Context, id string) (*models.User, error)
 	return userDBToBL(&userDB)
 }
 
+func (a *authRepo) SetRole(ctx context.Context, id, role string) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := a.Builder.
+		Update("user_").
+		Set("role_", role).
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return a.exec(ctx, sql, args...)
+}
+
+func (a *authRepo) CreateRefreshToken(ctx context.Context, token *models.RefreshToken) (*models.RefreshToken, error) {
+	tokenDB, err := refreshTokenBLToDB(token)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := a.Builder.
+		Insert("refresh_token_").
+		Columns("user_id_, family_id_, hash_, access_jti_, expires_at_").
+		Values(tokenDB.User_id, tokenDB.Family_id, tokenDB.Hash, tokenDB.Access_jti, tokenDB.Expires_at).
+		Suffix("RETURNING \"id_\"").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&tokenDB.Id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return nil, errs.ErrForbidden
+		}
+
+		return nil, err
+	}
+
+	return refreshTokenDBToBL(tokenDB), nil
+}
+
+func (a *authRepo) GetRefreshTokenByHash(ctx context.Context, hash string) (*models.RefreshToken, error) {
+	sql, args, err := a.Builder.
+		Select("id_, user_id_, family_id_, access_jti_, expires_at_, used_, revoked_").
+		From("refresh_token_").
+		Where(squirrel.Eq{"hash_": hash}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	tokenDB := RefreshTokenDB{Hash: hash}
+	err = a.Conn(ctx).QueryRow(ctx, sql, args...).Scan(
+		&tokenDB.Id,
+		&tokenDB.User_id,
+		&tokenDB.Family_id,
+		&tokenDB.Access_jti,
+		&tokenDB.Expires_at,
+		&tokenDB.Used,
+		&tokenDB.Revoked,
+	)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return refreshTokenDBToBL(&tokenDB), nil
+}
+
+func (a *authRepo) UseRefreshToken(ctx context.Context, id string) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	// token is used once, concurrent rotation of it finds nothing
+	sql, args, err := a.Builder.
+		Update("refresh_token_").
+		Set("used_", true).
+		Where(squirrel.Eq{"id_": intid, "used_": false, "revoked_": false}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return a.exec(ctx, sql, args...)
+}
+
+func (a *authRepo) RevokeRefreshFamily(ctx context.Context, family_id string) error {
+	sql, args, err := a.Builder.
+		Update("refresh_token_").
+		Set("revoked_", true).
+		Where(squirrel.Eq{"family_id_": family_id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	return a.exec(ctx, sql, args...)
+}
+
+func (a *authRepo) IsAccessRevoked(ctx context.Context, jti string) (bool, error) {
+	sql, args, err := a.Builder.
+		Select("revoked_").
+		From("refresh_token_").
+		Where(squirrel.Eq{"access_jti_": jti}).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+
+	var revoked bool
+	err = a.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&revoked)
+	if err != nil && err != pgx.ErrNoRows {
+		return false, err
+	}
+
+	return revoked, nil
+}
+
+// Returns ErrContentNotFound, if no rows affected.
+func (a *authRepo) exec(ctx context.Context, sql string, args ...interface{}) error {
+	res, err := a.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func refreshTokenDBToBL(tokenDB *RefreshTokenDB) *models.RefreshToken {
+	return &models.RefreshToken{
+		Id:         strconv.Itoa(tokenDB.Id),
+		User_id:    strconv.Itoa(tokenDB.User_id),
+		Family_id:  tokenDB.Family_id,
+		Hash:       tokenDB.Hash,
+		Access_jti: tokenDB.Access_jti,
+		Expires_at: tokenDB.Expires_at,
+		Used:       tokenDB.Used,
+		Revoked:    tokenDB.Revoked,
+	}
+}
+
+func refreshTokenBLToDB(tokenBL *models.RefreshToken) (*RefreshTokenDB, error) {
+	user_id, err := strconv.Atoi(tokenBL.User_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &RefreshTokenDB{
+		User_id:    user_id,
+		Family_id:  tokenBL.Family_id,
+		Hash:       tokenBL.Hash,
+		Access_jti: tokenBL.Access_jti,
+		Expires_at: tokenBL.Expires_at,
+		Used:       tokenBL.Used,
+		Revoked:    tokenBL.Revoked,
+	}, nil
+}
+
 func userDBToBL(userDB *UserDB) (*models.User, error) {
 	return &models.User{
 		Id:       strconv.Itoa(userDB.Id),
 		Login:    userDB.Login,
 		Password: userDB.Password,
+		Role:     userDB.Role,
 	}, nil
 }
 
@@ -132,5 +319,6 @@ func userBLToDB(userBL *models.User) (*UserDB, error) {
 		Id:       id,
 		Login:    userBL.Login,
 		Password: userBL.Password,
+		Role:     userBL.Role,
 	}, nil
 }
@@ -8,10 +8,13 @@ import (
 	"quizapp/pkg/errs"
 	"quizapp/pkg/postgres"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/driftprogramming/pgxpoolmock"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -49,16 +52,18 @@ func TestAuthRepo_Create(t *testing.T) {
 			user: models.User{
 				Login:    "sdcsd",
 				Password: "ecefvc",
+				Role:     models.RoleUser,
 			},
 			mockBehavior: func(ctx context.Context, user *models.User) {
 				pgxRows := pgxpoolmock.NewRows([]string{"id_"}).AddRow(345).ToPgxRows()
 				pgxRows.Next()
-				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO user_ (login_, password_) VALUES ($1,$2) RETURNING \"id_\"", user.Login, user.Password).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO user_ (login_, password_, role_) VALUES ($1,$2,$3) RETURNING \"id_\"", user.Login, user.Password, user.Role).Return(pgxRows)
 			},
 			expectedUser: models.User{
 				Id:       "345",
 				Login:    "sdcsd",
 				Password: "ecefvc",
+				Role:     models.RoleUser,
 			},
 		},
 		{
@@ -81,7 +86,7 @@ func TestAuthRepo_Create(t *testing.T) {
 			},
 			mockBehavior: func(ctx context.Context, user *models.User) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
-				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO user_ (login_, password_) VALUES ($1,$2) RETURNING \"id_\"", user.Login, user.Password).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO user_ (login_, password_, role_) VALUES ($1,$2,$3) RETURNING \"id_\"", user.Login, user.Password, user.Role).Return(pgxRows)
 			},
 		},
 	}
@@ -135,14 +140,15 @@ func TestAuthRepo_GetByLogin(t *testing.T) {
 			ctx:      context.Background(),
 			login:    "sdcsd",
 			mockBehavior: func(ctx context.Context, login string) {
-				pgxRows := pgxpoolmock.NewRows([]string{"id_", "password_"}).AddRow(345, "ecefvc").ToPgxRows()
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "password_", "role_"}).AddRow(345, "ecefvc", models.RoleUser).ToPgxRows()
 				pgxRows.Next()
-				mockPool.EXPECT().QueryRow(ctx, "SELECT id_, password_ FROM user_ WHERE login_ = $1", login).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT id_, password_, role_ FROM user_ WHERE login_ = $1", login).Return(pgxRows)
 			},
 			expectedUser: models.User{
 				Id:       "345",
 				Login:    "sdcsd",
 				Password: "ecefvc",
+				Role:     models.RoleUser,
 			},
 		},
 		{
@@ -151,7 +157,7 @@ func TestAuthRepo_GetByLogin(t *testing.T) {
 			login:    "sdcsd",
 			mockBehavior: func(ctx context.Context, login string) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
-				mockPool.EXPECT().QueryRow(ctx, "SELECT id_, password_ FROM user_ WHERE login_ = $1", login).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT id_, password_, role_ FROM user_ WHERE login_ = $1", login).Return(pgxRows)
 			},
 		},
 	}
@@ -203,14 +209,15 @@ func TestAuthRepo_GetById(t *testing.T) {
 			ctx:      context.Background(),
 			id:       "345",
 			mockBehavior: func(ctx context.Context, id string) {
-				pgxRows := pgxpoolmock.NewRows([]string{"login_", "password_"}).AddRow("sdcsd", "ecefvc").ToPgxRows()
+				pgxRows := pgxpoolmock.NewRows([]string{"login_", "password_", "role_"}).AddRow("sdcsd", "ecefvc", models.RoleUser).ToPgxRows()
 				pgxRows.Next()
-				mockPool.EXPECT().QueryRow(ctx, "SELECT login_, password_ FROM user_ WHERE id_ = $1", gomock.Any()).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT login_, password_, role_ FROM user_ WHERE id_ = $1", gomock.Any()).Return(pgxRows)
 			},
 			expectedUser: models.User{
 				Id:       "345",
 				Login:    "sdcsd",
 				Password: "ecefvc",
+				Role:     models.RoleUser,
 			},
 		},
 		{
@@ -225,7 +232,7 @@ func TestAuthRepo_GetById(t *testing.T) {
 			id:       "345",
 			mockBehavior: func(ctx context.Context, id string) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
-				mockPool.EXPECT().QueryRow(ctx, "SELECT login_, password_ FROM user_ WHERE id_ = $1", gomock.Any()).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT login_, password_, role_ FROM user_ WHERE id_ = $1", gomock.Any()).Return(pgxRows)
 			},
 		},
 	}
@@ -250,3 +257,423 @@ func TestAuthRepo_GetById(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthRepo_SetRole(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewAuthRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id, role string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id, role     string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "345",
+			role:     models.RoleModerator,
+			mockBehavior: func(ctx context.Context, id, role string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE user_ SET role_ = $1 WHERE id_ = $2", role, 345).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			role:         models.RoleModerator,
+			mockBehavior: func(ctx context.Context, id, role string) {},
+		},
+		{
+			nameTest: "not_found",
+			ctx:      context.Background(),
+			id:       "345",
+			role:     models.RoleAdmin,
+			mockBehavior: func(ctx context.Context, id, role string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE user_ SET role_ = $1 WHERE id_ = $2", role, 345).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id, testCase.role)
+
+			err := r.SetRole(testCase.ctx, testCase.id, testCase.role)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "not_found":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestAuthRepo_CreateRefreshToken(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewAuthRepo(&db)
+
+	expiresAt := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	type mockBehavior func(ctx context.Context, token *models.RefreshToken)
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		token         models.RefreshToken
+		mockBehavior  mockBehavior
+		expectedToken models.RefreshToken
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			token: models.RefreshToken{
+				User_id:    "5",
+				Family_id:  "fam",
+				Hash:       "hash",
+				Access_jti: "jti",
+				Expires_at: expiresAt,
+			},
+			mockBehavior: func(ctx context.Context, token *models.RefreshToken) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_"}).AddRow(3).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO refresh_token_ (user_id_, family_id_, hash_, access_jti_, expires_at_) VALUES ($1,$2,$3,$4,$5) RETURNING \"id_\"", 5, "fam", "hash", "jti", expiresAt).Return(pgxRows)
+			},
+			expectedToken: models.RefreshToken{
+				Id:         "3",
+				User_id:    "5",
+				Family_id:  "fam",
+				Hash:       "hash",
+				Access_jti: "jti",
+				Expires_at: expiresAt,
+			},
+		},
+		{
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			token: models.RefreshToken{
+				User_id: "5r4",
+			},
+			mockBehavior: func(ctx context.Context, token *models.RefreshToken) {},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.token)
+
+			got, err := r.CreateRefreshToken(testCase.ctx, &testCase.token)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedToken, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestAuthRepo_GetRefreshTokenByHash(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewAuthRepo(&db)
+
+	expiresAt := time.Date(2023, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	type mockBehavior func(ctx context.Context, hash string)
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		hash          string
+		mockBehavior  mockBehavior
+		expectedToken models.RefreshToken
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			hash:     "hash",
+			mockBehavior: func(ctx context.Context, hash string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "user_id_", "family_id_", "access_jti_", "expires_at_", "used_", "revoked_"}).
+					AddRow(3, 5, "fam", "jti", expiresAt, true, false).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT id_, user_id_, family_id_, access_jti_, expires_at_, used_, revoked_ FROM refresh_token_ WHERE hash_ = $1", hash).Return(pgxRows)
+			},
+			expectedToken: models.RefreshToken{
+				Id:         "3",
+				User_id:    "5",
+				Family_id:  "fam",
+				Hash:       "hash",
+				Access_jti: "jti",
+				Expires_at: expiresAt,
+				Used:       true,
+			},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			hash:     "hash",
+			mockBehavior: func(ctx context.Context, hash string) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT id_, user_id_, family_id_, access_jti_, expires_at_, used_, revoked_ FROM refresh_token_ WHERE hash_ = $1", hash).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.hash)
+
+			got, err := r.GetRefreshTokenByHash(testCase.ctx, testCase.hash)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedToken, *got)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestAuthRepo_UseRefreshToken(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewAuthRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "3",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE refresh_token_ SET used_ = $1 WHERE id_ = $2 AND revoked_ = $3 AND used_ = $4", true, 3, false, false).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+		},
+		{
+			nameTest: "used_or_revoked",
+			ctx:      context.Background(),
+			id:       "3",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE refresh_token_ SET used_ = $1 WHERE id_ = $2 AND revoked_ = $3 AND used_ = $4", true, 3, false, false).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			err := r.UseRefreshToken(testCase.ctx, testCase.id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "used_or_revoked":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestAuthRepo_RevokeRefreshFamily(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewAuthRepo(&db)
+
+	type mockBehavior func(ctx context.Context, family_id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		family_id    string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest:  "ok",
+			ctx:       context.Background(),
+			family_id: "fam",
+			mockBehavior: func(ctx context.Context, family_id string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE refresh_token_ SET revoked_ = $1 WHERE family_id_ = $2", true, family_id).Return(pgxmock.NewResult("UPDATE", 2), nil)
+			},
+		},
+		{
+			nameTest:  "not_found",
+			ctx:       context.Background(),
+			family_id: "fam",
+			mockBehavior: func(ctx context.Context, family_id string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE refresh_token_ SET revoked_ = $1 WHERE family_id_ = $2", true, family_id).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.family_id)
+
+			err := r.RevokeRefreshFamily(testCase.ctx, testCase.family_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "not_found":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestAuthRepo_IsAccessRevoked(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewAuthRepo(&db)
+
+	type mockBehavior func(ctx context.Context, jti string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		jti          string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "revoked",
+			ctx:      context.Background(),
+			jti:      "jti",
+			mockBehavior: func(ctx context.Context, jti string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"revoked_"}).AddRow(true).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT revoked_ FROM refresh_token_ WHERE access_jti_ = $1", jti).Return(pgxRows)
+			},
+		},
+		{
+			nameTest: "not_revoked",
+			ctx:      context.Background(),
+			jti:      "jti",
+			mockBehavior: func(ctx context.Context, jti string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"revoked_"}).AddRow(false).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT revoked_ FROM refresh_token_ WHERE access_jti_ = $1", jti).Return(pgxRows)
+			},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			jti:      "jti",
+			mockBehavior: func(ctx context.Context, jti string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"revoked_"}).AddRow(nil).RowError(0, pgx.ErrNoRows).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT revoked_ FROM refresh_token_ WHERE access_jti_ = $1", jti).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.jti)
+
+			got, err := r.IsAccessRevoked(testCase.ctx, testCase.jti)
+
+			switch testCase.nameTest {
+			case "revoked":
+				assert.Equal(t, nil, err)
+				assert.True(t, got)
+			case "not_revoked", "no_rows":
+				assert.Equal(t, nil, err)
+				assert.False(t, got)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
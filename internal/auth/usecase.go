@@ -3,6 +3,7 @@ package auth
 import (
 	"context"
 	"quizapp/models"
+	"quizapp/pkg/jwter"
 )
 
 type UseCase interface {
@@ -13,11 +14,24 @@ type UseCase interface {
 	// Returns nil & other err else.
 	SignUp(ctx context.Context, user *models.User) (*models.User, error)
 
-	// Returns token & nil, if successful signing in.
+	// Returns access and refresh tokens of new session & nil, if successful signing in.
 	// Returns nil & ErrUnathorized, if no such user.
 	// Returns nil & ErrInvalidPassword, if invalid password.
 	// Returns nil & other err else.
-	SignIn(ctx context.Context, user *models.User) (*string, error)
+	SignIn(ctx context.Context, user *models.User) (*models.TokenPair, error)
+
+	// Rotates refresh token: marks it used and issues new tokens of its session.
+	// Returns new tokens & nil, if refreshed.
+	// Returns nil & ErrInvalidRefresh, if no such token, or it is expired or revoked.
+	// Returns nil & ErrInvalidRefresh, if token is used already, revoking its session.
+	// Returns nil & other err else.
+	Refresh(ctx context.Context, refresh_token string) (*models.TokenPair, error)
+
+	// Revokes session of refresh token with its access tokens.
+	// Returns nil, if revoked.
+	// Returns ErrInvalidRefresh, if no such token, or it is expired or revoked.
+	// Returns other err else.
+	Logout(ctx context.Context, refresh_token string) error
 
 	// Returns found model & nil, if get.
 	// Returns nil & ErrContentNotFound, if get nothing.
@@ -25,7 +39,20 @@ type UseCase interface {
 	// Returns nil & other err else.
 	GetById(ctx context.Context, id string) (*models.User, error)
 
+	// Sets role of user, role is carried by tokens issued after, older tokens are rejected.
+	// Returns updated model & nil, if set.
+	// Returns nil & ErrContentNotFound, if no such user.
+	// Returns nil & ErrInvalidContent, if invalid inputs or unknown role.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if current user is not admin or permission denied.
+	// Returns nil & other err else.
+	SetRole(ctx context.Context, id, role string) (*models.User, error)
+
 	// Returns user model & nil, if parsed.
-	// Returns nil & ErrInvalidAccessToken else.
+	// Returns nil & ErrInvalidAccessToken, if token is invalid, expired or revoked.
+	// Returns nil & other err else.
 	ParseToken(ctx context.Context, token string) (*models.User, error)
+
+	// Returns public keys verifying access tokens.
+	Keys(ctx context.Context) []*jwter.JWK
 }
@@ -2,23 +2,38 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
 	"quizapp/internal/auth"
+	"quizapp/internal/authz"
 	"quizapp/models"
 	"quizapp/pkg/errs"
 	"quizapp/pkg/jwter"
+	"quizapp/pkg/transactor"
+	"time"
 
 	"golang.org/x/crypto/bcrypt"
 )
 
 type authUseCase struct {
-	authRepo auth.Repo
-	jwter    jwter.JWTer
+	authRepo   auth.Repo
+	jwter      jwter.JWTer
+	authorizer authz.Authorizer
+	transactor transactor.Transactor
+	accessTTL  time.Duration
+	refreshTTL time.Duration
 }
 
-func NewAuthUseCase(authRepo auth.Repo, jwter jwter.JWTer) auth.UseCase {
+func NewAuthUseCase(authRepo auth.Repo, jwter jwter.JWTer, authorizer authz.Authorizer, transactor transactor.Transactor, accessTTL, refreshTTL time.Duration) auth.UseCase {
 	return &authUseCase{
-		authRepo: authRepo,
-		jwter:    jwter,
+		authRepo:   authRepo,
+		jwter:      jwter,
+		authorizer: authorizer,
+		transactor: transactor,
+		accessTTL:  accessTTL,
+		refreshTTL: refreshTTL,
 	}
 }
 
@@ -31,6 +46,9 @@ func (a *authUseCase) SignUp(ctx context.Context, user *models.User) (*models.Us
 		return nil, err
 	}
 
+	// roles other than user are granted by admin only
+	user.Role = models.RoleUser
+
 	nonhashedpswd := user.Password
 
 	pswd, err := bcrypt.GenerateFromPassword([]byte(nonhashedpswd), bcrypt.DefaultCost)
@@ -47,7 +65,7 @@ func (a *authUseCase) SignUp(ctx context.Context, user *models.User) (*models.Us
 	return createduser, err
 }
 
-func (a *authUseCase) SignIn(ctx context.Context, user *models.User) (*string, error) {
+func (a *authUseCase) SignIn(ctx context.Context, user *models.User) (*models.TokenPair, error) {
 	founduser, err := a.authRepo.GetByLogin(ctx, user.Login)
 	if err != nil {
 		if err == errs.ErrContentNotFound {
@@ -61,23 +79,201 @@ func (a *authUseCase) SignIn(ctx context.Context, user *models.User) (*string, e
 		return nil, errs.ErrInvalidPassword
 	}
 
-	founduser.Password = user.Password
+	family_id, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	return a.issueTokens(ctx, founduser, family_id)
+}
+
+func (a *authUseCase) Refresh(ctx context.Context, refresh_token string) (*models.TokenPair, error) {
+	found, err := a.getRefreshToken(ctx, refresh_token)
+	if err != nil {
+		return nil, err
+	}
+
+	// used token is presented again, so it may be stolen
+	if found.Used {
+		return nil, a.revokeReused(ctx, found)
+	}
+
+	founduser, err := a.authRepo.GetById(ctx, found.User_id)
+	if err != nil {
+		if err == errs.ErrContentNotFound {
+			err = errs.ErrInvalidRefresh
+		}
+
+		return nil, err
+	}
+
+	var res *models.TokenPair
+
+	err = a.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err := a.authRepo.UseRefreshToken(ctx, found.Id)
+		if err != nil {
+			return err
+		}
+
+		res, err = a.issueTokens(ctx, founduser, found.Family_id)
+		return err
+	})
+	if err != nil {
+		// token was rotated concurrently
+		if err == errs.ErrContentNotFound {
+			return nil, a.revokeReused(ctx, found)
+		}
+
+		return nil, err
+	}
+
+	return res, nil
+}
 
-	return a.jwter.GenerateJWTToken(founduser.Id, founduser.Login)
+func (a *authUseCase) Logout(ctx context.Context, refresh_token string) error {
+	found, err := a.getRefreshToken(ctx, refresh_token)
+	if err != nil {
+		return err
+	}
+
+	return a.authRepo.RevokeRefreshFamily(ctx, found.Family_id)
 }
 
 func (a *authUseCase) ParseToken(ctx context.Context, token string) (*models.User, error) {
-	id, login, err := a.jwter.ParseToken(token)
+	claims, err := a.jwter.ParseToken(token)
 	if err != nil {
 		return nil, errs.ErrInvalidAccessToken
 	}
 
+	revoked, err := a.authRepo.IsAccessRevoked(ctx, claims.Jti)
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked {
+		return nil, errs.ErrInvalidAccessToken
+	}
+
 	return &models.User{
-		Id:    *id,
-		Login: *login,
+		Id:    claims.Id,
+		Login: claims.Login,
+		Role:  claims.Role,
 	}, nil
 }
 
 func (a *authUseCase) GetById(ctx context.Context, id string) (*models.User, error) {
 	return a.authRepo.GetById(ctx, id)
 }
+
+func (a *authUseCase) SetRole(ctx context.Context, id, role string) (*models.User, error) {
+	if !models.IsValidRole(role) {
+		return nil, errs.ErrInvalidContent
+	}
+
+	err := a.authorizer.Authorize(ctx, models.ActionUserManage)
+	if err != nil {
+		return nil, err
+	}
+
+	err = a.authRepo.SetRole(ctx, id, role)
+	if err != nil {
+		return nil, err
+	}
+
+	return a.authRepo.GetById(ctx, id)
+}
+
+func (a *authUseCase) Keys(ctx context.Context) []*jwter.JWK {
+	return a.jwter.Keys()
+}
+
+// Issues access token and refresh token of family to user.
+func (a *authUseCase) issueTokens(ctx context.Context, user *models.User, family_id string) (*models.TokenPair, error) {
+	jti, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	access_token, err := a.jwter.GenerateJWTToken(&jwter.Claims{
+		Id:         user.Id,
+		Login:      user.Login,
+		Role:       user.Role,
+		Jti:        jti,
+		Expires_at: now.Add(a.accessTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	refresh_token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = a.authRepo.CreateRefreshToken(ctx, &models.RefreshToken{
+		User_id:    user.Id,
+		Family_id:  family_id,
+		Hash:       hashToken(refresh_token),
+		Access_jti: jti,
+		Expires_at: now.Add(a.refreshTTL),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.TokenPair{
+		Access_token:  *access_token,
+		Refresh_token: refresh_token,
+		Expires_in:    a.accessTTL,
+	}, nil
+}
+
+// Returns found token & nil, if it is neither revoked nor expired.
+// Returns nil & ErrInvalidRefresh, if no such token, or it is revoked or expired, or repo err else.
+func (a *authUseCase) getRefreshToken(ctx context.Context, refresh_token string) (*models.RefreshToken, error) {
+	found, err := a.authRepo.GetRefreshTokenByHash(ctx, hashToken(refresh_token))
+	if err != nil {
+		if err == errs.ErrContentNotFound {
+			err = errs.ErrInvalidRefresh
+		}
+
+		return nil, err
+	}
+
+	if found.Revoked || found.IsExpired(time.Now()) {
+		return nil, errs.ErrInvalidRefresh
+	}
+
+	return found, nil
+}
+
+// Revokes family of reused token.
+// Returns ErrInvalidRefresh, if revoked, or repo err else.
+func (a *authUseCase) revokeReused(ctx context.Context, reused *models.RefreshToken) error {
+	err := a.authRepo.RevokeRefreshFamily(ctx, reused.Family_id)
+	if err != nil {
+		return err
+	}
+
+	return errs.ErrInvalidRefresh
+}
+
+// Returns random url safe token of 32 bytes.
+func randomToken() (string, error) {
+	b := make([]byte, 32)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// Refresh tokens are kept hashed, so leaked table does not give sessions.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
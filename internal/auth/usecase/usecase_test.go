@@ -2,20 +2,31 @@ package usecase_test
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"quizapp/internal/auth/usecase"
 	"quizapp/models"
 	"testing"
+	"time"
 
 	mockauth "quizapp/internal/auth/mock"
+	mockauthz "quizapp/internal/authz/mock"
 	"quizapp/pkg/errs"
+	"quizapp/pkg/jwter"
 	mockjwt "quizapp/pkg/jwter/mock"
+	mocktx "quizapp/pkg/transactor/mock"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	accessTTL  = 15 * time.Minute
+	refreshTTL = 30 * 24 * time.Hour
+)
+
 func TestAuthUseCase_Create(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -23,8 +34,10 @@ func TestAuthUseCase_Create(t *testing.T) {
 
 	mockRepoAuth := mockauth.NewMockRepo(ctrl)
 	mockjwter := mockjwt.NewMockJWTer(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter)
+	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter, mockAuthz, mockTx, accessTTL, refreshTTL)
 
 	type mockBehavior func(ctx context.Context, user *models.User)
 
@@ -47,6 +60,7 @@ func TestAuthUseCase_Create(t *testing.T) {
 					Id:       "5",
 					Login:    user.Login,
 					Password: user.Password,
+					Role:     models.RoleUser,
 				}
 				mockRepoAuth.EXPECT().GetByLogin(ctx, user.Login).Return(nil, errs.ErrContentNotFound)
 				mockRepoAuth.EXPECT().Create(ctx, user).Return(&createduser, nil)
@@ -55,6 +69,7 @@ func TestAuthUseCase_Create(t *testing.T) {
 				Id:       "5",
 				Login:    "login",
 				Password: "password",
+				Role:     models.RoleUser,
 			},
 		},
 		{
@@ -121,8 +136,10 @@ func TestAuthUseCase_SignIn(t *testing.T) {
 
 	mockRepoAuth := mockauth.NewMockRepo(ctrl)
 	mockjwter := mockjwt.NewMockJWTer(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter)
+	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter, mockAuthz, mockTx, accessTTL, refreshTTL)
 
 	type mockBehavior func(ctx context.Context, user *models.User)
 
@@ -148,12 +165,7 @@ func TestAuthUseCase_SignIn(t *testing.T) {
 					Password: string(pswd),
 				}
 				mockRepoAuth.EXPECT().GetByLogin(ctx, user.Login).Return(&founduser, nil)
-				token := "token"
-				mockjwter.EXPECT().GenerateJWTToken(&models.User{
-					Id:       founduser.Id,
-					Login:    founduser.Login,
-					Password: user.Password,
-				}).Return(&token, nil)
+				expectIssue(ctx, mockRepoAuth, mockjwter, "5", "")
 			},
 			expectedToken: "token",
 		},
@@ -206,7 +218,9 @@ func TestAuthUseCase_SignIn(t *testing.T) {
 			switch testCase.nameTest {
 			case "ok":
 				assert.Equal(t, nil, err)
-				assert.Equal(t, testCase.expectedToken, *got)
+				assert.Equal(t, testCase.expectedToken, got.Access_token)
+				assert.NotEmpty(t, got.Refresh_token)
+				assert.Equal(t, accessTTL, got.Expires_in)
 			case "repoAuth_getbylogin_error":
 				assert.NotEqual(t, nil, err)
 			case "wrong_password":
@@ -227,10 +241,20 @@ func TestAuthUseCase_ParseToken(t *testing.T) {
 
 	mockRepoAuth := mockauth.NewMockRepo(ctrl)
 	mockjwter := mockjwt.NewMockJWTer(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter)
+	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter, mockAuthz, mockTx, accessTTL, refreshTTL)
 
-	type mockBehavior func(token string)
+	type mockBehavior func(ctx context.Context, token string)
+
+	claims := &jwter.Claims{
+		Id:         "5",
+		Login:      "login",
+		Role:       models.RoleModerator,
+		Jti:        "jti",
+		Expires_at: time.Now().Add(accessTTL),
+	}
 
 	testTable := []struct {
 		nameTest     string
@@ -243,33 +267,47 @@ func TestAuthUseCase_ParseToken(t *testing.T) {
 			nameTest: "ok",
 			ctx:      context.Background(),
 			token:    "token",
-			mockBehavior: func(token string) {
-				user := models.User{
-					Id:       "5",
-					Login:    "login",
-					Password: "password",
-				}
-				mockjwter.EXPECT().ParseToken(token).Return(&user, nil)
+			mockBehavior: func(ctx context.Context, token string) {
+				mockjwter.EXPECT().ParseToken(token).Return(claims, nil)
+				mockRepoAuth.EXPECT().IsAccessRevoked(ctx, "jti").Return(false, nil)
 			},
 			expectedUser: models.User{
-				Id:       "5",
-				Login:    "login",
-				Password: "password",
+				Id:    "5",
+				Login: "login",
+				Role:  models.RoleModerator,
 			},
 		},
 		{
 			nameTest: "invalid_token",
 			ctx:      context.Background(),
 			token:    "invalidtoken",
-			mockBehavior: func(token string) {
+			mockBehavior: func(ctx context.Context, token string) {
 				mockjwter.EXPECT().ParseToken(token).Return(nil, errors.New("invalid_token"))
 			},
 		},
+		{
+			nameTest: "revoked_token",
+			ctx:      context.Background(),
+			token:    "token",
+			mockBehavior: func(ctx context.Context, token string) {
+				mockjwter.EXPECT().ParseToken(token).Return(claims, nil)
+				mockRepoAuth.EXPECT().IsAccessRevoked(ctx, "jti").Return(true, nil)
+			},
+		},
+		{
+			nameTest: "repoAuth_isrevoked_error",
+			ctx:      context.Background(),
+			token:    "token",
+			mockBehavior: func(ctx context.Context, token string) {
+				mockjwter.EXPECT().ParseToken(token).Return(claims, nil)
+				mockRepoAuth.EXPECT().IsAccessRevoked(ctx, "jti").Return(false, errors.New("repoAuth_isrevoked_error"))
+			},
+		},
 	}
 
 	for _, testCase := range testTable {
 		t.Run(testCase.nameTest, func(t *testing.T) {
-			testCase.mockBehavior(testCase.token)
+			testCase.mockBehavior(testCase.ctx, testCase.token)
 
 			got, err := uc.ParseToken(testCase.ctx, testCase.token)
 
@@ -277,8 +315,10 @@ func TestAuthUseCase_ParseToken(t *testing.T) {
 			case "ok":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedUser, *got)
-			case "invalid_token":
+			case "invalid_token", "revoked_token":
 				assert.Equal(t, errs.ErrInvalidAccessToken, err)
+			case "repoAuth_isrevoked_error":
+				assert.NotEqual(t, nil, err)
 			default:
 				assert.Error(t, errors.New("No case"), "No case")
 			}
@@ -293,8 +333,10 @@ func TestAuthUseCase_GetById(t *testing.T) {
 
 	mockRepoAuth := mockauth.NewMockRepo(ctrl)
 	mockjwter := mockjwt.NewMockJWTer(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter)
+	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter, mockAuthz, mockTx, accessTTL, refreshTTL)
 
 	type mockBehavior func(ctx context.Context, id string)
 
@@ -341,3 +383,398 @@ func TestAuthUseCase_GetById(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthUseCase_SetRole(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoAuth := mockauth.NewMockRepo(ctrl)
+	mockjwter := mockjwt.NewMockJWTer(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter, mockAuthz, mockTx, accessTTL, refreshTTL)
+
+	type mockBehavior func(ctx context.Context, id, role string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id, role     string
+		mockBehavior mockBehavior
+		expectedUser models.User
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "2",
+			role:     models.RoleModerator,
+			mockBehavior: func(ctx context.Context, id, role string) {
+				mockAuthz.EXPECT().Authorize(ctx, models.ActionUserManage).Return(nil)
+				mockRepoAuth.EXPECT().SetRole(ctx, id, role).Return(nil)
+				mockRepoAuth.EXPECT().GetById(ctx, id).Return(&models.User{
+					Id:    id,
+					Login: "login",
+					Role:  role,
+				}, nil)
+			},
+			expectedUser: models.User{
+				Id:    "2",
+				Login: "login",
+				Role:  models.RoleModerator,
+			},
+		},
+		{
+			nameTest:     "unknown_role",
+			ctx:          context.Background(),
+			id:           "2",
+			role:         "root",
+			mockBehavior: func(ctx context.Context, id, role string) {},
+		},
+		{
+			nameTest: "not_an_admin",
+			ctx:      context.Background(),
+			id:       "2",
+			role:     models.RoleAdmin,
+			mockBehavior: func(ctx context.Context, id, role string) {
+				mockAuthz.EXPECT().Authorize(ctx, models.ActionUserManage).Return(errs.ErrForbidden)
+			},
+		},
+		{
+			nameTest: "no_user",
+			ctx:      context.Background(),
+			id:       "2",
+			role:     models.RoleUser,
+			mockBehavior: func(ctx context.Context, id, role string) {
+				mockAuthz.EXPECT().Authorize(ctx, models.ActionUserManage).Return(nil)
+				mockRepoAuth.EXPECT().SetRole(ctx, id, role).Return(errs.ErrContentNotFound)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id, testCase.role)
+
+			got, err := uc.SetRole(testCase.ctx, testCase.id, testCase.role)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedUser, *got)
+			case "unknown_role":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "not_an_admin":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "no_user":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestAuthUseCase_Refresh(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoAuth := mockauth.NewMockRepo(ctrl)
+	mockjwter := mockjwt.NewMockJWTer(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter, mockAuthz, mockTx, accessTTL, refreshTTL)
+
+	type mockBehavior func(ctx context.Context, refresh_token string)
+
+	// found token of session "fam" with state changed by change
+	found := func(change func(token *models.RefreshToken)) *models.RefreshToken {
+		res := &models.RefreshToken{
+			Id:         "3",
+			User_id:    "5",
+			Family_id:  "fam",
+			Access_jti: "jti",
+			Expires_at: time.Now().Add(time.Hour),
+		}
+		if change != nil {
+			change(res)
+		}
+		return res
+	}
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		refresh_token string
+		mockBehavior  mockBehavior
+	}{
+		{
+			nameTest:      "ok",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(found(nil), nil)
+				mockRepoAuth.EXPECT().GetById(ctx, "5").Return(&models.User{Id: "5", Login: "login"}, nil)
+				expectTx(ctx, mockTx)
+				mockRepoAuth.EXPECT().UseRefreshToken(ctx, "3").Return(nil)
+				expectIssue(ctx, mockRepoAuth, mockjwter, "5", "fam")
+			},
+		},
+		{
+			nameTest:      "no_such_token",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest:      "expired",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(found(func(token *models.RefreshToken) {
+					token.Expires_at = time.Now().Add(-time.Minute)
+				}), nil)
+			},
+		},
+		{
+			nameTest:      "revoked",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(found(func(token *models.RefreshToken) {
+					token.Used = true
+					token.Revoked = true
+				}), nil)
+			},
+		},
+		{
+			nameTest:      "reused",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(found(func(token *models.RefreshToken) {
+					token.Used = true
+				}), nil)
+				mockRepoAuth.EXPECT().RevokeRefreshFamily(ctx, "fam").Return(nil)
+			},
+		},
+		{
+			nameTest:      "rotated_concurrently",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(found(nil), nil)
+				mockRepoAuth.EXPECT().GetById(ctx, "5").Return(&models.User{Id: "5", Login: "login"}, nil)
+				expectTx(ctx, mockTx)
+				mockRepoAuth.EXPECT().UseRefreshToken(ctx, "3").Return(errs.ErrContentNotFound)
+				mockRepoAuth.EXPECT().RevokeRefreshFamily(ctx, "fam").Return(nil)
+			},
+		},
+		{
+			nameTest:      "repoAuth_create_error",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				token := "token"
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(found(nil), nil)
+				mockRepoAuth.EXPECT().GetById(ctx, "5").Return(&models.User{Id: "5", Login: "login"}, nil)
+				expectTx(ctx, mockTx)
+				mockRepoAuth.EXPECT().UseRefreshToken(ctx, "3").Return(nil)
+				mockjwter.EXPECT().GenerateJWTToken(gomock.Any()).Return(&token, nil)
+				mockRepoAuth.EXPECT().CreateRefreshToken(ctx, gomock.Any()).Return(nil, errors.New("repoAuth_create_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.refresh_token)
+
+			got, err := uc.Refresh(testCase.ctx, testCase.refresh_token)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, "token", got.Access_token)
+				assert.NotEqual(t, testCase.refresh_token, got.Refresh_token)
+			case "no_such_token", "expired", "revoked", "reused", "rotated_concurrently":
+				assert.Equal(t, errs.ErrInvalidRefresh, err)
+			case "repoAuth_create_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestAuthUseCase_Logout(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoAuth := mockauth.NewMockRepo(ctrl)
+	mockjwter := mockjwt.NewMockJWTer(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter, mockAuthz, mockTx, accessTTL, refreshTTL)
+
+	type mockBehavior func(ctx context.Context, refresh_token string)
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		refresh_token string
+		mockBehavior  mockBehavior
+	}{
+		{
+			nameTest:      "ok",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(&models.RefreshToken{
+					Id:         "3",
+					User_id:    "5",
+					Family_id:  "fam",
+					Used:       true,
+					Expires_at: time.Now().Add(time.Hour),
+				}, nil)
+				mockRepoAuth.EXPECT().RevokeRefreshFamily(ctx, "fam").Return(nil)
+			},
+		},
+		{
+			nameTest:      "no_such_token",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest:      "revoked",
+			ctx:           context.Background(),
+			refresh_token: "refresh",
+			mockBehavior: func(ctx context.Context, refresh_token string) {
+				mockRepoAuth.EXPECT().GetRefreshTokenByHash(ctx, hashToken(refresh_token)).Return(&models.RefreshToken{
+					Id:         "3",
+					User_id:    "5",
+					Family_id:  "fam",
+					Revoked:    true,
+					Expires_at: time.Now().Add(time.Hour),
+				}, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.refresh_token)
+
+			err := uc.Logout(testCase.ctx, testCase.refresh_token)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "no_such_token", "revoked":
+				assert.Equal(t, errs.ErrInvalidRefresh, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+// access token "token" and refresh token of family are issued to user,
+// new family is expected, if family_id is empty
+func expectIssue(ctx context.Context, mockRepoAuth *mockauth.MockRepo, mockjwter *mockjwt.MockJWTer, user_id, family_id string) {
+	token := "token"
+	mockjwter.EXPECT().GenerateJWTToken(gomock.Any()).DoAndReturn(func(claims *jwter.Claims) (*string, error) {
+		if claims.Id != user_id || claims.Jti == "" {
+			return nil, errors.New("unexpected claims")
+		}
+		return &token, nil
+	})
+	mockRepoAuth.EXPECT().CreateRefreshToken(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, refresh *models.RefreshToken) (*models.RefreshToken, error) {
+		if refresh.User_id != user_id || refresh.Family_id == "" || (family_id != "" && refresh.Family_id != family_id) {
+			return nil, errors.New("unexpected refresh token")
+		}
+		return refresh, nil
+	})
+}
+
+func expectTx(ctx context.Context, mockTx *mocktx.MockTransactor) {
+	mockTx.EXPECT().WithinTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+func TestAuthUseCase_Keys(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoAuth := mockauth.NewMockRepo(ctrl)
+	mockjwter := mockjwt.NewMockJWTer(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewAuthUseCase(mockRepoAuth, mockjwter, mockAuthz, mockTx, accessTTL, refreshTTL)
+
+	type mockBehavior func()
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		mockBehavior mockBehavior
+		expectedKeys []*jwter.JWK
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			mockBehavior: func() {
+				mockjwter.EXPECT().Keys().Return([]*jwter.JWK{
+					{Kty: "RSA", Kid: "k1", Alg: "RS256", N: "n", E: "AQAB"},
+					{Kty: "OKP", Kid: "k2", Alg: "EdDSA", Crv: "Ed25519", X: "x"},
+				})
+			},
+			expectedKeys: []*jwter.JWK{
+				{Kty: "RSA", Kid: "k1", Alg: "RS256", N: "n", E: "AQAB"},
+				{Kty: "OKP", Kid: "k2", Alg: "EdDSA", Crv: "Ed25519", X: "x"},
+			},
+		},
+		{
+			nameTest: "symmetric_keys_only",
+			ctx:      context.Background(),
+			mockBehavior: func() {
+				mockjwter.EXPECT().Keys().Return([]*jwter.JWK{})
+			},
+			expectedKeys: []*jwter.JWK{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior()
+
+			got := uc.Keys(testCase.ctx)
+
+			switch testCase.nameTest {
+			case "ok", "symmetric_keys_only":
+				assert.Equal(t, testCase.expectedKeys, got)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
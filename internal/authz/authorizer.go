@@ -0,0 +1,28 @@
+package authz
+
+import "context"
+
+// Single place deciding whether current user may take action.
+// Actions are models.Action* constants.
+type Authorizer interface {
+	// Returns nil, if current user owns form, role grants action on any form,
+	// member role grants action on form or workspace role grants action on forms of workspace.
+	// Returns ErrContentNotFound, if no such form.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	AuthorizeForm(ctx context.Context, form_id, action string) error
+
+	// Returns nil, if role of current user grants action or workspace role grants action on workspace.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if user is not member of workspace or permission denied.
+	// Returns other err else.
+	AuthorizeWorkspace(ctx context.Context, workspace_id, action string) error
+
+	// Returns nil, if role of current user grants action.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if permission denied.
+	Authorize(ctx context.Context, action string) error
+}
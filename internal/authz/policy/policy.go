@@ -0,0 +1,107 @@
+package policy
+
+import (
+	"context"
+	"quizapp/internal/authz"
+	"quizapp/internal/form"
+	"quizapp/internal/member"
+	"quizapp/internal/workspace"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+)
+
+type policy struct {
+	formRepo      form.Repo
+	memberRepo    member.Repo
+	workspaceRepo workspace.Repo
+	ctxUserKey    string
+}
+
+func NewPolicy(formRepo form.Repo, memberRepo member.Repo, workspaceRepo workspace.Repo, ctxUserKey string) authz.Authorizer {
+	return &policy{
+		formRepo:      formRepo,
+		memberRepo:    memberRepo,
+		workspaceRepo: workspaceRepo,
+		ctxUserKey:    ctxUserKey,
+	}
+}
+
+func (p *policy) AuthorizeForm(ctx context.Context, form_id, action string) error {
+	currentuser, ok := ctx.Value(p.ctxUserKey).(*models.User)
+	if !ok {
+		return errs.ErrUnauthorized
+	}
+
+	owner_id, err := p.formRepo.GetOwnerId(ctx, form_id)
+	if err != nil {
+		return err
+	}
+
+	if owner_id == currentuser.Id || currentuser.Can(action) {
+		return nil
+	}
+
+	foundmember, err := p.memberRepo.GetById(ctx, form_id, currentuser.Id)
+	if err != nil && err != errs.ErrContentNotFound {
+		return err
+	}
+
+	if err == nil && foundmember.Can(action) {
+		return nil
+	}
+
+	// role in workspace of form applies, if form membership does not grant action
+	workspacemember, err := p.workspaceRepo.GetMemberByFormId(ctx, form_id, currentuser.Id)
+	if err != nil {
+		if err == errs.ErrContentNotFound {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if !workspacemember.Can(action) {
+		return errs.ErrForbidden
+	}
+
+	return nil
+}
+
+func (p *policy) AuthorizeWorkspace(ctx context.Context, workspace_id, action string) error {
+	currentuser, ok := ctx.Value(p.ctxUserKey).(*models.User)
+	if !ok {
+		return errs.ErrUnauthorized
+	}
+
+	if currentuser.Can(action) {
+		return nil
+	}
+
+	foundmember, err := p.workspaceRepo.GetMember(ctx, workspace_id, currentuser.Id)
+	if err != nil {
+		if err == errs.ErrContentNotFound {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if !foundmember.Can(action) {
+		return errs.ErrForbidden
+	}
+
+	return nil
+}
+
+func (p *policy) Authorize(ctx context.Context, action string) error {
+	currentuser, ok := ctx.Value(p.ctxUserKey).(*models.User)
+	if !ok {
+		return errs.ErrUnauthorized
+	}
+
+	if !currentuser.Can(action) {
+		return errs.ErrForbidden
+	}
+
+	return nil
+}
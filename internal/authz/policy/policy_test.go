@@ -0,0 +1,398 @@
+package policy_test
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/authz/policy"
+	mockf "quizapp/internal/form/mock"
+	mockm "quizapp/internal/member/mock"
+	mockw "quizapp/internal/workspace/mock"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPolicy_AuthorizeForm(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoM := mockm.NewMockRepo(ctrl)
+	mockRepoW := mockw.NewMockRepo(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	p := policy.NewPolicy(mockRepoF, mockRepoM, mockRepoW, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	owner := &models.User{Id: "4", Role: models.RoleUser}
+	user := &models.User{Id: "5", Role: models.RoleUser}
+	moderator := &models.User{Id: "6", Role: models.RoleModerator}
+	admin := &models.User{Id: "7", Role: models.RoleAdmin}
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		action       string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "owner_edit",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, owner),
+			form_id:  "3",
+			action:   models.ActionFormEdit,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+			},
+		},
+		{
+			nameTest: "user_view",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionFormView,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(nil, errs.ErrContentNotFound)
+				mockRepoW.EXPECT().GetMemberByFormId(ctx, form_id, "5").Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "moderator_moderate",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, moderator),
+			form_id:  "3",
+			action:   models.ActionResponseModerate,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+			},
+		},
+		{
+			nameTest: "moderator_delete",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, moderator),
+			form_id:  "3",
+			action:   models.ActionFormDelete,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "6").Return(nil, errs.ErrContentNotFound)
+				mockRepoW.EXPECT().GetMemberByFormId(ctx, form_id, "6").Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "admin_close",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, admin),
+			form_id:  "3",
+			action:   models.ActionFormClose,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+			},
+		},
+		{
+			nameTest: "admin_edit",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, admin),
+			form_id:  "3",
+			action:   models.ActionFormEdit,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "7").Return(nil, errs.ErrContentNotFound)
+				mockRepoW.EXPECT().GetMemberByFormId(ctx, form_id, "7").Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "viewer_view",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionResponseView,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(&models.FormMember{Form_id: form_id, User_id: "5", Role: models.MemberRoleViewer}, nil)
+			},
+		},
+		{
+			nameTest: "viewer_edit",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionFormEdit,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(&models.FormMember{Form_id: form_id, User_id: "5", Role: models.MemberRoleViewer}, nil)
+				mockRepoW.EXPECT().GetMemberByFormId(ctx, form_id, "5").Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "editor_edit",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionFormEdit,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(&models.FormMember{Form_id: form_id, User_id: "5", Role: models.MemberRoleEditor}, nil)
+			},
+		},
+		{
+			nameTest: "editor_delete",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionFormDelete,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(&models.FormMember{Form_id: form_id, User_id: "5", Role: models.MemberRoleEditor}, nil)
+				mockRepoW.EXPECT().GetMemberByFormId(ctx, form_id, "5").Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "member_owner_manage",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionMemberManage,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(&models.FormMember{Form_id: form_id, User_id: "5", Role: models.MemberRoleOwner}, nil)
+			},
+		},
+		{
+			nameTest: "workspace_editor_edit",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionFormEdit,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(nil, errs.ErrContentNotFound)
+				mockRepoW.EXPECT().GetMemberByFormId(ctx, form_id, "5").Return(&models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleEditor}, nil)
+			},
+		},
+		{
+			nameTest: "viewer_workspace_editor_edit",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionFormEdit,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(&models.FormMember{Form_id: form_id, User_id: "5", Role: models.MemberRoleViewer}, nil)
+				mockRepoW.EXPECT().GetMemberByFormId(ctx, form_id, "5").Return(&models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleEditor}, nil)
+			},
+		},
+		{
+			nameTest: "workspace_viewer_moderate",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionResponseModerate,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(nil, errs.ErrContentNotFound)
+				mockRepoW.EXPECT().GetMemberByFormId(ctx, form_id, "5").Return(&models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleViewer}, nil)
+			},
+		},
+		{
+			nameTest: "member_err",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, user),
+			form_id:  "3",
+			action:   models.ActionFormView,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("4", nil)
+				mockRepoM.EXPECT().GetById(ctx, form_id, "5").Return(nil, errors.New("err"))
+			},
+		},
+		{
+			nameTest:     "unauthorized",
+			ctx:          context.Background(),
+			form_id:      "3",
+			action:       models.ActionFormView,
+			mockBehavior: func(ctx context.Context, form_id string) {},
+		},
+		{
+			nameTest: "no_form",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, admin),
+			form_id:  "3",
+			action:   models.ActionFormDelete,
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockRepoF.EXPECT().GetOwnerId(ctx, form_id).Return("", errs.ErrContentNotFound)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			err := p.AuthorizeForm(testCase.ctx, testCase.form_id, testCase.action)
+
+			switch testCase.nameTest {
+			case "owner_edit", "moderator_moderate", "admin_close", "viewer_view", "editor_edit", "member_owner_manage",
+				"workspace_editor_edit", "viewer_workspace_editor_edit":
+				assert.Equal(t, nil, err)
+			case "user_view", "moderator_delete", "admin_edit", "viewer_edit", "editor_delete", "workspace_viewer_moderate":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "unauthorized":
+				assert.Equal(t, errs.ErrUnauthorized, err)
+			case "no_form":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			case "member_err":
+				assert.Equal(t, errors.New("err"), err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPolicy_AuthorizeWorkspace(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoM := mockm.NewMockRepo(ctrl)
+	mockRepoW := mockw.NewMockRepo(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	p := policy.NewPolicy(mockRepoF, mockRepoM, mockRepoW, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, workspace_id string)
+
+	user := &models.User{Id: "5", Role: models.RoleUser}
+	admin := &models.User{Id: "7", Role: models.RoleAdmin}
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		workspace_id string
+		action       string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest:     "editor_edit",
+			ctx:          context.WithValue(context.Background(), ctxUserKey, user),
+			workspace_id: "8",
+			action:       models.ActionFormEdit,
+			mockBehavior: func(ctx context.Context, workspace_id string) {
+				mockRepoW.EXPECT().GetMember(ctx, workspace_id, "5").Return(&models.WorkspaceMember{Workspace_id: workspace_id, User_id: "5", Role: models.MemberRoleEditor}, nil)
+			},
+		},
+		{
+			nameTest:     "editor_manage",
+			ctx:          context.WithValue(context.Background(), ctxUserKey, user),
+			workspace_id: "8",
+			action:       models.ActionWorkspaceManage,
+			mockBehavior: func(ctx context.Context, workspace_id string) {
+				mockRepoW.EXPECT().GetMember(ctx, workspace_id, "5").Return(&models.WorkspaceMember{Workspace_id: workspace_id, User_id: "5", Role: models.MemberRoleEditor}, nil)
+			},
+		},
+		{
+			nameTest:     "admin_view",
+			ctx:          context.WithValue(context.Background(), ctxUserKey, admin),
+			workspace_id: "8",
+			action:       models.ActionFormView,
+			mockBehavior: func(ctx context.Context, workspace_id string) {},
+		},
+		{
+			nameTest:     "not_member",
+			ctx:          context.WithValue(context.Background(), ctxUserKey, user),
+			workspace_id: "8",
+			action:       models.ActionFormView,
+			mockBehavior: func(ctx context.Context, workspace_id string) {
+				mockRepoW.EXPECT().GetMember(ctx, workspace_id, "5").Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.WithValue(context.Background(), ctxUserKey, user),
+			workspace_id: "8r",
+			action:       models.ActionFormView,
+			mockBehavior: func(ctx context.Context, workspace_id string) {
+				mockRepoW.EXPECT().GetMember(ctx, workspace_id, "5").Return(nil, errs.ErrInvalidContent)
+			},
+		},
+		{
+			nameTest:     "unauthorized",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			action:       models.ActionFormView,
+			mockBehavior: func(ctx context.Context, workspace_id string) {},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.workspace_id)
+
+			err := p.AuthorizeWorkspace(testCase.ctx, testCase.workspace_id, testCase.action)
+
+			switch testCase.nameTest {
+			case "editor_edit", "admin_view":
+				assert.Equal(t, nil, err)
+			case "editor_manage", "not_member":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "unauthorized":
+				assert.Equal(t, errs.ErrUnauthorized, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPolicy_Authorize(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoM := mockm.NewMockRepo(ctrl)
+	mockRepoW := mockw.NewMockRepo(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	p := policy.NewPolicy(mockRepoF, mockRepoM, mockRepoW, ctxUserKey)
+
+	testTable := []struct {
+		nameTest string
+		ctx      context.Context
+		action   string
+	}{
+		{
+			nameTest: "admin_manage_users",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "7", Role: models.RoleAdmin}),
+			action:   models.ActionUserManage,
+		},
+		{
+			nameTest: "moderator_manage_users",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "6", Role: models.RoleModerator}),
+			action:   models.ActionUserManage,
+		},
+		{
+			nameTest: "unknown_role",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "5", Role: "root"}),
+			action:   models.ActionUserManage,
+		},
+		{
+			nameTest: "unauthorized",
+			ctx:      context.Background(),
+			action:   models.ActionUserManage,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			err := p.Authorize(testCase.ctx, testCase.action)
+
+			switch testCase.nameTest {
+			case "admin_manage_users":
+				assert.Equal(t, nil, err)
+			case "moderator_manage_users", "unknown_role":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "unauthorized":
+				assert.Equal(t, errs.ErrUnauthorized, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
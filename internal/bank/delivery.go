@@ -0,0 +1,12 @@
+package bank
+
+import "github.com/gin-gonic/gin"
+
+// Bank HTTP Handlers interface
+type Handlers interface {
+	Create() gin.HandlerFunc
+	GetByUser() gin.HandlerFunc
+	GetById() gin.HandlerFunc
+	Update() gin.HandlerFunc
+	Delete() gin.HandlerFunc
+}
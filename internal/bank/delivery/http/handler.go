@@ -0,0 +1,267 @@
+package http
+
+import (
+	"net/http"
+	"quizapp/internal/bank"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/questiondto"
+	"quizapp/pkg/types"
+
+	"github.com/gin-gonic/gin"
+)
+
+type bankQuestionCreatRequest struct {
+	Header  string              `json:"header" binding:"required"`
+	Type    string              `json:"type" enums:"text,single_choice,multiple_choice,scale,date,number"`
+	Options questiondto.Options `json:"options"`
+	// Drawn required question has to be answered
+	Required bool `json:"required"`
+	// Answer rules of text questions
+	Rules questiondto.Rules `json:"rules"`
+	// Correct answer value in quiz, question is not scored, if not set
+	Correct string `json:"correct"`
+	// Points for correct answer, has to be 0 for not scored question
+	Points int `json:"points" minimum:"0"`
+	// Tags to draw question by, have to be unique and not empty
+	Tags []string `json:"tags"`
+}
+
+type bankQuestionResponse struct {
+	Id       string              `json:"id"`
+	User_id  string              `json:"user_id"`
+	Header   string              `json:"header"`
+	Type     string              `json:"type"`
+	Options  questiondto.Options `json:"options"`
+	Required bool                `json:"required"`
+	Rules    questiondto.Rules   `json:"rules"`
+	Correct  string              `json:"correct,omitempty"`
+	Points   int                 `json:"points"`
+	Tags     []string            `json:"tags"`
+}
+
+type bankQuestionGetByUserResponse struct {
+	Questions []*bankQuestionResponse `json:"questions"`
+}
+
+type bankHandlers struct {
+	bankUC     bank.UseCase
+	ctxUserKey string
+}
+
+func NewBankHandlers(bankUC bank.UseCase, ctxUserKey string) bank.Handlers {
+	return &bankHandlers{
+		bankUC:     bankUC,
+		ctxUserKey: ctxUserKey,
+	}
+}
+
+// Create godoc
+// @Summary Create bank question
+// @Description Add question with header, type, type options and tags to bank of current user
+// @Tags Bank
+// @Security JWTToken
+// @Param data body bankQuestionCreatRequest true "question header, type, options and tags"
+// @Success 201 {object} bankQuestionResponse
+// @Failure 400   "Invalid json, unknown type, options or rules inconsistent with type, empty or repeated tags"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 500   "Other err"
+// @Router /bank/questions [post]
+func (h *bankHandlers) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(bankQuestionCreatRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		createdquestion, err := h.bankUC.Create(c, bankQuestionRequestToBL(request))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, bankQuestionBLToResponse(createdquestion))
+	}
+}
+
+// GetByUser godoc
+// @Summary Get bank questions
+// @Description Get questions of current user bank, optionally with given tag
+// @Tags Bank
+// @Security JWTToken
+// @Param limit query int true "limit" minimum(1)
+// @Param offset query int true "offset" minimum(0)
+// @Param tag query string false "tag"
+// @Success 200 {object} bankQuestionGetByUserResponse "Found"
+// @Failure 204 {object} bankQuestionGetByUserResponse "No questions in bank of current user"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 500   "Other err"
+// @Router /bank/questions [get]
+func (h *bankHandlers) GetByUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		limit, offset, ok := types.ValidateGetSets(c.Query("limit"), c.Query("offset"))
+		if !ok {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		foundquestions, err := h.bankUC.GetByUserId(c, currentuser.Id, c.Query("tag"), types.GetSets{
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		status := http.StatusOK
+
+		if len(foundquestions) == 0 {
+			status = http.StatusNoContent
+		}
+
+		c.JSON(status, &bankQuestionGetByUserResponse{
+			Questions: bankQuestionsBLToResponse(foundquestions),
+		})
+	}
+}
+
+// GetById godoc
+// @Summary Get bank question
+// @Description Get question of current user bank by id
+// @Tags Bank
+// @Security JWTToken
+// @Param questionid path string true "bank question id"
+// @Success 200 {object} bankQuestionResponse "Found"
+// @Failure 204   "No such question"
+// @Failure 400   "Invalid question id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Question is not in bank of current user"
+// @Failure 500   "Other err"
+// @Router /bank/questions/{questionid} [get]
+func (h *bankHandlers) GetById() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		foundquestion, err := h.bankUC.GetById(c, c.Param("questionid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, bankQuestionBLToResponse(foundquestion))
+	}
+}
+
+// Update godoc
+// @Summary Update bank question
+// @Description Update bank question with header, type, type options and tags, type is required, attempts keep questions drawn before
+// @Tags Bank
+// @Security JWTToken
+// @Param questionid path string true "bank question id"
+// @Param new body bankQuestionCreatRequest true "new header, type, options and tags"
+// @Success 200 {object} bankQuestionResponse "Updated"
+// @Failure 204   "No such question"
+// @Failure 400   "Invalid question id, unknown type, options or rules inconsistent with type, empty or repeated tags"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Question is not in bank of current user or permission denied"
+// @Failure 500   "Other err"
+// @Router /bank/questions/{questionid} [put]
+func (h *bankHandlers) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(bankQuestionCreatRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		modelBL := bankQuestionRequestToBL(request)
+		modelBL.Id = c.Param("questionid")
+
+		updatedquestion, err := h.bankUC.Update(c, modelBL)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, bankQuestionBLToResponse(updatedquestion))
+	}
+}
+
+// Delete godoc
+// @Summary Delete bank question
+// @Description Delete question from bank by id, attempts keep questions drawn before
+// @Tags Bank
+// @Security JWTToken
+// @Param questionid path string true "bank question id"
+// @Success 200   "Deleted"
+// @Failure 204   "No such question"
+// @Failure 400   "Invalid question id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Question is not in bank of current user or permission denied"
+// @Failure 500   "Other err"
+// @Router /bank/questions/{questionid} [delete]
+func (h *bankHandlers) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.bankUC.Delete(c, c.Param("questionid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+func bankQuestionRequestToBL(dto *bankQuestionCreatRequest) *models.BankQuestion {
+	return &models.BankQuestion{
+		Header:   dto.Header,
+		Type:     dto.Type,
+		Options:  questiondto.OptionsToBL(&dto.Options),
+		Required: dto.Required,
+		Rules:    questiondto.RulesToBL(&dto.Rules),
+		Correct:  dto.Correct,
+		Points:   dto.Points,
+		Tags:     dto.Tags,
+	}
+}
+
+func bankQuestionBLToResponse(modelBL *models.BankQuestion) *bankQuestionResponse {
+	return &bankQuestionResponse{
+		Id:       modelBL.Id,
+		User_id:  modelBL.User_id,
+		Header:   modelBL.Header,
+		Type:     modelBL.Type,
+		Options:  questiondto.OptionsBLToDTO(&modelBL.Options),
+		Required: modelBL.Required,
+		Rules:    questiondto.RulesBLToDTO(&modelBL.Rules),
+		Correct:  modelBL.Correct,
+		Points:   modelBL.Points,
+		Tags:     modelBL.Tags,
+	}
+}
+
+func bankQuestionsBLToResponse(questions []*models.BankQuestion) []*bankQuestionResponse {
+	if questions == nil {
+		return nil
+	}
+
+	res := make([]*bankQuestionResponse, len(questions))
+
+	for i, q := range questions {
+		res[i] = bankQuestionBLToResponse(q)
+	}
+
+	return res
+}
@@ -0,0 +1,16 @@
+package http
+
+import (
+	"quizapp/internal/bank"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Map bank routes
+func MapBankRoutes(bankGroup *gin.RouterGroup, h bank.Handlers) {
+	bankGroup.POST("", h.Create())
+	bankGroup.GET("", h.GetByUser())
+	bankGroup.GET("/:questionid", h.GetById())
+	bankGroup.PUT("/:questionid", h.Update())
+	bankGroup.DELETE("/:questionid", h.Delete())
+}
@@ -0,0 +1,48 @@
+package bank
+
+import (
+	"context"
+	"quizapp/models"
+	"quizapp/pkg/types"
+)
+
+type Repo interface {
+	// Returns created model & nil, if created.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Create(ctx context.Context, modelBL *models.BankQuestion) (*models.BankQuestion, error)
+
+	// Returns slice of user bank questions tagged with tag, all of them, if tag is empty, & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetByUserId(ctx context.Context, user_id, tag string, sets types.GetSets) ([]*models.BankQuestion, error)
+
+	// Returns found model, if get.
+	// Returns nil & ErrContentNotFound, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetById(ctx context.Context, id string) (*models.BankQuestion, error)
+
+	// Returns source model & nil, if updated.
+	// Returns nil & ErrContentNotFound, if nothing to update.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Update(ctx context.Context, modelBL *models.BankQuestion) (*models.BankQuestion, error)
+
+	// Returns nil, if deleted.
+	// Returns ErrContentNotFound, if nothing to delete.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	Delete(ctx context.Context, id string) error
+
+	// Returns up to count random user bank questions tagged with tag, any of them, if tag is empty,
+	// except questions with exclude ids & nil.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetRandom(ctx context.Context, user_id, tag string, count int, exclude []string) ([]*models.BankQuestion, error)
+}
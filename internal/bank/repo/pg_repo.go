@@ -0,0 +1,323 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/bank"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"quizapp/pkg/snapshot"
+	"quizapp/pkg/types"
+	"strconv"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type BankQuestionDB struct {
+	Id, UserId   int
+	Header, Type string
+	Options      []byte
+	Required     bool
+	Rules        []byte
+	Correct      string
+	Points       int
+	Tags         []string
+}
+
+type bankRepo struct {
+	*postgres.Postgres
+}
+
+func NewBankRepo(db *postgres.Postgres) bank.Repo {
+	return &bankRepo{db}
+}
+
+func (b *bankRepo) Create(ctx context.Context, modelBL *models.BankQuestion) (*models.BankQuestion, error) {
+	modelDB, err := bankQuestionBLToDB(modelBL)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := b.Builder.
+		Insert("bank_question_").
+		Columns("user_id_, header_, type_, options_, required_, rules_, correct_, points_, tags_").
+		Values(modelDB.UserId, modelDB.Header, modelDB.Type, string(modelDB.Options), modelDB.Required,
+			string(modelDB.Rules), modelDB.Correct, modelDB.Points, modelDB.Tags).
+		Suffix("RETURNING \"id_\"").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = b.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.Id)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return nil, errs.ErrForbidden
+		}
+
+		return nil, err
+	}
+
+	return bankQuestionDBToBL(modelDB)
+}
+
+func (b *bankRepo) GetByUserId(ctx context.Context, user_id, tag string, sets types.GetSets) ([]*models.BankQuestion, error) {
+	intuserid, err := strconv.Atoi(user_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	builder := b.Builder.
+		Select("id_, header_, type_, options_, required_, rules_, correct_, points_, tags_").
+		From("bank_question_").
+		Where(squirrel.Eq{"user_id_": intuserid})
+	if tag != "" {
+		builder = builder.Where(squirrel.Expr("? = ANY(tags_)", tag))
+	}
+
+	return b.getByUserId(ctx, intuserid, builder.
+		OrderBy("id_").
+		Limit(sets.Limit).
+		Offset(sets.Offset))
+}
+
+func (b *bankRepo) GetRandom(ctx context.Context, user_id, tag string, count int, exclude []string) ([]*models.BankQuestion, error) {
+	intuserid, err := strconv.Atoi(user_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	intexclude := make([]int, len(exclude))
+	for i, id := range exclude {
+		intexclude[i], err = strconv.Atoi(id)
+		if err != nil {
+			return nil, errs.ErrInvalidContent
+		}
+	}
+
+	builder := b.Builder.
+		Select("id_, header_, type_, options_, required_, rules_, correct_, points_, tags_").
+		From("bank_question_").
+		Where(squirrel.Eq{"user_id_": intuserid})
+	if tag != "" {
+		builder = builder.Where(squirrel.Expr("? = ANY(tags_)", tag))
+	}
+	if len(intexclude) != 0 {
+		builder = builder.Where(squirrel.NotEq{"id_": intexclude})
+	}
+
+	return b.getByUserId(ctx, intuserid, builder.
+		OrderBy("random()").
+		Limit(uint64(count)))
+}
+
+func (b *bankRepo) getByUserId(ctx context.Context, intuserid int, builder squirrel.SelectBuilder) ([]*models.BankQuestion, error) {
+	sql, args, err := builder.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := b.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.BankQuestion, 0)
+
+	for rows.Next() {
+		modelDB := BankQuestionDB{UserId: intuserid}
+
+		err = rows.Scan(&modelDB.Id, &modelDB.Header, &modelDB.Type, &modelDB.Options, &modelDB.Required, &modelDB.Rules, &modelDB.Correct, &modelDB.Points, &modelDB.Tags)
+		if err != nil {
+			return nil, err
+		}
+
+		modelBL, err := bankQuestionDBToBL(&modelDB)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, modelBL)
+	}
+
+	return res, nil
+}
+
+func (b *bankRepo) GetById(ctx context.Context, id string) (*models.BankQuestion, error) {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := b.Builder.
+		Select("user_id_, header_, type_, options_, required_, rules_, correct_, points_, tags_").
+		From("bank_question_").
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	modelDB := BankQuestionDB{Id: intid}
+	err = b.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.UserId, &modelDB.Header, &modelDB.Type, &modelDB.Options, &modelDB.Required, &modelDB.Rules, &modelDB.Correct, &modelDB.Points, &modelDB.Tags)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return bankQuestionDBToBL(&modelDB)
+}
+
+func (b *bankRepo) Update(ctx context.Context, modelBL *models.BankQuestion) (*models.BankQuestion, error) {
+	modelDB, err := bankQuestionBLToDB(modelBL)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := b.Builder.
+		Update("bank_question_").
+		Set("header_", modelDB.Header).
+		Set("type_", modelDB.Type).
+		Set("options_", string(modelDB.Options)).
+		Set("required_", modelDB.Required).
+		Set("rules_", string(modelDB.Rules)).
+		Set("correct_", modelDB.Correct).
+		Set("points_", modelDB.Points).
+		Set("tags_", modelDB.Tags).
+		Where(squirrel.Eq{"id_": modelDB.Id}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := b.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return nil, errs.ErrForbidden
+		}
+
+		return nil, err
+	}
+
+	if res.RowsAffected() == 0 {
+		return nil, errs.ErrContentNotFound
+	}
+
+	return modelBL, nil
+}
+
+func (b *bankRepo) Delete(ctx context.Context, id string) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := b.Builder.
+		Delete("bank_question_").
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := b.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func bankQuestionDBToBL(modelDB *BankQuestionDB) (*models.BankQuestion, error) {
+	options, err := snapshot.UnmarshalOptions(modelDB.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := snapshot.UnmarshalRules(modelDB.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.BankQuestion{
+		Id:       strconv.Itoa(modelDB.Id),
+		User_id:  strconv.Itoa(modelDB.UserId),
+		Header:   modelDB.Header,
+		Type:     modelDB.Type,
+		Options:  options,
+		Required: modelDB.Required,
+		Rules:    rules,
+		Correct:  modelDB.Correct,
+		Points:   modelDB.Points,
+		Tags:     modelDB.Tags,
+	}, nil
+}
+
+func bankQuestionBLToDB(modelBL *models.BankQuestion) (*BankQuestionDB, error) {
+	var (
+		err error
+		id  int
+	)
+
+	if modelBL.Id != "" {
+		id, err = strconv.Atoi(modelBL.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var uid int
+	if modelBL.User_id != "" {
+		uid, err = strconv.Atoi(modelBL.User_id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	options, err := snapshot.MarshalOptions(modelBL.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := snapshot.MarshalRules(modelBL.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	// untagged question is stored with empty array, not NULL
+	tags := modelBL.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+
+	return &BankQuestionDB{
+		Id:       id,
+		UserId:   uid,
+		Header:   modelBL.Header,
+		Type:     modelBL.Type,
+		Options:  options,
+		Required: modelBL.Required,
+		Rules:    rules,
+		Correct:  modelBL.Correct,
+		Points:   modelBL.Points,
+		Tags:     tags,
+	}, nil
+}
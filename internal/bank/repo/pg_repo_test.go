@@ -0,0 +1,628 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/bank/repo"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"quizapp/pkg/types"
+	"strconv"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/pashagolub/pgxmock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+)
+
+func TestBankRepo_Create(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewBankRepo(&db)
+
+	type mockBehavior func(ctx context.Context, question *models.BankQuestion)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		question         models.BankQuestion
+		mockBehavior     mockBehavior
+		expectedQuestion models.BankQuestion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			question: models.BankQuestion{
+				User_id: "3",
+				Header:  "sdcsd",
+				Type:    models.QuestionTypeSingleChoice,
+				Options: models.QuestionOptions{
+					Choices: []string{"a", "b"},
+				},
+				Correct: "a",
+				Points:  2,
+				Tags:    []string{"go"},
+			},
+			mockBehavior: func(ctx context.Context, question *models.BankQuestion) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_"}).AddRow(345).ToPgxRows()
+				pgxRows.Next()
+				useridint, _ := strconv.Atoi(question.User_id)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO bank_question_ (user_id_, header_, type_, options_, required_, rules_, correct_, points_, tags_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING \"id_\"", useridint, question.Header, question.Type, `{"choices":["a","b"]}`, false, "{}", "a", 2, []string{"go"}).Return(pgxRows)
+			},
+			expectedQuestion: models.BankQuestion{
+				Id:      "345",
+				User_id: "3",
+				Header:  "sdcsd",
+				Type:    models.QuestionTypeSingleChoice,
+				Options: models.QuestionOptions{
+					Choices: []string{"a", "b"},
+				},
+				Correct: "a",
+				Points:  2,
+				Tags:    []string{"go"},
+			},
+		},
+		{
+			nameTest: "ok_untagged",
+			ctx:      context.Background(),
+			question: models.BankQuestion{
+				User_id: "3",
+				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
+			},
+			mockBehavior: func(ctx context.Context, question *models.BankQuestion) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_"}).AddRow(345).ToPgxRows()
+				pgxRows.Next()
+				useridint, _ := strconv.Atoi(question.User_id)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO bank_question_ (user_id_, header_, type_, options_, required_, rules_, correct_, points_, tags_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING \"id_\"", useridint, question.Header, question.Type, "{}", false, "{}", "", 0, []string{}).Return(pgxRows)
+			},
+			expectedQuestion: models.BankQuestion{
+				Id:      "345",
+				User_id: "3",
+				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
+				Tags:    []string{},
+			},
+		},
+		{
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			question: models.BankQuestion{
+				User_id: "5r4",
+				Header:  "sdcsd",
+			},
+			mockBehavior: func(ctx context.Context, question *models.BankQuestion) {},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			question: models.BankQuestion{
+				User_id: "3",
+				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
+			},
+			mockBehavior: func(ctx context.Context, question *models.BankQuestion) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				useridint, _ := strconv.Atoi(question.User_id)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO bank_question_ (user_id_, header_, type_, options_, required_, rules_, correct_, points_, tags_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING \"id_\"", useridint, question.Header, question.Type, "{}", false, "{}", "", 0, []string{}).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.question)
+
+			got, err := r.Create(testCase.ctx, &testCase.question)
+
+			switch testCase.nameTest {
+			case "ok", "ok_untagged":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedQuestion, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestBankRepo_GetById(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewBankRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		id               string
+		mockBehavior     mockBehavior
+		expectedQuestion models.BankQuestion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"user_id_", "header_", "type_", "options_", "required_", "rules_", "correct_", "points_", "tags_"}).AddRow(3, "sdcsd", "text", []byte("{}"), true, []byte(`{"max_length":10}`), "", 0, []string{"go", "sql"}).ToPgxRows()
+				pgxRows.Next()
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, header_, type_, options_, required_, rules_, correct_, points_, tags_ FROM bank_question_ WHERE id_ = $1", idint).Return(pgxRows)
+			},
+			expectedQuestion: models.BankQuestion{
+				Id:       "345",
+				User_id:  "3",
+				Header:   "sdcsd",
+				Type:     models.QuestionTypeText,
+				Required: true,
+				Rules: models.QuestionRules{
+					Max_length: intRef(10),
+				},
+				Tags: []string{"go", "sql"},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, header_, type_, options_, required_, rules_, correct_, points_, tags_ FROM bank_question_ WHERE id_ = $1", idint).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := r.GetById(testCase.ctx, testCase.id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedQuestion, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestBankRepo_GetByUserId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewBankRepo(&db)
+
+	type mockBehavior func(ctx context.Context, user_id, tag string, sets types.GetSets)
+
+	testTable := []struct {
+		nameTest          string
+		ctx               context.Context
+		user_id, tag      string
+		sets              types.GetSets
+		mockBehavior      mockBehavior
+		expectedQuestions []*models.BankQuestion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			user_id:  "3",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, user_id, tag string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "header_", "type_", "options_", "required_", "rules_", "correct_", "points_", "tags_"}).AddRow(345, "ecefvc", "text", []byte("{}"), false, []byte("{}"), "", 0, []string{"go"}).ToPgxRows()
+				useridint, _ := strconv.Atoi(user_id)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, header_, type_, options_, required_, rules_, correct_, points_, tags_ FROM bank_question_ WHERE user_id_ = $1 ORDER BY id_ LIMIT 10 OFFSET 0", useridint).Return(pgxRows, nil)
+			},
+			expectedQuestions: []*models.BankQuestion{
+				{
+					Id:      "345",
+					User_id: "3",
+					Header:  "ecefvc",
+					Type:    models.QuestionTypeText,
+					Tags:    []string{"go"},
+				},
+			},
+		},
+		{
+			nameTest: "ok_tagged",
+			ctx:      context.Background(),
+			user_id:  "3",
+			tag:      "go",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, user_id, tag string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "header_", "type_", "options_", "required_", "rules_", "correct_", "points_", "tags_"}).AddRow(345, "ecefvc", "text", []byte("{}"), false, []byte("{}"), "", 0, []string{"go"}).ToPgxRows()
+				useridint, _ := strconv.Atoi(user_id)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, header_, type_, options_, required_, rules_, correct_, points_, tags_ FROM bank_question_ WHERE user_id_ = $1 AND $2 = ANY(tags_) ORDER BY id_ LIMIT 10 OFFSET 0", useridint, tag).Return(pgxRows, nil)
+			},
+			expectedQuestions: []*models.BankQuestion{
+				{
+					Id:      "345",
+					User_id: "3",
+					Header:  "ecefvc",
+					Type:    models.QuestionTypeText,
+					Tags:    []string{"go"},
+				},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			user_id:      "5r4",
+			mockBehavior: func(ctx context.Context, user_id, tag string, sets types.GetSets) {},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			user_id:  "3",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, user_id, tag string, sets types.GetSets) {
+				useridint, _ := strconv.Atoi(user_id)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, header_, type_, options_, required_, rules_, correct_, points_, tags_ FROM bank_question_ WHERE user_id_ = $1 ORDER BY id_ LIMIT 10 OFFSET 0", useridint).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.user_id, testCase.tag, testCase.sets)
+
+			got, err := r.GetByUserId(testCase.ctx, testCase.user_id, testCase.tag, testCase.sets)
+
+			switch testCase.nameTest {
+			case "ok", "ok_tagged":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedQuestions, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestBankRepo_GetRandom(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewBankRepo(&db)
+
+	type mockBehavior func(ctx context.Context, user_id, tag string, count int, exclude []string)
+
+	testTable := []struct {
+		nameTest          string
+		ctx               context.Context
+		user_id, tag      string
+		count             int
+		exclude           []string
+		mockBehavior      mockBehavior
+		expectedQuestions []*models.BankQuestion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			user_id:  "3",
+			count:    2,
+			mockBehavior: func(ctx context.Context, user_id, tag string, count int, exclude []string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "header_", "type_", "options_", "required_", "rules_", "correct_", "points_", "tags_"}).AddRow(346, "ty", "single_choice", []byte(`{"choices":["a","b"]}`), false, []byte("{}"), "b", 1, []string{}).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, "SELECT id_, header_, type_, options_, required_, rules_, correct_, points_, tags_ FROM bank_question_ WHERE user_id_ = $1 ORDER BY random() LIMIT 2", 3).Return(pgxRows, nil)
+			},
+			expectedQuestions: []*models.BankQuestion{
+				{
+					Id:      "346",
+					User_id: "3",
+					Header:  "ty",
+					Type:    models.QuestionTypeSingleChoice,
+					Options: models.QuestionOptions{
+						Choices: []string{"a", "b"},
+					},
+					Correct: "b",
+					Points:  1,
+					Tags:    []string{},
+				},
+			},
+		},
+		{
+			nameTest: "ok_tagged_excluding",
+			ctx:      context.Background(),
+			user_id:  "3",
+			tag:      "go",
+			count:    1,
+			exclude:  []string{"345", "347"},
+			mockBehavior: func(ctx context.Context, user_id, tag string, count int, exclude []string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "header_", "type_", "options_", "required_", "rules_", "correct_", "points_", "tags_"}).AddRow(346, "ty", "text", []byte("{}"), false, []byte("{}"), "", 0, []string{"go"}).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, "SELECT id_, header_, type_, options_, required_, rules_, correct_, points_, tags_ FROM bank_question_ WHERE user_id_ = $1 AND $2 = ANY(tags_) AND id_ NOT IN ($3,$4) ORDER BY random() LIMIT 1", 3, tag, 345, 347).Return(pgxRows, nil)
+			},
+			expectedQuestions: []*models.BankQuestion{
+				{
+					Id:      "346",
+					User_id: "3",
+					Header:  "ty",
+					Type:    models.QuestionTypeText,
+					Tags:    []string{"go"},
+				},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs_user_id",
+			ctx:          context.Background(),
+			user_id:      "5r4",
+			count:        1,
+			mockBehavior: func(ctx context.Context, user_id, tag string, count int, exclude []string) {},
+		},
+		{
+			nameTest:     "invalid_inputs_exclude",
+			ctx:          context.Background(),
+			user_id:      "3",
+			count:        1,
+			exclude:      []string{"5r4"},
+			mockBehavior: func(ctx context.Context, user_id, tag string, count int, exclude []string) {},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			user_id:  "3",
+			count:    2,
+			mockBehavior: func(ctx context.Context, user_id, tag string, count int, exclude []string) {
+				mockPool.EXPECT().Query(ctx, "SELECT id_, header_, type_, options_, required_, rules_, correct_, points_, tags_ FROM bank_question_ WHERE user_id_ = $1 ORDER BY random() LIMIT 2", 3).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.user_id, testCase.tag, testCase.count, testCase.exclude)
+
+			got, err := r.GetRandom(testCase.ctx, testCase.user_id, testCase.tag, testCase.count, testCase.exclude)
+
+			switch testCase.nameTest {
+			case "ok", "ok_tagged_excluding":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedQuestions, got)
+			case "invalid_inputs_user_id", "invalid_inputs_exclude":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestBankRepo_Update(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewBankRepo(&db)
+
+	type mockBehavior func(ctx context.Context, question *models.BankQuestion)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		question         models.BankQuestion
+		mockBehavior     mockBehavior
+		expectedQuestion models.BankQuestion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			question: models.BankQuestion{
+				Id:      "345",
+				User_id: "3",
+				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
+				Tags:    []string{"go"},
+			},
+			mockBehavior: func(ctx context.Context, question *models.BankQuestion) {
+				idint, _ := strconv.Atoi(question.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE bank_question_ SET header_ = $1, type_ = $2, options_ = $3, required_ = $4, rules_ = $5, correct_ = $6, points_ = $7, tags_ = $8 WHERE id_ = $9", question.Header, question.Type, "{}", false, "{}", "", 0, []string{"go"}, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+			expectedQuestion: models.BankQuestion{
+				Id:      "345",
+				User_id: "3",
+				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
+				Tags:    []string{"go"},
+			},
+		},
+		{
+			nameTest: "invalid_inputs_id",
+			ctx:      context.Background(),
+			question: models.BankQuestion{
+				Id:      "5r4",
+				User_id: "3",
+				Header:  "sdcsd",
+			},
+			mockBehavior: func(ctx context.Context, question *models.BankQuestion) {},
+		},
+		{
+			nameTest: "no_question_to_update",
+			ctx:      context.Background(),
+			question: models.BankQuestion{
+				Id:      "345",
+				User_id: "3",
+				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
+				Tags:    []string{"go"},
+			},
+			mockBehavior: func(ctx context.Context, question *models.BankQuestion) {
+				idint, _ := strconv.Atoi(question.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE bank_question_ SET header_ = $1, type_ = $2, options_ = $3, required_ = $4, rules_ = $5, correct_ = $6, points_ = $7, tags_ = $8 WHERE id_ = $9", question.Header, question.Type, "{}", false, "{}", "", 0, []string{"go"}, idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+		{
+			nameTest: "exec_error",
+			ctx:      context.Background(),
+			question: models.BankQuestion{
+				Id:      "345",
+				User_id: "3",
+				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
+				Tags:    []string{"go"},
+			},
+			mockBehavior: func(ctx context.Context, question *models.BankQuestion) {
+				idint, _ := strconv.Atoi(question.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE bank_question_ SET header_ = $1, type_ = $2, options_ = $3, required_ = $4, rules_ = $5, correct_ = $6, points_ = $7, tags_ = $8 WHERE id_ = $9", question.Header, question.Type, "{}", false, "{}", "", 0, []string{"go"}, idint).Return(nil, errors.New("exec_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.question)
+
+			got, err := r.Update(testCase.ctx, &testCase.question)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedQuestion, *got)
+			case "invalid_inputs_id":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_question_to_update":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			case "exec_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestBankRepo_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewBankRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM bank_question_ WHERE id_ = $1", 345).Return(pgxmock.NewResult("DELETE", 1), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "no_question_to_delete",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM bank_question_ WHERE id_ = $1", 345).Return(pgxmock.NewResult("DELETE", 0), nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			err := r.Delete(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func intRef(i int) *int {
+	return &i
+}
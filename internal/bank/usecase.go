@@ -0,0 +1,50 @@
+package bank
+
+import (
+	"context"
+	"quizapp/models"
+	"quizapp/pkg/types"
+)
+
+type UseCase interface {
+	// Adds question to bank of current user.
+	// Returns created model & nil, if created.
+	// Returns nil & ErrInvalidContent, if invalid inputs, options, rules or scoring are inconsistent with type
+	// or tags are empty or repeated.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Create(ctx context.Context, model *models.BankQuestion) (*models.BankQuestion, error)
+
+	// Returns slice of user bank questions tagged with tag, all of them, if tag is empty, & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetByUserId(ctx context.Context, user_id, tag string, sets types.GetSets) ([]*models.BankQuestion, error)
+
+	// Returns found model & nil, if get.
+	// Returns nil & ErrContentNotFound, if no such model.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if question is not in bank of user.
+	// Returns nil & other err else.
+	GetById(ctx context.Context, id string) (*models.BankQuestion, error)
+
+	// Returns source model & nil, if updated.
+	// Returns nil & ErrContentNotFound, if no such model.
+	// Returns nil & ErrInvalidContent, if invalid inputs, options, rules or scoring are inconsistent with type
+	// or tags are empty or repeated.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if question is not in bank of user or permission denied.
+	// Returns nil & other err else.
+	Update(ctx context.Context, model *models.BankQuestion) (*models.BankQuestion, error)
+
+	// Deletes question from bank, attempts keep questions drawn from it.
+	// Returns nil, if deleted.
+	// Returns ErrContentNotFound, if no such model.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if question is not in bank of user or permission denied.
+	// Returns other errors else.
+	Delete(ctx context.Context, id string) error
+}
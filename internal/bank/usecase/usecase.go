@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"context"
+	"quizapp/internal/bank"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/types"
+)
+
+type bankUseCase struct {
+	bankRepo   bank.Repo
+	ctxUserKey string
+}
+
+func NewBankUseCase(bankRepo bank.Repo, ctxUserKey string) bank.UseCase {
+	return &bankUseCase{
+		bankRepo:   bankRepo,
+		ctxUserKey: ctxUserKey,
+	}
+}
+
+func (b *bankUseCase) Create(ctx context.Context, model *models.BankQuestion) (*models.BankQuestion, error) {
+	// untyped questions are free text
+	if model.Type == "" {
+		model.Type = models.QuestionTypeText
+	}
+
+	err := validateBankQuestion(model)
+	if err != nil {
+		return nil, err
+	}
+
+	currentuser, ok := ctx.Value(b.ctxUserKey).(*models.User)
+	if !ok {
+		return nil, errs.ErrUnauthorized
+	}
+
+	// question is added to bank of current user only
+	model.User_id = currentuser.Id
+
+	return b.bankRepo.Create(ctx, model)
+}
+
+func (b *bankUseCase) GetByUserId(ctx context.Context, user_id, tag string, sets types.GetSets) ([]*models.BankQuestion, error) {
+	return b.bankRepo.GetByUserId(ctx, user_id, tag, sets)
+}
+
+func (b *bankUseCase) GetById(ctx context.Context, id string) (*models.BankQuestion, error) {
+	return b.getOwned(ctx, id)
+}
+
+func (b *bankUseCase) Update(ctx context.Context, model *models.BankQuestion) (*models.BankQuestion, error) {
+	err := validateBankQuestion(model)
+	if err != nil {
+		return nil, err
+	}
+
+	foundquestion, err := b.getOwned(ctx, model.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	model.User_id = foundquestion.User_id
+
+	_, err = b.bankRepo.Update(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	return model, nil
+}
+
+func (b *bankUseCase) Delete(ctx context.Context, id string) error {
+	_, err := b.getOwned(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	return b.bankRepo.Delete(ctx, id)
+}
+
+// Returns bank question of current user.
+func (b *bankUseCase) getOwned(ctx context.Context, id string) (*models.BankQuestion, error) {
+	currentuser, ok := ctx.Value(b.ctxUserKey).(*models.User)
+	if !ok {
+		return nil, errs.ErrUnauthorized
+	}
+
+	foundquestion, err := b.bankRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if foundquestion.User_id != currentuser.Id {
+		return nil, errs.ErrForbidden
+	}
+
+	return foundquestion, nil
+}
+
+// type is required on update, as for form questions
+func validateBankQuestion(model *models.BankQuestion) error {
+	if !model.Validate() {
+		return errs.ErrInvalidContent
+	}
+
+	return nil
+}
@@ -0,0 +1,308 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/bank/mock"
+	"quizapp/internal/bank/usecase"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBankUseCase_Create(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewBankUseCase(mockRepo, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.BankQuestion)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		model            models.BankQuestion
+		mockBehavior     mockBehavior
+		expectedQuestion *models.BankQuestion
+		expectedErr      error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model:    models.BankQuestion{Header: "q", Tags: []string{"go"}},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {
+				mockRepo.EXPECT().Create(ctx, &models.BankQuestion{User_id: "3", Header: "q", Type: models.QuestionTypeText, Tags: []string{"go"}}).
+					Return(&models.BankQuestion{Id: "1", User_id: "3", Header: "q", Type: models.QuestionTypeText, Tags: []string{"go"}}, nil)
+			},
+			expectedQuestion: &models.BankQuestion{Id: "1", User_id: "3", Header: "q", Type: models.QuestionTypeText, Tags: []string{"go"}},
+		},
+		{
+			nameTest: "other_user_id_is_ignored",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model:    models.BankQuestion{User_id: "4", Header: "q"},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {
+				mockRepo.EXPECT().Create(ctx, &models.BankQuestion{User_id: "3", Header: "q", Type: models.QuestionTypeText}).
+					Return(&models.BankQuestion{Id: "1", User_id: "3", Header: "q", Type: models.QuestionTypeText}, nil)
+			},
+			expectedQuestion: &models.BankQuestion{Id: "1", User_id: "3", Header: "q", Type: models.QuestionTypeText},
+		},
+		{
+			nameTest:     "repeated_tags",
+			ctx:          context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model:        models.BankQuestion{Header: "q", Tags: []string{"go", "go"}},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "choices_of_text_question",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model: models.BankQuestion{
+				Header:  "q",
+				Options: models.QuestionOptions{Choices: []string{"a"}},
+			},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "unauthorized",
+			ctx:          context.Background(),
+			model:        models.BankQuestion{Header: "q"},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {},
+			expectedErr:  errs.ErrUnauthorized,
+		},
+		{
+			nameTest: "repo_error",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model:    models.BankQuestion{Header: "q"},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {
+				mockRepo.EXPECT().Create(ctx, model).Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.Create(testCase.ctx, &testCase.model)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedQuestion, got)
+		})
+	}
+}
+
+func TestBankUseCase_GetById(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewBankUseCase(mockRepo, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		id               string
+		mockBehavior     mockBehavior
+		expectedQuestion *models.BankQuestion
+		expectedErr      error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.BankQuestion{Id: "1", User_id: "3", Header: "q"}, nil)
+			},
+			expectedQuestion: &models.BankQuestion{Id: "1", User_id: "3", Header: "q"},
+		},
+		{
+			nameTest: "question_of_other_user",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.BankQuestion{Id: "1", User_id: "4", Header: "q"}, nil)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest:     "unauthorized",
+			ctx:          context.Background(),
+			id:           "1",
+			mockBehavior: func(ctx context.Context, id string) {},
+			expectedErr:  errs.ErrUnauthorized,
+		},
+		{
+			nameTest: "not_found",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(nil, errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := uc.GetById(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedQuestion, got)
+		})
+	}
+}
+
+func TestBankUseCase_Update(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewBankUseCase(mockRepo, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.BankQuestion)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		model            models.BankQuestion
+		mockBehavior     mockBehavior
+		expectedQuestion *models.BankQuestion
+		expectedErr      error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model:    models.BankQuestion{Id: "1", Header: "new", Type: models.QuestionTypeText, Tags: []string{"sql"}},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(&models.BankQuestion{Id: "1", User_id: "3", Header: "q"}, nil)
+				mockRepo.EXPECT().Update(ctx, &models.BankQuestion{Id: "1", User_id: "3", Header: "new", Type: models.QuestionTypeText, Tags: []string{"sql"}}).
+					Return(&models.BankQuestion{Id: "1", User_id: "3", Header: "new", Type: models.QuestionTypeText, Tags: []string{"sql"}}, nil)
+			},
+			expectedQuestion: &models.BankQuestion{Id: "1", User_id: "3", Header: "new", Type: models.QuestionTypeText, Tags: []string{"sql"}},
+		},
+		{
+			nameTest:     "no_type",
+			ctx:          context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model:        models.BankQuestion{Id: "1", Header: "new"},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "empty_tag",
+			ctx:          context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model:        models.BankQuestion{Id: "1", Header: "new", Type: models.QuestionTypeText, Tags: []string{""}},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "question_of_other_user",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model:    models.BankQuestion{Id: "1", Header: "new", Type: models.QuestionTypeText},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(&models.BankQuestion{Id: "1", User_id: "4", Header: "q"}, nil)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "no_question_to_update",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			model:    models.BankQuestion{Id: "1", Header: "new", Type: models.QuestionTypeText},
+			mockBehavior: func(ctx context.Context, model *models.BankQuestion) {
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(&models.BankQuestion{Id: "1", User_id: "3", Header: "q"}, nil)
+				mockRepo.EXPECT().Update(ctx, model).Return(nil, errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.Update(testCase.ctx, &testCase.model)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedQuestion, got)
+		})
+	}
+}
+
+func TestBankUseCase_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewBankUseCase(mockRepo, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.BankQuestion{Id: "1", User_id: "3"}, nil)
+				mockRepo.EXPECT().Delete(ctx, id).Return(nil)
+			},
+		},
+		{
+			nameTest: "question_of_other_user",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "3"}),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.BankQuestion{Id: "1", User_id: "4"}, nil)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest:     "unauthorized",
+			ctx:          context.Background(),
+			id:           "1",
+			mockBehavior: func(ctx context.Context, id string) {},
+			expectedErr:  errs.ErrUnauthorized,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			err := uc.Delete(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -9,4 +9,19 @@ type Handlers interface {
 	Update() gin.HandlerFunc
 	GetById() gin.HandlerFunc
 	GetByUser() gin.HandlerFunc
+	Publish() gin.HandlerFunc
+	Close() gin.HandlerFunc
+	Reopen() gin.HandlerFunc
+	Archive() gin.HandlerFunc
+	SetSchedule() gin.HandlerFunc
+	SetLimits() gin.HandlerFunc
+	SetAccess() gin.HandlerFunc
+	SetQuiz() gin.HandlerFunc
+	SetDraws() gin.HandlerFunc
+	PublicGetById() gin.HandlerFunc
+	Export() gin.HandlerFunc
+	Import() gin.HandlerFunc
+	Clone() gin.HandlerFunc
+	SetTemplate() gin.HandlerFunc
+	GetTemplates() gin.HandlerFunc
 }
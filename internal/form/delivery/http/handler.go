@@ -5,14 +5,22 @@ import (
 	"quizapp/internal/form"
 	"quizapp/models"
 	"quizapp/pkg/errs"
+	"quizapp/pkg/questiondto"
 	"quizapp/pkg/types"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
 type formCreatRequest struct {
-	Title       string `json:"title" binding:"required"`
-	Description string `json:"description" binding:"required"`
+	Title       string     `json:"title" binding:"required"`
+	Description string     `json:"description" binding:"required"`
+	Opens_at    *time.Time `json:"opens_at"`
+	Closes_at   *time.Time `json:"closes_at"`
+	Access      string     `json:"access" enums:"authenticated,anonymous,link"`
+	// Workspace to create form in, form is personal, if not set
+	Workspace_id string `json:"workspace_id"`
+	formLimitsRequest
 }
 
 type formUpdRequest struct {
@@ -20,11 +28,149 @@ type formUpdRequest struct {
 	Description *string `json:"description"`
 }
 
+type formScheduleRequest struct {
+	Opens_at  *time.Time `json:"opens_at"`
+	Closes_at *time.Time `json:"closes_at"`
+}
+
+type formLimitsRequest struct {
+	One_response  bool `json:"one_response"`
+	Max_responses int  `json:"max_responses" minimum:"0"`
+	Edit_window   int  `json:"edit_window" minimum:"0"` // seconds
+	// Time to submit answers after attempt start, attempts are required if set
+	Time_limit int `json:"time_limit" minimum:"0"` // seconds
+}
+
+type formTemplateRequest struct {
+	Is_template bool `json:"is_template"`
+}
+
+type formAccessRequest struct {
+	Access string `json:"access" binding:"required" enums:"authenticated,anonymous,link"`
+}
+
+type formQuizRequest struct {
+	Is_quiz bool `json:"is_quiz"`
+	// When respondents see correct answers in their results
+	Show_correct string `json:"show_correct" binding:"required" enums:"never,after_submission,after_close"`
+}
+
+type drawDTO struct {
+	// Tag of bank questions to draw, any bank question is drawn, if not set
+	Tag   string `json:"tag,omitempty"`
+	Count int    `json:"count" minimum:"1"`
+}
+
+type formDrawsRequest struct {
+	// Rules to draw random questions of owner bank into each attempt, attempts are required if set
+	Draws []*drawDTO `json:"draws"`
+}
+
 type formResponse struct {
+	Id           string     `json:"id"`
+	User_id      string     `json:"user_id"`
+	Workspace_id string     `json:"workspace_id,omitempty"`
+	Title        string     `json:"title,omitempty"`
+	Description  string     `json:"description,omitempty"`
+	Status       string     `json:"status,omitempty" enums:"draft,open,closed,archived"`
+	Opens_at     *time.Time `json:"opens_at,omitempty"`
+	Closes_at    *time.Time `json:"closes_at,omitempty"`
+
+	One_response  bool `json:"one_response"`
+	Max_responses int  `json:"max_responses"`
+	Edit_window   int  `json:"edit_window"`
+	Time_limit    int  `json:"time_limit"`
+
+	Access       string `json:"access,omitempty" enums:"authenticated,anonymous,link"`
+	Access_token string `json:"access_token,omitempty"`
+
+	Is_template bool `json:"is_template"`
+
+	Is_quiz      bool   `json:"is_quiz"`
+	Show_correct string `json:"show_correct,omitempty" enums:"never,after_submission,after_close"`
+
+	Draws []*drawDTO `json:"draws,omitempty"`
+}
+
+type conditionDTO struct {
+	Question_id string `json:"question_id"`
+	Op          string `json:"op" enums:"equals,not_equals,answered,not_answered"`
+	Value       string `json:"value,omitempty"`
+}
+
+type questionResponse struct {
+	Id         string              `json:"id"`
+	Header     string              `json:"header"`
+	Type       string              `json:"type"`
+	Options    questiondto.Options `json:"options"`
+	Section_id string              `json:"section_id,omitempty"`
+	// Question is shown only when condition on earlier answer holds
+	Condition *conditionDTO `json:"condition,omitempty"`
+	// Shown required question has to be answered
+	Required bool              `json:"required"`
+	Rules    questiondto.Rules `json:"rules"`
+	// Points for correct answer in quiz, correct answers are not public
+	Points int `json:"points,omitempty"`
+}
+
+type sectionResponse struct {
 	Id          string `json:"id"`
-	User_id     string `json:"user_id"`
-	Title       string `json:"title,omitempty"`
-	Description string `json:"description,omitempty"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+	// Section is shown only when condition on earlier answer holds
+	Condition *conditionDTO `json:"condition,omitempty"`
+}
+
+type formPublicResponse struct {
+	Id          string              `json:"id"`
+	Version_id  string              `json:"version_id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Status      string              `json:"status" enums:"open,closed"`
+	Opens_at    *time.Time          `json:"opens_at,omitempty"`
+	Closes_at   *time.Time          `json:"closes_at,omitempty"`
+	Questions   []*questionResponse `json:"questions"`
+	Sections    []*sectionResponse  `json:"sections"`
+}
+
+// Portable form definition, schema_version is raised on incompatible changes
+type formDocument struct {
+	Schema_version int                     `json:"schema_version" binding:"required"`
+	Form           formDocumentForm        `json:"form"`
+	Sections       []*formDocumentSection  `json:"sections" binding:"dive"`
+	Questions      []*formDocumentQuestion `json:"questions" binding:"dive"`
+}
+
+type formDocumentForm struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	Access      string `json:"access,omitempty" enums:"authenticated,anonymous,link"`
+	formLimitsRequest
+	Is_quiz      bool       `json:"is_quiz,omitempty"`
+	Show_correct string     `json:"show_correct,omitempty" enums:"never,after_submission,after_close"`
+	Draws        []*drawDTO `json:"draws,omitempty"`
+}
+
+type formDocumentSection struct {
+	// Id of section in document, questions refer to section by it
+	Id          string        `json:"id" binding:"required"`
+	Title       string        `json:"title" binding:"required"`
+	Description string        `json:"description,omitempty"`
+	Condition   *conditionDTO `json:"condition,omitempty"`
+}
+
+type formDocumentQuestion struct {
+	// Id of question in document, conditions refer to question by it
+	Id         string              `json:"id,omitempty"`
+	Section_id string              `json:"section_id,omitempty"`
+	Condition  *conditionDTO       `json:"condition,omitempty"`
+	Header     string              `json:"header" binding:"required"`
+	Type       string              `json:"type" enums:"text,single_choice,multiple_choice,scale,date,number"`
+	Options    questiondto.Options `json:"options"`
+	Required   bool                `json:"required,omitempty"`
+	Rules      questiondto.Rules   `json:"rules"`
+	Correct    string              `json:"correct,omitempty"`
+	Points     int                 `json:"points,omitempty" minimum:"0"`
 }
 
 type formGetByUserIdResponse struct {
@@ -45,15 +191,15 @@ func NewFormHandlers(formUC form.UseCase, ctxUserKey string) form.Handlers {
 
 // Create godoc
 // @Summary Create form
-// @Description Create new form with title and description
+// @Description Create new draft form with title, description, optional answering period, response limits and access (authenticated by default). Form is created in workspace, if it is set, editors and owners of workspace create forms in it
 // @Tags Forms
 // @Security JWTToken
 // @Accept json
-// @Param data body formCreatRequest true "form title and description"
+// @Param data body formCreatRequest true "form title, description, opens_at, closes_at, limits and access"
 // @Success 201 {object} formResponse
-// @Failure 400   "Invalid json"
+// @Failure 400   "Invalid json, unknown access, form closes before it opens or negative limits"
 // @Failure 401   "Unauthorized"
-// @Failure 403   "Permission denied"
+// @Failure 403   "User may not create forms in workspace or permission denied"
 // @Failure 500   "Other err"
 // @Router /forms [post]
 func (h *formHandlers) Create() gin.HandlerFunc {
@@ -112,14 +258,15 @@ func (h *formHandlers) Delete() gin.HandlerFunc {
 
 // GetByUser godoc
 // @Summary Get forms
-// @Description Get forms owned by current user
+// @Description Get forms owned by current user and forms of workspaces user is member of, optionally with given status. Access token is returned for forms user may edit only
 // @Tags Forms
 // @Security JWTToken
 // @Param limit query int true "limit" minimum(1)
 // @Param offset query int true "offset" minimum(0)
+// @Param status query string false "status" Enums(draft, open, closed, archived)
 // @Success 200 {object} formGetByUserIdResponse "Found"
-// @Failure 204 {object} formGetByUserIdResponse "No forms owned by current user"
-// @Failure 400   "Invalid limit and/or offset"
+// @Failure 204 {object} formGetByUserIdResponse "No forms of current user"
+// @Failure 400   "Invalid limit, offset and/or status"
 // @Failure 401   "Unauthorized"
 // @Failure 500   "Other err"
 // @Router /forms [get]
@@ -137,7 +284,7 @@ func (h *formHandlers) GetByUser() gin.HandlerFunc {
 			return
 		}
 
-		foundforms, err := h.formUC.GetByUserId(c, currentuser.Id, types.GetSets{
+		foundforms, err := h.formUC.GetByUserId(c, currentuser.Id, c.Query("status"), types.GetSets{
 			Limit:  limit,
 			Offset: offset,
 		})
@@ -231,19 +378,639 @@ func (h *formHandlers) GetById() gin.HandlerFunc {
 	}
 }
 
+// Publish godoc
+// @Summary Publish form
+// @Description Open draft form for answers. Form must have published version
+// @Tags Forms
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Success 200 {object} formResponse "Published"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 409   "Form is not draft or has no published version"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/publish [post]
+func (h *formHandlers) Publish() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		updatedform, err := h.formUC.Publish(c, c.Param("formid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// Close godoc
+// @Summary Close form
+// @Description Stop accepting answers to open form
+// @Tags Forms
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Success 200 {object} formResponse "Closed"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 409   "Form is not open"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/close [post]
+func (h *formHandlers) Close() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		updatedform, err := h.formUC.Close(c, c.Param("formid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// Reopen godoc
+// @Summary Reopen form
+// @Description Accept answers to closed form again
+// @Tags Forms
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Success 200 {object} formResponse "Reopened"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 409   "Form is not closed"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/reopen [post]
+func (h *formHandlers) Reopen() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		updatedform, err := h.formUC.Reopen(c, c.Param("formid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// Archive godoc
+// @Summary Archive form
+// @Description Archive form, archived form never accepts answers
+// @Tags Forms
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Success 200 {object} formResponse "Archived"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 409   "Form is already archived"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/archive [post]
+func (h *formHandlers) Archive() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		updatedform, err := h.formUC.Archive(c, c.Param("formid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// SetSchedule godoc
+// @Summary Set form schedule
+// @Description Set period when open form accepts answers, omitted bound is cleared
+// @Tags Forms
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param data body formScheduleRequest true "opens_at and closes_at"
+// @Success 200 {object} formResponse "Updated"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id, json or form closes before it opens"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/schedule [put]
+func (h *formHandlers) SetSchedule() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(formScheduleRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		updatedform, err := h.formUC.SetSchedule(c, &models.Form{
+			Id:        c.Param("formid"),
+			Opens_at:  request.Opens_at,
+			Closes_at: request.Closes_at,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// SetLimits godoc
+// @Summary Set form response limits
+// @Description Set one response per user, max total responses (0 is unlimited) and edit window in seconds (0 disables editing). Form is closed, when it gets max responses
+// @Tags Forms
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param data body formLimitsRequest true "one_response, max_responses and edit_window"
+// @Success 200 {object} formResponse "Updated"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id, json or negative limits"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/limits [put]
+func (h *formHandlers) SetLimits() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(formLimitsRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		model := &models.Form{
+			Id: c.Param("formid"),
+		}
+		formLimitsRequestToBL(request, model)
+
+		updatedform, err := h.formUC.SetLimits(c, model)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// SetAccess godoc
+// @Summary Set form access
+// @Description Set who may answer form: authenticated users, anyone or holders of link token. New link token is generated each time link access is set
+// @Tags Forms
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param data body formAccessRequest true "access"
+// @Success 200 {object} formResponse "Updated"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id, json or access"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/access [put]
+func (h *formHandlers) SetAccess() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(formAccessRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		updatedform, err := h.formUC.SetAccess(c, &models.Form{
+			Id:     c.Param("formid"),
+			Access: request.Access,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// SetQuiz godoc
+// @Summary Set form quiz mode
+// @Description Make form a quiz with scored pool answers or a plain form, and set when respondents see correct answers. Pool answers are scored only, if created while form is quiz
+// @Tags Forms
+// @Security JWTToken
+// @Accept json
+// @Param formid path string true "form id"
+// @Param data body formQuizRequest true "quiz mode"
+// @Success 200 {object} formResponse "Updated"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id, json or correct answers visibility"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not the form owner or permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/quiz [put]
+func (h *formHandlers) SetQuiz() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(formQuizRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		updatedform, err := h.formUC.SetQuiz(c, &models.Form{
+			Id:           c.Param("formid"),
+			Is_quiz:      request.Is_quiz,
+			Show_correct: request.Show_correct,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// SetDraws godoc
+// @Summary Set form draws
+// @Description Set rules to draw random questions of owner bank by tags into each attempt, questions and their choices are shuffled per attempt. Attempts started before keep their questions
+// @Tags Forms
+// @Security JWTToken
+// @Accept json
+// @Param formid path string true "form id"
+// @Param data body formDrawsRequest true "draws, empty to draw nothing"
+// @Success 200 {object} formResponse "Updated"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id or json, count of draw is not positive or tags of draws repeat"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not the form owner or permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/draws [put]
+func (h *formHandlers) SetDraws() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(formDrawsRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		updatedform, err := h.formUC.SetDraws(c, &models.Form{
+			Id:    c.Param("formid"),
+			Draws: drawsRequestToBL(request.Draws),
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// PublicGetById godoc
+// @Summary Get form as guest
+// @Description Get open or closed form with questions of latest published version without registration, if form is anonymous or link token is valid
+// @Tags Public
+// @Param formid path string true "form id"
+// @Param token query string false "secret of form link"
+// @Success 200 {object} formPublicResponse "Found"
+// @Failure 204   "No such form or form is not published"
+// @Failure 400   "Invalid id"
+// @Failure 401   "Form is accessible to authenticated users only"
+// @Failure 403   "Invalid link token"
+// @Failure 500   "Other err"
+// @Router /public/forms/{formid} [get]
+func (h *formHandlers) PublicGetById() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		foundform, latest, err := h.formUC.GetPublic(c, c.Param("formid"), c.Query("token"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formPublicBLToResponse(foundform, latest))
+	}
+}
+
+// Export godoc
+// @Summary Export form
+// @Description Export form metadata, response limits, access, draws, sections and questions with conditions as portable JSON document
+// @Tags Forms
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Success 200 {object} formDocument
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner nor moderator or admin"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/export [get]
+func (h *formHandlers) Export() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		document, err := h.formUC.Export(c, c.Param("formid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formDocumentBLToDTO(document))
+	}
+}
+
+// Import godoc
+// @Summary Import form
+// @Description Create new draft form of current user with sections and questions from JSON document made by export. Ids in document are local, created sections and questions get new ids
+// @Tags Forms
+// @Security JWTToken
+// @Accept json
+// @Param data body formDocument true "form document"
+// @Success 201 {object} formResponse
+// @Failure 400   "Invalid json, unsupported schema version, invalid form, sections, questions or references between them"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/import [post]
+func (h *formHandlers) Import() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		request := new(formDocument)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		document := formDocumentDTOToBL(request)
+		document.Form.User_id = currentuser.Id
+
+		createdform, err := h.formUC.Import(c, document)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, formBLToResponse(createdform))
+	}
+}
+
+// Clone godoc
+// @Summary Clone form
+// @Description Copy template form or form visible to current user with its sections and questions as new draft form of current user. Answering period, versions and answers are not copied
+// @Tags Forms
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Success 201 {object} formResponse
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Form is not template and user is neither the form owner or member nor moderator or admin"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/clone [post]
+func (h *formHandlers) Clone() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		createdform, err := h.formUC.Clone(c, c.Param("formid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, formBLToResponse(createdform))
+	}
+}
+
+// SetTemplate godoc
+// @Summary Set form template flag
+// @Description Mark form as template listed to all users and clonable by anyone, or unmark it
+// @Tags Forms
+// @Security JWTToken
+// @Accept json
+// @Param data body formTemplateRequest true "template flag"
+// @Param formid path string true "form id"
+// @Success 200 {object} formResponse "Set"
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid json or id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not the form owner or permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/template [put]
+func (h *formHandlers) SetTemplate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(formTemplateRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		updatedform, err := h.formUC.SetTemplate(c, c.Param("formid"), request.Is_template)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, formBLToResponse(updatedform))
+	}
+}
+
+// GetTemplates godoc
+// @Summary Get templates
+// @Description Get template forms of all users
+// @Tags Forms
+// @Security JWTToken
+// @Param limit query int true "limit" minimum(1)
+// @Param offset query int true "offset" minimum(0)
+// @Success 200 {object} formGetByUserIdResponse "Found"
+// @Failure 204 {object} formGetByUserIdResponse "No templates"
+// @Failure 400   "Invalid limit and/or offset"
+// @Failure 401   "Unauthorized"
+// @Failure 500   "Other err"
+// @Router /forms/templates [get]
+func (h *formHandlers) GetTemplates() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, offset, ok := types.ValidateGetSets(c.Query("limit"), c.Query("offset"))
+		if !ok {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		foundforms, err := h.formUC.GetTemplates(c, types.GetSets{
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		status := http.StatusOK
+
+		if len(foundforms) == 0 {
+			status = http.StatusNoContent
+		}
+
+		c.JSON(status, &formGetByUserIdResponse{
+			Forms: formsBLToResponse(foundforms),
+		})
+	}
+}
+
 func formCreatRequestToBL(dto *formCreatRequest) *models.Form {
-	return &models.Form{
+	res := &models.Form{
 		Title:       dto.Title,
 		Description: dto.Description,
+		Opens_at:    dto.Opens_at,
+		Closes_at:   dto.Closes_at,
+		Access:      dto.Access,
+
+		Workspace_id: dto.Workspace_id,
 	}
+	formLimitsRequestToBL(&dto.formLimitsRequest, res)
+
+	return res
+}
+
+func formLimitsRequestToBL(dto *formLimitsRequest, modelBL *models.Form) {
+	modelBL.One_response = dto.One_response
+	modelBL.Max_responses = dto.Max_responses
+	modelBL.Edit_window = time.Duration(dto.Edit_window) * time.Second
+	modelBL.Time_limit = time.Duration(dto.Time_limit) * time.Second
 }
 
 func formBLToResponse(modelBL *models.Form) *formResponse {
 	return &formResponse{
-		Id:          modelBL.Id,
-		User_id:     modelBL.User_id,
-		Title:       modelBL.Title,
-		Description: modelBL.Description,
+		Id:           modelBL.Id,
+		User_id:      modelBL.User_id,
+		Workspace_id: modelBL.Workspace_id,
+		Title:        modelBL.Title,
+		Description:  modelBL.Description,
+		Status:       modelBL.Status,
+		Opens_at:     modelBL.Opens_at,
+		Closes_at:    modelBL.Closes_at,
+
+		One_response:  modelBL.One_response,
+		Max_responses: modelBL.Max_responses,
+		Edit_window:   int(modelBL.Edit_window / time.Second),
+		Time_limit:    int(modelBL.Time_limit / time.Second),
+
+		Access:       modelBL.Access,
+		Access_token: modelBL.Access_token,
+
+		Is_template: modelBL.Is_template,
+
+		Is_quiz:      modelBL.Is_quiz,
+		Show_correct: modelBL.Show_correct,
+
+		Draws: drawsBLToResponse(modelBL.Draws),
+	}
+}
+
+func drawsRequestToBL(draws []*drawDTO) []*models.Draw {
+	res := make([]*models.Draw, 0, len(draws))
+
+	for _, d := range draws {
+		if d == nil {
+			continue
+		}
+
+		res = append(res, &models.Draw{
+			Tag:   d.Tag,
+			Count: d.Count,
+		})
+	}
+
+	return res
+}
+
+func drawsBLToResponse(draws []*models.Draw) []*drawDTO {
+	if draws == nil {
+		return nil
+	}
+
+	res := make([]*drawDTO, len(draws))
+
+	for i, d := range draws {
+		res[i] = &drawDTO{
+			Tag:   d.Tag,
+			Count: d.Count,
+		}
+	}
+
+	return res
+}
+
+func formPublicBLToResponse(formBL *models.Form, versionBL *models.FormVersion) *formPublicResponse {
+	res := &formPublicResponse{
+		Id:          formBL.Id,
+		Version_id:  versionBL.Id,
+		Title:       versionBL.Title,
+		Description: versionBL.Description,
+		Status:      formBL.Status,
+		Opens_at:    formBL.Opens_at,
+		Closes_at:   formBL.Closes_at,
+		Questions:   make([]*questionResponse, len(versionBL.Questions)),
+		Sections:    make([]*sectionResponse, len(versionBL.Sections)),
+	}
+
+	for i, q := range versionBL.Questions {
+		res.Questions[i] = &questionResponse{
+			Id:         q.Id,
+			Header:     q.Header,
+			Type:       q.Type,
+			Options:    questiondto.OptionsBLToDTO(&q.Options),
+			Section_id: q.Section_id,
+			Condition:  conditionBLToResponse(q.Condition),
+			Required:   q.Required,
+			Rules:      questiondto.RulesBLToDTO(&q.Rules),
+			Points:     q.Points,
+		}
+	}
+
+	for i, s := range versionBL.Sections {
+		res.Sections[i] = &sectionResponse{
+			Id:          s.Id,
+			Title:       s.Title,
+			Description: s.Description,
+			Condition:   conditionBLToResponse(s.Condition),
+		}
+	}
+
+	return res
+}
+
+func conditionRequestToBL(dto *conditionDTO) *models.Condition {
+	if dto == nil {
+		return nil
+	}
+
+	return &models.Condition{
+		Question_id: dto.Question_id,
+		Op:          dto.Op,
+		Value:       dto.Value,
+	}
+}
+
+func conditionBLToResponse(modelBL *models.Condition) *conditionDTO {
+	if modelBL == nil {
+		return nil
+	}
+
+	return &conditionDTO{
+		Question_id: modelBL.Question_id,
+		Op:          modelBL.Op,
+		Value:       modelBL.Value,
 	}
 }
 
@@ -260,3 +1027,99 @@ func formsBLToResponse(forms []*models.Form) []*formResponse {
 
 	return res
 }
+
+func formDocumentBLToDTO(documentBL *models.FormDocument) *formDocument {
+	formBL := documentBL.Form
+
+	res := &formDocument{
+		Schema_version: documentBL.Schema_version,
+		Form: formDocumentForm{
+			Title:       formBL.Title,
+			Description: formBL.Description,
+			Access:      formBL.Access,
+			formLimitsRequest: formLimitsRequest{
+				One_response:  formBL.One_response,
+				Max_responses: formBL.Max_responses,
+				Edit_window:   int(formBL.Edit_window / time.Second),
+				Time_limit:    int(formBL.Time_limit / time.Second),
+			},
+			Is_quiz:      formBL.Is_quiz,
+			Show_correct: formBL.Show_correct,
+			Draws:        drawsBLToResponse(formBL.Draws),
+		},
+		Sections:  make([]*formDocumentSection, len(documentBL.Sections)),
+		Questions: make([]*formDocumentQuestion, len(documentBL.Questions)),
+	}
+
+	for i, s := range documentBL.Sections {
+		res.Sections[i] = &formDocumentSection{
+			Id:          s.Id,
+			Title:       s.Title,
+			Description: s.Description,
+			Condition:   conditionBLToResponse(s.Condition),
+		}
+	}
+
+	for i, q := range documentBL.Questions {
+		res.Questions[i] = &formDocumentQuestion{
+			Id:         q.Id,
+			Section_id: q.Section_id,
+			Condition:  conditionBLToResponse(q.Condition),
+			Header:     q.Header,
+			Type:       q.Type,
+			Options:    questiondto.OptionsBLToDTO(&q.Options),
+			Required:   q.Required,
+			Rules:      questiondto.RulesBLToDTO(&q.Rules),
+			Correct:    q.Correct,
+			Points:     q.Points,
+		}
+	}
+
+	return res
+}
+
+func formDocumentDTOToBL(dto *formDocument) *models.FormDocument {
+	formBL := &models.Form{
+		Title:       dto.Form.Title,
+		Description: dto.Form.Description,
+		Access:      dto.Form.Access,
+
+		Is_quiz:      dto.Form.Is_quiz,
+		Show_correct: dto.Form.Show_correct,
+		Draws:        drawsRequestToBL(dto.Form.Draws),
+	}
+	formLimitsRequestToBL(&dto.Form.formLimitsRequest, formBL)
+
+	res := &models.FormDocument{
+		Schema_version: dto.Schema_version,
+		Form:           formBL,
+		Sections:       make([]*models.Section, len(dto.Sections)),
+		Questions:      make([]*models.Question, len(dto.Questions)),
+	}
+
+	for i, s := range dto.Sections {
+		res.Sections[i] = &models.Section{
+			Id:          s.Id,
+			Title:       s.Title,
+			Description: s.Description,
+			Condition:   conditionRequestToBL(s.Condition),
+		}
+	}
+
+	for i, q := range dto.Questions {
+		res.Questions[i] = &models.Question{
+			Id:         q.Id,
+			Section_id: q.Section_id,
+			Condition:  conditionRequestToBL(q.Condition),
+			Header:     q.Header,
+			Type:       q.Type,
+			Options:    questiondto.OptionsToBL(&q.Options),
+			Required:   q.Required,
+			Rules:      questiondto.RulesToBL(&q.Rules),
+			Correct:    q.Correct,
+			Points:     q.Points,
+		}
+	}
+
+	return res
+}
@@ -13,4 +13,23 @@ func MapFormRoutes(formGroup *gin.RouterGroup, h form.Handlers) {
 	formGroup.PATCH("/:formid", h.Update())
 	formGroup.GET("", h.GetByUser())
 	formGroup.GET("/:formid", h.GetById())
+	formGroup.POST("/:formid/publish", h.Publish())
+	formGroup.POST("/:formid/close", h.Close())
+	formGroup.POST("/:formid/reopen", h.Reopen())
+	formGroup.POST("/:formid/archive", h.Archive())
+	formGroup.PUT("/:formid/schedule", h.SetSchedule())
+	formGroup.PUT("/:formid/limits", h.SetLimits())
+	formGroup.PUT("/:formid/access", h.SetAccess())
+	formGroup.PUT("/:formid/quiz", h.SetQuiz())
+	formGroup.PUT("/:formid/draws", h.SetDraws())
+	formGroup.GET("/:formid/export", h.Export())
+	formGroup.POST("/import", h.Import())
+	formGroup.POST("/:formid/clone", h.Clone())
+	formGroup.PUT("/:formid/template", h.SetTemplate())
+	formGroup.GET("/templates", h.GetTemplates())
+}
+
+// Map form routes open to guests
+func MapPublicFormRoutes(formGroup *gin.RouterGroup, h form.Handlers) {
+	formGroup.GET("/:formid", h.PublicGetById())
 }
@@ -19,11 +19,14 @@ type Repo interface {
 	// Returns nil & other err else.
 	GetById(ctx context.Context, id string) (*models.Form, error)
 
+	// Returns forms created by user, shared with user or in workspaces user is member of, ordered by id.
+	// Access tokens are read of forms user may edit only, they are empty for others.
+	// Filters by status, if it is not empty.
 	// Returns slice & nil, if get smth.
 	// Returns empty slice & nil, if get nothing.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & other err else.
-	GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.Form, error)
+	GetByUserId(ctx context.Context, user_id, status string, sets types.GetSets) ([]*models.Form, error)
 
 	// Returns source model & nil, if updated.
 	// Returns nil & ErrContentNotFound, if nothing to update.
@@ -32,6 +35,73 @@ type Repo interface {
 	// Returns nil & other err else.
 	Update(ctx context.Context, modelBL *models.Form) (*models.Form, error)
 
+	// Returns nil, if updated.
+	// Returns ErrContentNotFound, if nothing to update.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	UpdateStatus(ctx context.Context, id, status string) error
+
+	// Sets both opens_at and closes_at, nil bound is cleared.
+	// Returns nil, if updated.
+	// Returns ErrContentNotFound, if nothing to update.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	UpdateSchedule(ctx context.Context, modelBL *models.Form) error
+
+	// Sets one_response, max_responses, edit_window and time_limit.
+	// Returns nil, if updated.
+	// Returns ErrContentNotFound, if nothing to update.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	UpdateLimits(ctx context.Context, modelBL *models.Form) error
+
+	// Sets access and access_token.
+	// Returns nil, if updated.
+	// Returns ErrContentNotFound, if nothing to update.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	UpdateAccess(ctx context.Context, modelBL *models.Form) error
+
+	// Sets is_quiz and show_correct.
+	// Returns nil, if updated.
+	// Returns ErrContentNotFound, if nothing to update.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	UpdateQuiz(ctx context.Context, modelBL *models.Form) error
+
+	// Sets draws.
+	// Returns nil, if updated.
+	// Returns ErrContentNotFound, if nothing to update.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	UpdateDraws(ctx context.Context, modelBL *models.Form) error
+
+	// Returns nil, if updated.
+	// Returns ErrContentNotFound, if nothing to update.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	UpdateTemplate(ctx context.Context, id string, is_template bool) error
+
+	// Returns template forms of all users without access tokens ordered by id & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & other err else.
+	GetTemplates(ctx context.Context, sets types.GetSets) ([]*models.Form, error)
+
+	// Locks form row till the end of transaction.
+	// Returns nil, if locked.
+	// Returns ErrContentNotFound, if no such form.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other err else.
+	LockById(ctx context.Context, id string) error
+
 	// Returns nil, if deleted.
 	// Returns ErrContentNotFound, if nothing to delete.
 	// Returns ErrInvalidContent, if invalid inputs.
@@ -39,11 +109,9 @@ type Repo interface {
 	// Returns other errors else.
 	Delete(ctx context.Context, id string) error
 
-	// Returns nil, if user is owner.
-	// Returns ErrContentNotFound, if no such form.
-	// Returns ErrInvalidContent, if invalid inputs.
-	// Returns ErrUnauthorized, if user unauthorized.
-	// Returns ErrForbidden, if user is not owner.
-	// Returns other err else.
-	ValidateIsOwner(ctx context.Context, form_id string) error
+	// Returns owner id & nil, if found.
+	// Returns "" & ErrContentNotFound, if no such form.
+	// Returns "" & ErrInvalidContent, if invalid inputs.
+	// Returns "" & other err else.
+	GetOwnerId(ctx context.Context, form_id string) (string, error)
 }
@@ -2,6 +2,7 @@ package repo
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"quizapp/internal/form"
 	"quizapp/models"
@@ -9,6 +10,7 @@ import (
 	"quizapp/pkg/postgres"
 	"quizapp/pkg/types"
 	"strconv"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgconn"
@@ -16,17 +18,31 @@ import (
 )
 
 type formDB struct {
-	Id, UserId         int
-	Title, Description string
+	Id, UserId                 int
+	WorkspaceId                *int
+	Title, Description, Status string
+	OpensAt, ClosesAt          *time.Time
+	OneResponse                bool
+	MaxResponses, EditWindow   int
+	Access, AccessToken        string
+	IsTemplate                 bool
+	IsQuiz                     bool
+	ShowCorrect                string
+	TimeLimit                  int
+	Draws                      []byte
+}
+
+type drawDB struct {
+	Tag   string `json:"tag,omitempty"`
+	Count int    `json:"count"`
 }
 
 type formRepo struct {
-	ctxUserKey string
 	*postgres.Postgres
 }
 
-func NewFormRepo(ctx_user_key string, db *postgres.Postgres) form.Repo {
-	return &formRepo{ctx_user_key, db}
+func NewFormRepo(db *postgres.Postgres) form.Repo {
+	return &formRepo{db}
 }
 
 func (f *formRepo) Create(ctx context.Context, modelBL *models.Form) (*models.Form, error) {
@@ -37,15 +53,17 @@ func (f *formRepo) Create(ctx context.Context, modelBL *models.Form) (*models.Fo
 
 	sql, args, err := f.Builder.
 		Insert("form_").
-		Columns("user_id_, title_, description_").
-		Values(modelDB.UserId, modelDB.Title, modelDB.Description).
+		Columns("user_id_, workspace_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_, access_token_, is_template_, is_quiz_, show_correct_, time_limit_, draws_").
+		Values(modelDB.UserId, modelDB.WorkspaceId, modelDB.Title, modelDB.Description, modelDB.Status, modelDB.OpensAt, modelDB.ClosesAt,
+			modelDB.OneResponse, modelDB.MaxResponses, modelDB.EditWindow, modelDB.Access, modelDB.AccessToken, modelDB.IsTemplate,
+			modelDB.IsQuiz, modelDB.ShowCorrect, modelDB.TimeLimit, string(modelDB.Draws)).
 		Suffix("RETURNING \"id_\"").
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = f.Pool.QueryRow(ctx, sql, args...).Scan(&modelDB.Id)
+	err = f.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.Id)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
@@ -65,7 +83,7 @@ func (f *formRepo) GetById(ctx context.Context, id string) (*models.Form, error)
 	}
 
 	sql, args, err := f.Builder.
-		Select("user_id_, title_, description_").
+		Select("user_id_, workspace_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_, access_token_, is_template_, is_quiz_, show_correct_, time_limit_, draws_").
 		From("form_").
 		Where(squirrel.Eq{"id_": intid}).
 		ToSql()
@@ -74,7 +92,9 @@ func (f *formRepo) GetById(ctx context.Context, id string) (*models.Form, error)
 	}
 
 	modelDB := formDB{Id: intid}
-	err = f.Pool.QueryRow(ctx, sql, args...).Scan(&modelDB.UserId, &modelDB.Title, &modelDB.Description)
+	err = f.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.UserId, &modelDB.WorkspaceId, &modelDB.Title, &modelDB.Description,
+		&modelDB.Status, &modelDB.OpensAt, &modelDB.ClosesAt, &modelDB.OneResponse, &modelDB.MaxResponses, &modelDB.EditWindow,
+		&modelDB.Access, &modelDB.AccessToken, &modelDB.IsTemplate, &modelDB.IsQuiz, &modelDB.ShowCorrect, &modelDB.TimeLimit, &modelDB.Draws)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, errs.ErrContentNotFound
@@ -86,16 +106,42 @@ func (f *formRepo) GetById(ctx context.Context, id string) (*models.Form, error)
 	return formDBToBL(&modelDB)
 }
 
-func (f *formRepo) GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.Form, error) {
+func (f *formRepo) GetByUserId(ctx context.Context, user_id, status string, sets types.GetSets) ([]*models.Form, error) {
 	intuserid, err := strconv.Atoi(user_id)
 	if err != nil {
 		return nil, errs.ErrInvalidContent
 	}
 
-	sql, args, err := f.Builder.
-		Select("id_, title_, description_").
+	// link secret is read in the same query for forms user may edit as their owner or as member of form or its workspace
+	editors := models.MemberRolesCan(models.ActionFormEdit)
+	editable, editableargs, err := squirrel.Or{
+		squirrel.Eq{"user_id_": intuserid},
+		squirrel.Expr("id_ IN (SELECT form_id_ FROM form_member_ WHERE user_id_ = ? AND role_ = ANY(?))", intuserid, editors),
+		squirrel.Expr("workspace_id_ IN (SELECT workspace_id_ FROM workspace_member_ WHERE user_id_ = ? AND role_ = ANY(?))", intuserid, editors),
+	}.ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	// shared forms and forms of workspaces are listed to all their members
+	builder := f.Builder.
+		Select("id_, user_id_, workspace_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_").
+		Column(squirrel.Expr("CASE WHEN "+editable+" THEN access_token_ ELSE '' END", editableargs...)).
+		Column("is_template_, is_quiz_, show_correct_, time_limit_, draws_").
 		From("form_").
-		Where(squirrel.Eq{"user_id_": intuserid}).
+		Where(squirrel.Or{
+			squirrel.Eq{"user_id_": intuserid},
+			squirrel.Expr("id_ IN (SELECT form_id_ FROM form_member_ WHERE user_id_ = ?)", intuserid),
+			squirrel.Expr("workspace_id_ IN (SELECT workspace_id_ FROM workspace_member_ WHERE user_id_ = ?)", intuserid),
+		})
+
+	if status != "" {
+		builder = builder.
+			Where(squirrel.Eq{"status_": status})
+	}
+
+	sql, args, err := builder.
+		OrderBy("id_").
 		Limit(sets.Limit).
 		Offset(sets.Offset).
 		ToSql()
@@ -103,7 +149,7 @@ func (f *formRepo) GetByUserId(ctx context.Context, user_id string, sets types.G
 		return nil, err
 	}
 
-	rows, err := f.Pool.Query(ctx, sql, args...)
+	rows, err := f.Conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -112,9 +158,11 @@ func (f *formRepo) GetByUserId(ctx context.Context, user_id string, sets types.G
 	res := make([]*models.Form, 0)
 
 	for rows.Next() {
-		modelDB := formDB{UserId: intuserid}
+		modelDB := formDB{}
 
-		err = rows.Scan(&modelDB.Id, &modelDB.Title, &modelDB.Description)
+		err = rows.Scan(&modelDB.Id, &modelDB.UserId, &modelDB.WorkspaceId, &modelDB.Title, &modelDB.Description,
+			&modelDB.Status, &modelDB.OpensAt, &modelDB.ClosesAt, &modelDB.OneResponse, &modelDB.MaxResponses, &modelDB.EditWindow,
+			&modelDB.Access, &modelDB.AccessToken, &modelDB.IsTemplate, &modelDB.IsQuiz, &modelDB.ShowCorrect, &modelDB.TimeLimit, &modelDB.Draws)
 		if err != nil {
 			return nil, err
 		}
@@ -156,7 +204,7 @@ func (f *formRepo) Update(ctx context.Context, modelBL *models.Form) (*models.Fo
 		return nil, err
 	}
 
-	res, err := f.Pool.Exec(ctx, sql, args...)
+	res, err := f.Conn(ctx).Exec(ctx, sql, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
@@ -173,21 +221,22 @@ func (f *formRepo) Update(ctx context.Context, modelBL *models.Form) (*models.Fo
 	return modelBL, nil
 }
 
-func (f *formRepo) Delete(ctx context.Context, id string) error {
+func (f *formRepo) UpdateStatus(ctx context.Context, id, status string) error {
 	intid, err := strconv.Atoi(id)
 	if err != nil {
 		return errs.ErrInvalidContent
 	}
 
 	sql, args, err := f.Builder.
-		Delete("form_").
+		Update("form_").
+		Set("status_", status).
 		Where(squirrel.Eq{"id_": intid}).
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	res, err := f.Pool.Exec(ctx, sql, args...)
+	res, err := f.Conn(ctx).Exec(ctx, sql, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
@@ -204,49 +253,385 @@ func (f *formRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
-func (f *formRepo) ValidateIsOwner(ctx context.Context, form_id string) error {
-	intid, err := strconv.Atoi(form_id)
+func (f *formRepo) UpdateSchedule(ctx context.Context, modelBL *models.Form) error {
+	modelDB, err := formBLToDB(modelBL)
 	if err != nil {
 		return errs.ErrInvalidContent
 	}
 
 	sql, args, err := f.Builder.
-		Select("user_id_").
+		Update("form_").
+		Set("opens_at_", modelDB.OpensAt).
+		Set("closes_at_", modelDB.ClosesAt).
+		Where(squirrel.Eq{"id_": modelDB.Id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := f.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (f *formRepo) UpdateLimits(ctx context.Context, modelBL *models.Form) error {
+	modelDB, err := formBLToDB(modelBL)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := f.Builder.
+		Update("form_").
+		Set("one_response_", modelDB.OneResponse).
+		Set("max_responses_", modelDB.MaxResponses).
+		Set("edit_window_", modelDB.EditWindow).
+		Set("time_limit_", modelDB.TimeLimit).
+		Where(squirrel.Eq{"id_": modelDB.Id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := f.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (f *formRepo) UpdateAccess(ctx context.Context, modelBL *models.Form) error {
+	modelDB, err := formBLToDB(modelBL)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := f.Builder.
+		Update("form_").
+		Set("access_", modelDB.Access).
+		Set("access_token_", modelDB.AccessToken).
+		Where(squirrel.Eq{"id_": modelDB.Id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := f.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (f *formRepo) UpdateQuiz(ctx context.Context, modelBL *models.Form) error {
+	modelDB, err := formBLToDB(modelBL)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := f.Builder.
+		Update("form_").
+		Set("is_quiz_", modelDB.IsQuiz).
+		Set("show_correct_", modelDB.ShowCorrect).
+		Where(squirrel.Eq{"id_": modelDB.Id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := f.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (f *formRepo) UpdateDraws(ctx context.Context, modelBL *models.Form) error {
+	modelDB, err := formBLToDB(modelBL)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := f.Builder.
+		Update("form_").
+		Set("draws_", string(modelDB.Draws)).
+		Where(squirrel.Eq{"id_": modelDB.Id}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := f.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (f *formRepo) UpdateTemplate(ctx context.Context, id string, is_template bool) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := f.Builder.
+		Update("form_").
+		Set("is_template_", is_template).
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := f.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (f *formRepo) GetTemplates(ctx context.Context, sets types.GetSets) ([]*models.Form, error) {
+	sql, args, err := f.Builder.
+		Select("id_, user_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_, is_quiz_, show_correct_, time_limit_, draws_").
+		From("form_").
+		Where(squirrel.Eq{"is_template_": true}).
+		OrderBy("id_").
+		Limit(sets.Limit).
+		Offset(sets.Offset).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := f.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.Form, 0)
+
+	for rows.Next() {
+		// link secret is not shared with template users
+		modelDB := formDB{IsTemplate: true}
+
+		err = rows.Scan(&modelDB.Id, &modelDB.UserId, &modelDB.Title, &modelDB.Description,
+			&modelDB.Status, &modelDB.OpensAt, &modelDB.ClosesAt, &modelDB.OneResponse, &modelDB.MaxResponses, &modelDB.EditWindow,
+			&modelDB.Access, &modelDB.IsQuiz, &modelDB.ShowCorrect, &modelDB.TimeLimit, &modelDB.Draws)
+		if err != nil {
+			return nil, err
+		}
+
+		formBL, err := formDBToBL(&modelDB)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, formBL)
+	}
+
+	return res, nil
+}
+
+func (f *formRepo) LockById(ctx context.Context, id string) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := f.Builder.
+		Select("id_").
 		From("form_").
 		Where(squirrel.Eq{"id_": intid}).
+		Suffix("FOR UPDATE").
 		ToSql()
 	if err != nil {
 		return err
 	}
 
-	var owner_id int
-	err = f.Pool.QueryRow(ctx, sql, args...).Scan(&owner_id)
+	err = f.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&intid)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return errs.ErrContentNotFound
 		}
 
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
 		return err
 	}
 
-	currentuser, ok := ctx.Value(f.ctxUserKey).(*models.User)
-	if !ok {
-		return errs.ErrUnauthorized
+	return nil
+}
+
+func (f *formRepo) Delete(ctx context.Context, id string) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := f.Builder.
+		Delete("form_").
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := f.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
 	}
 
-	if currentuser.Id != strconv.Itoa(owner_id) {
-		return errs.ErrForbidden
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
 	}
 
 	return nil
 }
 
+func (f *formRepo) GetOwnerId(ctx context.Context, form_id string) (string, error) {
+	intid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return "", errs.ErrInvalidContent
+	}
+
+	sql, args, err := f.Builder.
+		Select("user_id_").
+		From("form_").
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return "", err
+	}
+
+	var owner_id int
+	err = f.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&owner_id)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return "", errs.ErrContentNotFound
+		}
+
+		return "", err
+	}
+
+	return strconv.Itoa(owner_id), nil
+}
+
 func formDBToBL(modelDB *formDB) (*models.Form, error) {
+	var draws []*drawDB
+	if len(modelDB.Draws) != 0 {
+		err := json.Unmarshal(modelDB.Draws, &draws)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var drawsBL []*models.Draw
+	for _, d := range draws {
+		drawsBL = append(drawsBL, &models.Draw{
+			Tag:   d.Tag,
+			Count: d.Count,
+		})
+	}
+
+	var wid string
+	if modelDB.WorkspaceId != nil {
+		wid = strconv.Itoa(*modelDB.WorkspaceId)
+	}
+
 	return &models.Form{
-		Id:          strconv.Itoa(modelDB.Id),
-		User_id:     strconv.Itoa(modelDB.UserId),
-		Title:       modelDB.Title,
-		Description: modelDB.Description,
+		Id:           strconv.Itoa(modelDB.Id),
+		User_id:      strconv.Itoa(modelDB.UserId),
+		Workspace_id: wid,
+		Title:        modelDB.Title,
+		Description:  modelDB.Description,
+		Status:       modelDB.Status,
+		Opens_at:     modelDB.OpensAt,
+		Closes_at:    modelDB.ClosesAt,
+
+		One_response:  modelDB.OneResponse,
+		Max_responses: modelDB.MaxResponses,
+		Edit_window:   time.Duration(modelDB.EditWindow) * time.Second,
+		Time_limit:    time.Duration(modelDB.TimeLimit) * time.Second,
+
+		Access:       modelDB.Access,
+		Access_token: modelDB.AccessToken,
+
+		Is_template: modelDB.IsTemplate,
+
+		Is_quiz:      modelDB.IsQuiz,
+		Show_correct: modelDB.ShowCorrect,
+
+		Draws: drawsBL,
 	}, nil
 }
 
@@ -271,10 +656,52 @@ func formBLToDB(modelBL *models.Form) (*formDB, error) {
 		}
 	}
 
+	var wid *int
+	if modelBL.Workspace_id != "" {
+		intwid, err := strconv.Atoi(modelBL.Workspace_id)
+		if err != nil {
+			return nil, err
+		}
+		wid = &intwid
+	}
+
+	// form without draws is stored with empty array
+	draws := make([]*drawDB, len(modelBL.Draws))
+	for i, d := range modelBL.Draws {
+		draws[i] = &drawDB{
+			Tag:   d.Tag,
+			Count: d.Count,
+		}
+	}
+
+	drawsJSON, err := json.Marshal(draws)
+	if err != nil {
+		return nil, err
+	}
+
 	return &formDB{
 		Id:          id,
 		UserId:      uid,
+		WorkspaceId: wid,
 		Title:       modelBL.Title,
 		Description: modelBL.Description,
+		Status:      modelBL.Status,
+		OpensAt:     modelBL.Opens_at,
+		ClosesAt:    modelBL.Closes_at,
+
+		OneResponse:  modelBL.One_response,
+		MaxResponses: modelBL.Max_responses,
+		EditWindow:   int(modelBL.Edit_window / time.Second),
+		TimeLimit:    int(modelBL.Time_limit / time.Second),
+
+		Access:      modelBL.Access,
+		AccessToken: modelBL.Access_token,
+
+		IsTemplate: modelBL.Is_template,
+
+		IsQuiz:      modelBL.Is_quiz,
+		ShowCorrect: modelBL.Show_correct,
+
+		Draws: drawsJSON,
 	}, nil
 }
@@ -10,17 +10,20 @@ import (
 	"quizapp/pkg/types"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/driftprogramming/pgxpoolmock"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgx/v4"
 	"github.com/pashagolub/pgxmock"
 
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	_builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	_builder  = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	_closesAt = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
 )
 
 func TestFormRepo_Create(t *testing.T) {
@@ -35,7 +38,7 @@ func TestFormRepo_Create(t *testing.T) {
 		Pool:    mockPool,
 	}
 
-	r := repo.NewFormRepo("any", &db)
+	r := repo.NewFormRepo(&db)
 
 	type mockBehavior func(ctx context.Context, form *models.Form)
 
@@ -50,21 +53,47 @@ func TestFormRepo_Create(t *testing.T) {
 			nameTest: "ok",
 			ctx:      context.Background(),
 			form: models.Form{
-				User_id:     "12",
-				Title:       "sdcsd",
-				Description: "ecefvc",
+				User_id:      "12",
+				Workspace_id: "7",
+				Title:        "sdcsd",
+				Description:  "ecefvc",
+				Status:       models.FormStatusDraft,
+				Closes_at:    &_closesAt,
+
+				One_response:  true,
+				Edit_window:   time.Hour,
+				Max_responses: 100,
+
+				Access:       models.FormAccessLink,
+				Access_token: "secret",
+
+				Is_quiz:      true,
+				Show_correct: models.FormShowCorrectAfterSubmission,
 			},
 			mockBehavior: func(ctx context.Context, form *models.Form) {
 				pgxRows := pgxpoolmock.NewRows([]string{"id_"}).AddRow(345).ToPgxRows()
 				pgxRows.Next()
 				useridint, _ := strconv.Atoi(form.User_id)
-				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO form_ (user_id_, title_, description_) VALUES ($1,$2,$3) RETURNING \"id_\"", useridint, form.Title, form.Description).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO form_ (user_id_, workspace_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_, access_token_, is_template_, is_quiz_, show_correct_, time_limit_, draws_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17) RETURNING \"id_\"", useridint, intRef(7), form.Title, form.Description, form.Status, form.Opens_at, form.Closes_at, form.One_response, form.Max_responses, int(form.Edit_window/time.Second), form.Access, form.Access_token, form.Is_template, form.Is_quiz, form.Show_correct, int(form.Time_limit/time.Second), "[]").Return(pgxRows)
 			},
 			expectedForm: models.Form{
-				Id:          "345",
-				User_id:     "12",
-				Title:       "sdcsd",
-				Description: "ecefvc",
+				Id:           "345",
+				User_id:      "12",
+				Workspace_id: "7",
+				Title:        "sdcsd",
+				Description:  "ecefvc",
+				Status:       models.FormStatusDraft,
+				Closes_at:    &_closesAt,
+
+				One_response:  true,
+				Edit_window:   time.Hour,
+				Max_responses: 100,
+
+				Access:       models.FormAccessLink,
+				Access_token: "secret",
+
+				Is_quiz:      true,
+				Show_correct: models.FormShowCorrectAfterSubmission,
 			},
 		},
 		{
@@ -88,7 +117,7 @@ func TestFormRepo_Create(t *testing.T) {
 			mockBehavior: func(ctx context.Context, form *models.Form) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 				useridint, _ := strconv.Atoi(form.User_id)
-				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO form_ (user_id_, title_, description_) VALUES ($1,$2,$3) RETURNING \"id_\"", useridint, form.Title, form.Description).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO form_ (user_id_, workspace_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_, access_token_, is_template_, is_quiz_, show_correct_, time_limit_, draws_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11,$12,$13,$14,$15,$16,$17) RETURNING \"id_\"", useridint, (*int)(nil), form.Title, form.Description, form.Status, form.Opens_at, form.Closes_at, form.One_response, form.Max_responses, int(form.Edit_window/time.Second), form.Access, form.Access_token, form.Is_template, form.Is_quiz, form.Show_correct, int(form.Time_limit/time.Second), "[]").Return(pgxRows)
 			},
 		},
 	}
@@ -126,7 +155,7 @@ func TestFormRepo_GetById(t *testing.T) {
 		Pool:    mockPool,
 	}
 
-	r := repo.NewFormRepo("any", &db)
+	r := repo.NewFormRepo(&db)
 
 	type mockBehavior func(ctx context.Context, id string)
 
@@ -142,16 +171,30 @@ func TestFormRepo_GetById(t *testing.T) {
 			ctx:      context.Background(),
 			id:       "345",
 			mockBehavior: func(ctx context.Context, id string) {
-				pgxRows := pgxpoolmock.NewRows([]string{"user_id_", "title_", "description_"}).AddRow(12, "sdcsd", "ecefvc").ToPgxRows()
+				pgxRows := pgxpoolmock.NewRows([]string{"user_id_", "workspace_id_", "title_", "description_", "status_", "opens_at_", "closes_at_", "one_response_", "max_responses_", "edit_window_", "access_", "access_token_", "is_template_", "is_quiz_", "show_correct_", "time_limit_", "draws_"}).AddRow(12, nil, "sdcsd", "ecefvc", "open", nil, &_closesAt, true, 0, 600, "link", "secret", false, true, models.FormShowCorrectAfterClose, 900, []byte(`[{"tag":"go","count":2}]`)).ToPgxRows()
 				pgxRows.Next()
 				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, title_, description_ FROM form_ WHERE id_ = $1", idint).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, workspace_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_, access_token_, is_template_, is_quiz_, show_correct_, time_limit_, draws_ FROM form_ WHERE id_ = $1", idint).Return(pgxRows)
 			},
 			expectedForm: models.Form{
 				Id:          "345",
 				User_id:     "12",
 				Title:       "sdcsd",
 				Description: "ecefvc",
+				Status:      models.FormStatusOpen,
+				Closes_at:   &_closesAt,
+
+				One_response: true,
+				Edit_window:  10 * time.Minute,
+
+				Access:       models.FormAccessLink,
+				Access_token: "secret",
+
+				Is_quiz:      true,
+				Show_correct: models.FormShowCorrectAfterClose,
+				Time_limit:   15 * time.Minute,
+
+				Draws: []*models.Draw{{Tag: "go", Count: 2}},
 			},
 		},
 		{
@@ -167,7 +210,7 @@ func TestFormRepo_GetById(t *testing.T) {
 			mockBehavior: func(ctx context.Context, id string) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, title_, description_ FROM form_ WHERE id_ = $1", idint).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, workspace_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_, access_token_, is_template_, is_quiz_, show_correct_, time_limit_, draws_ FROM form_ WHERE id_ = $1", idint).Return(pgxRows)
 			},
 		},
 	}
@@ -205,14 +248,23 @@ func TestFormRepo_GetByUserId(t *testing.T) {
 		Pool:    mockPool,
 	}
 
-	r := repo.NewFormRepo("any", &db)
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, user_id, status string, sets types.GetSets)
 
-	type mockBehavior func(ctx context.Context, user_id string, sets types.GetSets)
+	// link secret is read in the same query for forms user may edit
+	const selectSQL = "SELECT id_, user_id_, workspace_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_, " +
+		"CASE WHEN (user_id_ = $1 OR id_ IN (SELECT form_id_ FROM form_member_ WHERE user_id_ = $2 AND role_ = ANY($3)) OR workspace_id_ IN (SELECT workspace_id_ FROM workspace_member_ WHERE user_id_ = $4 AND role_ = ANY($5))) THEN access_token_ ELSE '' END, " +
+		"is_template_, is_quiz_, show_correct_, time_limit_, draws_ FROM form_ " +
+		"WHERE (user_id_ = $6 OR id_ IN (SELECT form_id_ FROM form_member_ WHERE user_id_ = $7) OR workspace_id_ IN (SELECT workspace_id_ FROM workspace_member_ WHERE user_id_ = $8))"
+
+	editors := []string{models.MemberRoleEditor, models.MemberRoleOwner}
 
 	testTable := []struct {
 		nameTest      string
 		ctx           context.Context
 		user_id       string
+		status        string
 		sets          types.GetSets
 		mockBehavior  mockBehavior
 		expectedForms []*models.Form
@@ -222,10 +274,10 @@ func TestFormRepo_GetByUserId(t *testing.T) {
 			ctx:      context.Background(),
 			user_id:  "12",
 			sets:     types.GetSets{},
-			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
-				pgxRows := pgxpoolmock.NewRows([]string{"id_", "title_", "description_"}).AddRow(345, "sdcsd", "ecefvc").AddRow(346, "qwer", "ty").ToPgxRows()
+			mockBehavior: func(ctx context.Context, user_id, status string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "user_id_", "workspace_id_", "title_", "description_", "status_", "opens_at_", "closes_at_", "one_response_", "max_responses_", "edit_window_", "access_", "access_token_", "is_template_", "is_quiz_", "show_correct_", "time_limit_", "draws_"}).AddRow(345, 12, nil, "sdcsd", "ecefvc", "draft", nil, nil, false, 0, 0, "authenticated", "", false, false, models.FormShowCorrectNever, 0, []byte("[]")).AddRow(346, 13, intRef(7), "qwer", "ty", "closed", nil, nil, false, 50, 0, "anonymous", "", true, false, models.FormShowCorrectNever, 0, []byte("[]")).ToPgxRows()
 				useridint, _ := strconv.Atoi(user_id)
-				mockPool.EXPECT().Query(ctx, "SELECT id_, title_, description_ FROM form_ WHERE user_id_ = $1 LIMIT 0 OFFSET 0", useridint).Return(pgxRows, nil)
+				mockPool.EXPECT().Query(ctx, selectSQL+" ORDER BY id_ LIMIT 0 OFFSET 0", useridint, useridint, editors, useridint, editors, useridint, useridint, useridint).Return(pgxRows, nil)
 			},
 			expectedForms: []*models.Form{
 				{
@@ -233,12 +285,48 @@ func TestFormRepo_GetByUserId(t *testing.T) {
 					User_id:     "12",
 					Title:       "sdcsd",
 					Description: "ecefvc",
+					Status:      models.FormStatusDraft,
+					Access:      models.FormAccessAuthenticated,
+
+					Show_correct: models.FormShowCorrectNever,
 				},
 				{
-					Id:          "346",
+					Id:           "346",
+					User_id:      "13",
+					Workspace_id: "7",
+					Title:        "qwer",
+					Description:  "ty",
+					Status:       models.FormStatusClosed,
+
+					Max_responses: 50,
+					Access:        models.FormAccessAnonymous,
+					Is_template:   true,
+					Show_correct:  models.FormShowCorrectNever,
+				},
+			},
+		},
+		{
+			nameTest: "ok_status",
+			ctx:      context.Background(),
+			user_id:  "12",
+			status:   models.FormStatusOpen,
+			sets:     types.GetSets{},
+			mockBehavior: func(ctx context.Context, user_id, status string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "user_id_", "workspace_id_", "title_", "description_", "status_", "opens_at_", "closes_at_", "one_response_", "max_responses_", "edit_window_", "access_", "access_token_", "is_template_", "is_quiz_", "show_correct_", "time_limit_", "draws_"}).AddRow(347, 12, nil, "sdcsd", "ecefvc", "open", nil, &_closesAt, false, 0, 0, "authenticated", "", false, false, models.FormShowCorrectNever, 0, []byte("[]")).ToPgxRows()
+				useridint, _ := strconv.Atoi(user_id)
+				mockPool.EXPECT().Query(ctx, selectSQL+" AND status_ = $9 ORDER BY id_ LIMIT 0 OFFSET 0", useridint, useridint, editors, useridint, editors, useridint, useridint, useridint, status).Return(pgxRows, nil)
+			},
+			expectedForms: []*models.Form{
+				{
+					Id:          "347",
 					User_id:     "12",
-					Title:       "qwer",
-					Description: "ty",
+					Title:       "sdcsd",
+					Description: "ecefvc",
+					Status:      models.FormStatusOpen,
+					Closes_at:   &_closesAt,
+					Access:      models.FormAccessAuthenticated,
+
+					Show_correct: models.FormShowCorrectNever,
 				},
 			},
 		},
@@ -247,16 +335,16 @@ func TestFormRepo_GetByUserId(t *testing.T) {
 			ctx:          context.Background(),
 			user_id:      "5r4",
 			sets:         types.GetSets{},
-			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {},
+			mockBehavior: func(ctx context.Context, user_id, status string, sets types.GetSets) {},
 		},
 		{
 			nameTest: "query_error",
 			ctx:      context.Background(),
 			user_id:  "12",
 			sets:     types.GetSets{},
-			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
+			mockBehavior: func(ctx context.Context, user_id, status string, sets types.GetSets) {
 				useridint, _ := strconv.Atoi(user_id)
-				mockPool.EXPECT().Query(ctx, "SELECT id_, title_, description_ FROM form_ WHERE user_id_ = $1 LIMIT 0 OFFSET 0", useridint).Return(nil, errors.New("query_error"))
+				mockPool.EXPECT().Query(ctx, selectSQL+" ORDER BY id_ LIMIT 0 OFFSET 0", useridint, useridint, editors, useridint, editors, useridint, useridint, useridint).Return(nil, errors.New("query_error"))
 			},
 		},
 		{
@@ -264,11 +352,11 @@ func TestFormRepo_GetByUserId(t *testing.T) {
 			ctx:      context.Background(),
 			user_id:  "12",
 			sets:     types.GetSets{},
-			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
+			mockBehavior: func(ctx context.Context, user_id, status string, sets types.GetSets) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 				pgxRows.Next()
 				useridint, _ := strconv.Atoi(user_id)
-				mockPool.EXPECT().Query(ctx, "SELECT id_, title_, description_ FROM form_ WHERE user_id_ = $1 LIMIT 0 OFFSET 0", useridint).Return(pgxRows, nil)
+				mockPool.EXPECT().Query(ctx, selectSQL+" ORDER BY id_ LIMIT 0 OFFSET 0", useridint, useridint, editors, useridint, editors, useridint, useridint, useridint).Return(pgxRows, nil)
 			},
 			expectedForms: []*models.Form{},
 		},
@@ -276,12 +364,12 @@ func TestFormRepo_GetByUserId(t *testing.T) {
 
 	for _, testCase := range testTable {
 		t.Run(testCase.nameTest, func(t *testing.T) {
-			testCase.mockBehavior(testCase.ctx, testCase.user_id, types.GetSets{})
+			testCase.mockBehavior(testCase.ctx, testCase.user_id, testCase.status, types.GetSets{})
 
-			got, err := r.GetByUserId(testCase.ctx, testCase.user_id, types.GetSets{})
+			got, err := r.GetByUserId(testCase.ctx, testCase.user_id, testCase.status, types.GetSets{})
 
 			switch testCase.nameTest {
-			case "ok":
+			case "ok", "ok_status":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedForms, got)
 			case "invalid_inputs":
@@ -310,7 +398,7 @@ func TestFormRepo_Update(t *testing.T) {
 		Pool:    mockPool,
 	}
 
-	r := repo.NewFormRepo("any", &db)
+	r := repo.NewFormRepo(&db)
 
 	type mockBehavior func(ctx context.Context, form *models.Form)
 
@@ -416,7 +504,7 @@ func TestFormRepo_Update(t *testing.T) {
 	}
 }
 
-func TestFormRepo_Delete(t *testing.T) {
+func TestFormRepo_UpdateStatus(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -428,64 +516,132 @@ func TestFormRepo_Delete(t *testing.T) {
 		Pool:    mockPool,
 	}
 
-	r := repo.NewFormRepo("any", &db)
+	r := repo.NewFormRepo(&db)
 
-	type mockBehavior func(ctx context.Context, id string)
+	type mockBehavior func(ctx context.Context, id, status string)
 
 	testTable := []struct {
 		nameTest     string
 		ctx          context.Context
 		id           string
+		status       string
 		mockBehavior mockBehavior
 	}{
 		{
 			nameTest: "ok",
 			ctx:      context.Background(),
 			id:       "345",
-			mockBehavior: func(ctx context.Context, id string) {
+			status:   models.FormStatusClosed,
+			mockBehavior: func(ctx context.Context, id, status string) {
 				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().Exec(ctx, "DELETE FROM form_ WHERE id_ = $1", idint).Return(pgxmock.NewResult("DELETE", 1), nil)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET status_ = $1 WHERE id_ = $2", status, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
 			},
 		},
 		{
 			nameTest:     "invalid_inputs",
 			ctx:          context.Background(),
 			id:           "5r4",
-			mockBehavior: func(ctx context.Context, id string) {},
+			status:       models.FormStatusClosed,
+			mockBehavior: func(ctx context.Context, id, status string) {},
 		},
 		{
-			nameTest: "no_form_to_delete",
+			nameTest: "no_form_to_update",
 			ctx:      context.Background(),
 			id:       "345",
-			mockBehavior: func(ctx context.Context, id string) {
+			status:   models.FormStatusClosed,
+			mockBehavior: func(ctx context.Context, id, status string) {
 				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().Exec(ctx, "DELETE FROM form_ WHERE id_ = $1", idint).Return(pgxmock.NewResult("DELETE", 0), nil)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET status_ = $1 WHERE id_ = $2", status, idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id, testCase.status)
+
+			err := r.UpdateStatus(testCase.ctx, testCase.id, testCase.status)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_form_to_update":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormRepo_UpdateSchedule(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form *models.Form)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form         models.Form
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id:        "345",
+				Closes_at: &_closesAt,
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET opens_at_ = $1, closes_at_ = $2 WHERE id_ = $3", form.Opens_at, form.Closes_at, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+		},
+		{
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id: "5r4",
 			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {},
 		},
 		{
 			nameTest: "exec_error",
 			ctx:      context.Background(),
-			id:       "345",
-			mockBehavior: func(ctx context.Context, id string) {
-				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().Exec(ctx, "DELETE FROM form_ WHERE id_ = $1", idint).Return(nil, errors.New("exec_error"))
+			form: models.Form{
+				Id: "345",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET opens_at_ = $1, closes_at_ = $2 WHERE id_ = $3", form.Opens_at, form.Closes_at, idint).Return(nil, errors.New("exec_error"))
 			},
 		},
 	}
 
 	for _, testCase := range testTable {
 		t.Run(testCase.nameTest, func(t *testing.T) {
-			testCase.mockBehavior(testCase.ctx, testCase.id)
+			testCase.mockBehavior(testCase.ctx, &testCase.form)
 
-			err := r.Delete(testCase.ctx, testCase.id)
+			err := r.UpdateSchedule(testCase.ctx, &testCase.form)
 
 			switch testCase.nameTest {
 			case "ok":
 				assert.Equal(t, nil, err)
 			case "invalid_inputs":
 				assert.Equal(t, errs.ErrInvalidContent, err)
-			case "no_form_to_delete":
-				assert.Equal(t, errs.ErrContentNotFound, err)
 			case "exec_error":
 				assert.NotEqual(t, nil, err)
 			default:
@@ -495,7 +651,7 @@ func TestFormRepo_Delete(t *testing.T) {
 	}
 }
 
-func TestFormRepo_ValidateIsOwner(t *testing.T) {
+func TestFormRepo_UpdateLimits(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -507,95 +663,692 @@ func TestFormRepo_ValidateIsOwner(t *testing.T) {
 		Pool:    mockPool,
 	}
 
-	ctxuserkey := "ctxuserkey"
-
-	r := repo.NewFormRepo(ctxuserkey, &db)
-
-	type mockBehavior func(ctx context.Context, form_id string)
+	r := repo.NewFormRepo(&db)
 
-	user := models.User{
-		Id:       "23",
-		Login:    "qwe",
-		Password: "rty",
-	}
+	type mockBehavior func(ctx context.Context, form *models.Form)
 
 	testTable := []struct {
 		nameTest     string
 		ctx          context.Context
-		form_id      string
+		form         models.Form
 		mockBehavior mockBehavior
 	}{
 		{
 			nameTest: "ok",
-			ctx:      context.WithValue(context.Background(), ctxuserkey, &user),
-			form_id:  "345",
-			mockBehavior: func(ctx context.Context, id string) {
-				pgxrows := pgxpoolmock.NewRows([]string{"user_id_"}).AddRow(23).ToPgxRows()
-				pgxrows.Next()
-				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_ FROM form_ WHERE id_ = $1", idint).Return(pgxrows)
+			ctx:      context.Background(),
+			form: models.Form{
+				Id:            "345",
+				One_response:  true,
+				Max_responses: 100,
+				Edit_window:   time.Hour,
+				Time_limit:    30 * time.Minute,
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET one_response_ = $1, max_responses_ = $2, edit_window_ = $3, time_limit_ = $4 WHERE id_ = $5", true, 100, 3600, 1800, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
 			},
 		},
 		{
-			nameTest:     "invalid_inputs",
-			ctx:          context.Background(),
-			form_id:      "5r4",
-			mockBehavior: func(ctx context.Context, id string) {},
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id: "5r4",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {},
 		},
 		{
-			nameTest: "no_form",
+			nameTest: "not_found",
 			ctx:      context.Background(),
-			form_id:  "345",
-			mockBehavior: func(ctx context.Context, id string) {
-				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
-				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_ FROM form_ WHERE id_ = $1", idint).Return(pgxRows)
+			form: models.Form{
+				Id: "345",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET one_response_ = $1, max_responses_ = $2, edit_window_ = $3, time_limit_ = $4 WHERE id_ = $5", false, 0, 0, 0, idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.form)
+
+			err := r.UpdateLimits(testCase.ctx, &testCase.form)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "not_found":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormRepo_UpdateAccess(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form *models.Form)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form         models.Form
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id:           "345",
+				Access:       models.FormAccessLink,
+				Access_token: "secret",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET access_ = $1, access_token_ = $2 WHERE id_ = $3", form.Access, form.Access_token, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
 			},
 		},
 		{
-			nameTest: "unauthorized",
+			nameTest: "invalid_inputs",
 			ctx:      context.Background(),
-			form_id:  "345",
-			mockBehavior: func(ctx context.Context, id string) {
-				pgxrows := pgxpoolmock.NewRows([]string{"user_id_"}).AddRow(23).ToPgxRows()
-				pgxrows.Next()
-				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_ FROM form_ WHERE id_ = $1", idint).Return(pgxrows)
+			form: models.Form{
+				Id: "5r4",
 			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {},
 		},
 		{
-			nameTest: "not_an_owner",
-			ctx:      context.WithValue(context.Background(), ctxuserkey, &user),
-			form_id:  "345",
-			mockBehavior: func(ctx context.Context, id string) {
-				pgxrows := pgxpoolmock.NewRows([]string{"user_id_"}).AddRow(50).ToPgxRows()
-				pgxrows.Next()
-				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_ FROM form_ WHERE id_ = $1", idint).Return(pgxrows)
+			nameTest: "not_found",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id: "345",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET access_ = $1, access_token_ = $2 WHERE id_ = $3", form.Access, form.Access_token, idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
 			},
 		},
 	}
 
 	for _, testCase := range testTable {
 		t.Run(testCase.nameTest, func(t *testing.T) {
-			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+			testCase.mockBehavior(testCase.ctx, &testCase.form)
 
-			err := r.ValidateIsOwner(testCase.ctx, testCase.form_id)
+			err := r.UpdateAccess(testCase.ctx, &testCase.form)
 
 			switch testCase.nameTest {
 			case "ok":
 				assert.Equal(t, nil, err)
 			case "invalid_inputs":
 				assert.Equal(t, errs.ErrInvalidContent, err)
-			case "unauthorized":
-				assert.Equal(t, errs.ErrUnauthorized, err)
-			case "not_an_owner":
-				assert.Equal(t, errs.ErrForbidden, err)
-			case "no_form":
-				assert.NotEqual(t, nil, err)
+			case "not_found":
+				assert.Equal(t, errs.ErrContentNotFound, err)
 			default:
 				assert.Error(t, errors.New("No case"), "No case")
 			}
 		})
 	}
 }
+
+func TestFormRepo_UpdateQuiz(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form *models.Form)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form         models.Form
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id:           "345",
+				Is_quiz:      true,
+				Show_correct: models.FormShowCorrectAfterClose,
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET is_quiz_ = $1, show_correct_ = $2 WHERE id_ = $3", form.Is_quiz, form.Show_correct, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+		},
+		{
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id: "5r4",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {},
+		},
+		{
+			nameTest: "not_found",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id: "345",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET is_quiz_ = $1, show_correct_ = $2 WHERE id_ = $3", form.Is_quiz, form.Show_correct, idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.form)
+
+			err := r.UpdateQuiz(testCase.ctx, &testCase.form)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "not_found":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormRepo_UpdateDraws(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form *models.Form)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form         models.Form
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id:    "345",
+				Draws: []*models.Draw{{Tag: "go", Count: 2}, {Count: 1}},
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET draws_ = $1 WHERE id_ = $2", `[{"tag":"go","count":2},{"count":1}]`, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+		},
+		{
+			nameTest: "ok_no_draws",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id: "345",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET draws_ = $1 WHERE id_ = $2", "[]", idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+		},
+		{
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id: "5r4",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {},
+		},
+		{
+			nameTest: "not_found",
+			ctx:      context.Background(),
+			form: models.Form{
+				Id: "345",
+			},
+			mockBehavior: func(ctx context.Context, form *models.Form) {
+				idint, _ := strconv.Atoi(form.Id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET draws_ = $1 WHERE id_ = $2", "[]", idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.form)
+
+			err := r.UpdateDraws(testCase.ctx, &testCase.form)
+
+			switch testCase.nameTest {
+			case "ok", "ok_no_draws":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "not_found":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormRepo_UpdateTemplate(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string, is_template bool)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		is_template  bool
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest:    "ok",
+			ctx:         context.Background(),
+			id:          "345",
+			is_template: true,
+			mockBehavior: func(ctx context.Context, id string, is_template bool) {
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET is_template_ = $1 WHERE id_ = $2", is_template, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string, is_template bool) {},
+		},
+		{
+			nameTest: "not_found",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string, is_template bool) {
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE form_ SET is_template_ = $1 WHERE id_ = $2", is_template, idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id, testCase.is_template)
+
+			err := r.UpdateTemplate(testCase.ctx, testCase.id, testCase.is_template)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "not_found":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormRepo_GetTemplates(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, sets types.GetSets)
+
+	const selectSQL = "SELECT id_, user_id_, title_, description_, status_, opens_at_, closes_at_, one_response_, max_responses_, edit_window_, access_, is_quiz_, show_correct_, time_limit_, draws_ FROM form_ WHERE is_template_ = $1 ORDER BY id_ LIMIT 10 OFFSET 0"
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		sets          types.GetSets
+		mockBehavior  mockBehavior
+		expectedForms []*models.Form
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "user_id_", "title_", "description_", "status_", "opens_at_", "closes_at_", "one_response_", "max_responses_", "edit_window_", "access_", "is_quiz_", "show_correct_", "time_limit_", "draws_"}).
+					AddRow(345, 12, "retro", "sprint retro", "draft", nil, nil, true, 0, 0, "authenticated", true, models.FormShowCorrectAfterSubmission, 0, []byte("[]")).
+					AddRow(346, 13, "poll", "", "open", nil, nil, false, 0, 0, "link", false, models.FormShowCorrectNever, 0, []byte("[]")).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, true).Return(pgxRows, nil)
+			},
+			expectedForms: []*models.Form{
+				{
+					Id:           "345",
+					User_id:      "12",
+					Title:        "retro",
+					Description:  "sprint retro",
+					Status:       models.FormStatusDraft,
+					One_response: true,
+					Access:       models.FormAccessAuthenticated,
+					Is_template:  true,
+					Is_quiz:      true,
+					Show_correct: models.FormShowCorrectAfterSubmission,
+				},
+				{
+					// link secret is not selected
+					Id:           "346",
+					User_id:      "13",
+					Title:        "poll",
+					Status:       models.FormStatusOpen,
+					Access:       models.FormAccessLink,
+					Is_template:  true,
+					Show_correct: models.FormShowCorrectNever,
+				},
+			},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, sets types.GetSets) {
+				mockPool.EXPECT().Query(ctx, selectSQL, true).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.sets)
+
+			got, err := r.GetTemplates(testCase.ctx, testCase.sets)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedForms, got)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormRepo_LockById(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_"}).AddRow(345).ToPgxRows()
+				pgxRows.Next()
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT id_ FROM form_ WHERE id_ = $1 FOR UPDATE", idint).Return(pgxRows)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT id_ FROM form_ WHERE id_ = $1 FOR UPDATE", idint).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			err := r.LockById(testCase.ctx, testCase.id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormRepo_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM form_ WHERE id_ = $1", idint).Return(pgxmock.NewResult("DELETE", 1), nil)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+		},
+		{
+			nameTest: "no_form_to_delete",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM form_ WHERE id_ = $1", idint).Return(pgxmock.NewResult("DELETE", 0), nil)
+			},
+		},
+		{
+			nameTest: "exec_error",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM form_ WHERE id_ = $1", idint).Return(nil, errors.New("exec_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			err := r.Delete(testCase.ctx, testCase.id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_form_to_delete":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			case "exec_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormRepo_GetOwnerId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewFormRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxrows := pgxpoolmock.NewRows([]string{"user_id_"}).AddRow(23).ToPgxRows()
+				pgxrows.Next()
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_ FROM form_ WHERE id_ = $1", idint).Return(pgxrows)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+		},
+		{
+			nameTest: "no_form",
+			ctx:      context.Background(),
+			form_id:  "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"user_id_"}).AddRow(nil).RowError(0, pgx.ErrNoRows).ToPgxRows()
+				pgxRows.Next()
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_ FROM form_ WHERE id_ = $1", idint).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			owner_id, err := r.GetOwnerId(testCase.ctx, testCase.form_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, "23", owner_id)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_form":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func intRef(i int) *int {
+	return &i
+}
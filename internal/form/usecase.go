@@ -7,24 +7,41 @@ import (
 )
 
 type UseCase interface {
+	// Creates form as draft, accessible to authenticated users, if access is not set.
+	// Form is created in workspace, if workspace is set.
 	// Returns created model & nil, if created.
-	// Returns nil & ErrInvalidContent, if invalid inputs.
-	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & ErrInvalidContent, if invalid inputs, unknown access, form closes before it opens or limits are negative.
+	// Returns nil & ErrUnauthorized, if workspace is set and user unauthorized.
+	// Returns nil & ErrForbidden, if workspace role does not grant form edit or permission denied.
 	// Returns nil & other err else.
 	Create(ctx context.Context, model *models.Form) (*models.Form, error)
 
+	// Returns forms of user and forms of workspaces user is member of.
+	// Filters by status, if it is not empty.
+	// Access tokens are cleared of forms user may not edit.
 	// Returns slice & nil, if get smth.
 	// Returns empty slice & nil, if get nothing.
-	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrInvalidContent, if invalid inputs or unknown status.
 	// Returns nil & other err else.
-	GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.Form, error)
+	GetByUserId(ctx context.Context, user_id, status string, sets types.GetSets) ([]*models.Form, error)
 
+	// Access token is cleared, if user may not edit form.
 	// Returns found models & nil, if get.
 	// Returns nil & ErrContentNotFound, if get nothing.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & other err else.
 	GetById(ctx context.Context, id string) (*models.Form, error)
 
+	// Gets form with latest published version for guest holding token.
+	// Returns found models & nil, if get.
+	// Returns nil & ErrContentNotFound, if no such form, form is draft, archived or not published.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if form is accessible to authenticated users only.
+	// Returns nil & ErrForbidden, if token does not match form link.
+	// Returns nil & other err else.
+	GetPublic(ctx context.Context, id, token string) (*models.Form, *models.FormVersion, error)
+
+	// Owner of form is kept, editors update title & description only.
 	// Returns source model & nil, if updated.
 	// Returns nil & ErrContentNotFound, if nothing to update.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
@@ -37,7 +54,134 @@ type UseCase interface {
 	// Returns ErrContentNotFound, if nothing to delete.
 	// Returns ErrInvalidContent, if invalid inputs.
 	// Returns ErrUnauthorized, if user not set in context.
-	// Returns ErrForbidden, if user is not an owner or permission denied.
+	// Returns ErrForbidden, if user is neither an owner nor admin or permission denied.
 	// Returns other errors else.
 	Delete(ctx context.Context, id string) error
+
+	// Moves draft form with published version to open.
+	// Returns updated model & nil, if published.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is not an owner or permission denied.
+	// Returns nil & ErrInvalidTransition, if form is not draft or has no published version.
+	// Returns nil & other err else.
+	Publish(ctx context.Context, id string) (*models.Form, error)
+
+	// Moves open form to closed.
+	// Returns updated model & nil, if closed.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is neither an owner nor admin or permission denied.
+	// Returns nil & ErrInvalidTransition, if form is not open.
+	// Returns nil & other err else.
+	Close(ctx context.Context, id string) (*models.Form, error)
+
+	// Moves closed form to open.
+	// Returns updated model & nil, if reopened.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is not an owner or permission denied.
+	// Returns nil & ErrInvalidTransition, if form is not closed.
+	// Returns nil & other err else.
+	Reopen(ctx context.Context, id string) (*models.Form, error)
+
+	// Moves form to archived.
+	// Returns updated model & nil, if archived.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is neither an owner nor admin or permission denied.
+	// Returns nil & ErrInvalidTransition, if form is already archived.
+	// Returns nil & other err else.
+	Archive(ctx context.Context, id string) (*models.Form, error)
+
+	// Sets opens_at and closes_at of form, nil bound is cleared.
+	// Returns updated model & nil, if set.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs or form closes before it opens.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is not an owner or permission denied.
+	// Returns nil & other err else.
+	SetSchedule(ctx context.Context, model *models.Form) (*models.Form, error)
+
+	// Sets one_response, max_responses and edit_window of form.
+	// Returns updated model & nil, if set.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs or limits are negative.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is not an owner or permission denied.
+	// Returns nil & other err else.
+	SetLimits(ctx context.Context, model *models.Form) (*models.Form, error)
+
+	// Sets access of form, new access token is generated for each link access set.
+	// Returns updated model & nil, if set.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs or unknown access.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is not an owner or permission denied.
+	// Returns nil & other err else.
+	SetAccess(ctx context.Context, model *models.Form) (*models.Form, error)
+
+	// Sets quiz mode of form and when respondents see correct answers.
+	// Pool answers are scored only, if created while form is quiz.
+	// Returns updated model & nil, if set.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs or unknown correct answers visibility.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is not an owner or permission denied.
+	// Returns nil & other err else.
+	SetQuiz(ctx context.Context, model *models.Form) (*models.Form, error)
+
+	// Sets rules to draw random questions of owner bank into each attempt, no questions are drawn, if empty.
+	// Attempts started before keep questions drawn into them.
+	// Returns updated model & nil, if set.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs, count of draw is not positive or tags of draws repeat.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is not an owner or permission denied.
+	// Returns nil & other err else.
+	SetDraws(ctx context.Context, model *models.Form) (*models.Form, error)
+
+	// Returns document of form with its draft sections and questions & nil, if get.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is neither an owner nor moderator or admin.
+	// Returns nil & other err else.
+	Export(ctx context.Context, id string) (*models.FormDocument, error)
+
+	// Creates draft form of document user with document sections and questions in one transaction.
+	// Returns created form & nil, if imported.
+	// Returns nil & ErrInvalidContent, if unsupported schema version, invalid form, draws, sections or questions,
+	// or section or condition refers to what is not in document.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Import(ctx context.Context, document *models.FormDocument) (*models.Form, error)
+
+	// Copies form with its draft sections and questions as new draft form of current user in one transaction.
+	// Answering period, template flag, versions and answers are not copied.
+	// Returns created form & nil, if cloned.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if form is not template and user is neither form owner or member nor moderator or admin.
+	// Returns nil & other err else.
+	Clone(ctx context.Context, id string) (*models.Form, error)
+
+	// Marks form as template or unmarks it.
+	// Returns updated model & nil, if set.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user not set in context.
+	// Returns nil & ErrForbidden, if user is not an owner or permission denied.
+	// Returns nil & other err else.
+	SetTemplate(ctx context.Context, id string, is_template bool) (*models.Form, error)
+
+	// Returns template forms of all users without access tokens & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & other err else.
+	GetTemplates(ctx context.Context, sets types.GetSets) ([]*models.Form, error)
 }
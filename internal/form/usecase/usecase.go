@@ -2,45 +2,84 @@ package usecase
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"quizapp/internal/authz"
 	"quizapp/internal/form"
+	"quizapp/internal/question"
+	"quizapp/internal/section"
+	"quizapp/internal/version"
 	"quizapp/models"
 	"quizapp/pkg/errs"
+	"quizapp/pkg/transactor"
 	"quizapp/pkg/types"
 )
 
 type formUseCase struct {
-	formRepo   form.Repo
-	ctxUserKey string
+	formRepo     form.Repo
+	versionRepo  version.Repo
+	questionRepo question.Repo
+	sectionRepo  section.Repo
+	authorizer   authz.Authorizer
+	transactor   transactor.Transactor
+	ctxUserKey   string
 }
 
-func NewFormUseCase(formRepo form.Repo, ctxUserKey string) form.UseCase {
+func NewFormUseCase(formRepo form.Repo, versionRepo version.Repo, questionRepo question.Repo, sectionRepo section.Repo, authorizer authz.Authorizer, transactor transactor.Transactor, ctxUserKey string) form.UseCase {
 	return &formUseCase{
-		formRepo:   formRepo,
-		ctxUserKey: ctxUserKey,
+		formRepo:     formRepo,
+		versionRepo:  versionRepo,
+		questionRepo: questionRepo,
+		sectionRepo:  sectionRepo,
+		authorizer:   authorizer,
+		transactor:   transactor,
+		ctxUserKey:   ctxUserKey,
 	}
 }
 
 func (f *formUseCase) Create(ctx context.Context, model *models.Form) (*models.Form, error) {
+	err := prepareCreate(model)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.Workspace_id != "" {
+		err = f.authorizer.AuthorizeWorkspace(ctx, model.Workspace_id, models.ActionFormEdit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	return f.formRepo.Create(ctx, model)
 }
 
-func (f *formUseCase) GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.Form, error) {
-	return f.formRepo.GetByUserId(ctx, user_id, sets)
+func (f *formUseCase) GetByUserId(ctx context.Context, user_id, status string, sets types.GetSets) ([]*models.Form, error) {
+	if status != "" && !models.ValidateFormStatus(status) {
+		return nil, errs.ErrInvalidContent
+	}
+
+	// viewers of shared and workspace forms do not get their links, repo reads them for editors only
+	foundforms, err := f.formRepo.GetByUserId(ctx, user_id, status, sets)
+	if err != nil {
+		return nil, err
+	}
+
+	return foundforms, nil
 }
 
 func (f *formUseCase) Update(ctx context.Context, model *models.Form) (*models.Form, error) {
-	err := f.formRepo.ValidateIsOwner(ctx, model.Id)
+	err := f.authorizer.AuthorizeForm(ctx, model.Id, models.ActionFormEdit)
 	if err != nil {
 		return nil, err
 	}
 
-	currentuser, ok := ctx.Value(f.ctxUserKey).(*models.User)
-	if !ok {
-		return nil, errs.ErrUnauthorized
+	foundform, err := f.formRepo.GetById(ctx, model.Id)
+	if err != nil {
+		return nil, err
 	}
 
-	// user can not give own form to anyone
-	model.User_id = currentuser.Id
+	// editors other than owner keep form owned by its owner
+	model.User_id = foundform.User_id
 
 	_, err = f.formRepo.Update(ctx, model)
 	if err != nil {
@@ -51,7 +90,7 @@ func (f *formUseCase) Update(ctx context.Context, model *models.Form) (*models.F
 }
 
 func (f *formUseCase) Delete(ctx context.Context, id string) error {
-	err := f.formRepo.ValidateIsOwner(ctx, id)
+	err := f.authorizer.AuthorizeForm(ctx, id, models.ActionFormDelete)
 	if err != nil {
 		return err
 	}
@@ -65,5 +104,495 @@ func (f *formUseCase) Delete(ctx context.Context, id string) error {
 }
 
 func (f *formUseCase) GetById(ctx context.Context, id string) (*models.Form, error) {
-	return f.formRepo.GetById(ctx, id)
+	foundform, err := f.formRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	f.hideAccessToken(ctx, foundform)
+
+	return foundform, nil
+}
+
+func (f *formUseCase) GetPublic(ctx context.Context, id, token string) (*models.Form, *models.FormVersion, error) {
+	foundform, err := f.formRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if foundform.Status == models.FormStatusDraft || foundform.Status == models.FormStatusArchived {
+		return nil, nil, errs.ErrContentNotFound
+	}
+
+	if !foundform.IsAccessible("", token) {
+		if foundform.Access == models.FormAccessAuthenticated {
+			return nil, nil, errs.ErrUnauthorized
+		}
+
+		return nil, nil, errs.ErrForbidden
+	}
+
+	latest, err := f.versionRepo.GetLatestByFormId(ctx, id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	foundform.Access_token = ""
+
+	return foundform, latest, nil
+}
+
+func (f *formUseCase) Publish(ctx context.Context, id string) (*models.Form, error) {
+	err := f.authorizer.AuthorizeForm(ctx, id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	// respondents answer published version, so there must be one
+	_, err = f.versionRepo.GetLatestByFormId(ctx, id)
+	if err != nil {
+		if err == errs.ErrContentNotFound {
+			return nil, errs.ErrInvalidTransition
+		}
+
+		return nil, err
+	}
+
+	return f.transit(ctx, id, models.FormStatusOpen, models.FormStatusDraft)
+}
+
+func (f *formUseCase) Close(ctx context.Context, id string) (*models.Form, error) {
+	err := f.authorizer.AuthorizeForm(ctx, id, models.ActionFormClose)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.transit(ctx, id, models.FormStatusClosed, models.FormStatusOpen)
+}
+
+func (f *formUseCase) Reopen(ctx context.Context, id string) (*models.Form, error) {
+	err := f.authorizer.AuthorizeForm(ctx, id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.transit(ctx, id, models.FormStatusOpen, models.FormStatusClosed)
+}
+
+func (f *formUseCase) Archive(ctx context.Context, id string) (*models.Form, error) {
+	err := f.authorizer.AuthorizeForm(ctx, id, models.ActionFormClose)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.transit(ctx, id, models.FormStatusArchived,
+		models.FormStatusDraft, models.FormStatusOpen, models.FormStatusClosed)
+}
+
+func (f *formUseCase) SetSchedule(ctx context.Context, model *models.Form) (*models.Form, error) {
+	if !model.ValidateSchedule() {
+		return nil, errs.ErrInvalidContent
+	}
+
+	err := f.authorizer.AuthorizeForm(ctx, model.Id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.formRepo.UpdateSchedule(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.formRepo.GetById(ctx, model.Id)
+}
+
+func (f *formUseCase) SetLimits(ctx context.Context, model *models.Form) (*models.Form, error) {
+	if !model.ValidateLimits() {
+		return nil, errs.ErrInvalidContent
+	}
+
+	err := f.authorizer.AuthorizeForm(ctx, model.Id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.formRepo.UpdateLimits(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.formRepo.GetById(ctx, model.Id)
+}
+
+func (f *formUseCase) SetAccess(ctx context.Context, model *models.Form) (*models.Form, error) {
+	if !models.ValidateFormAccess(model.Access) {
+		return nil, errs.ErrInvalidContent
+	}
+
+	err := f.authorizer.AuthorizeForm(ctx, model.Id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = setAccessToken(model)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.formRepo.UpdateAccess(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.formRepo.GetById(ctx, model.Id)
+}
+
+func (f *formUseCase) SetQuiz(ctx context.Context, model *models.Form) (*models.Form, error) {
+	if !models.ValidateFormShowCorrect(model.Show_correct) {
+		return nil, errs.ErrInvalidContent
+	}
+
+	err := f.authorizer.AuthorizeForm(ctx, model.Id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.formRepo.UpdateQuiz(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.formRepo.GetById(ctx, model.Id)
+}
+
+func (f *formUseCase) SetDraws(ctx context.Context, model *models.Form) (*models.Form, error) {
+	if !models.ValidateDraws(model.Draws) {
+		return nil, errs.ErrInvalidContent
+	}
+
+	err := f.authorizer.AuthorizeForm(ctx, model.Id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.formRepo.UpdateDraws(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.formRepo.GetById(ctx, model.Id)
+}
+
+// Sets new random access token for link mode, clears it else.
+// Returns nil, if set.
+// Returns err, if no randomness.
+func setAccessToken(model *models.Form) error {
+	model.Access_token = ""
+
+	if model.Access != models.FormAccessLink {
+		return nil
+	}
+
+	b := make([]byte, 16)
+
+	_, err := rand.Read(b)
+	if err != nil {
+		return err
+	}
+
+	model.Access_token = hex.EncodeToString(b)
+
+	return nil
+}
+
+// Moves form to status, if current status is one of from.
+// Lifecycle: draft -> open <-> closed, any but archived -> archived.
+func (f *formUseCase) transit(ctx context.Context, id, status string, from ...string) (*models.Form, error) {
+	foundform, err := f.formRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	if !contains(from, foundform.Status) {
+		return nil, errs.ErrInvalidTransition
+	}
+
+	err = f.formRepo.UpdateStatus(ctx, id, status)
+	if err != nil {
+		return nil, err
+	}
+
+	foundform.Status = status
+
+	return foundform, nil
+}
+
+func contains(statuses []string, status string) bool {
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *formUseCase) Export(ctx context.Context, id string) (*models.FormDocument, error) {
+	err := f.authorizer.AuthorizeForm(ctx, id, models.ActionFormView)
+	if err != nil {
+		return nil, err
+	}
+
+	foundform, err := f.formRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.readDocument(ctx, foundform)
+}
+
+func (f *formUseCase) Import(ctx context.Context, document *models.FormDocument) (*models.Form, error) {
+	if !document.ValidateSchemaVersion() || document.Form == nil || !models.ValidateDraws(document.Form.Draws) {
+		return nil, errs.ErrInvalidContent
+	}
+
+	for _, s := range document.Sections {
+		if s.Title == "" {
+			return nil, errs.ErrInvalidContent
+		}
+	}
+
+	for _, q := range document.Questions {
+		if q.Type == "" {
+			q.Type = models.QuestionTypeText
+		}
+
+		if q.Header == "" || !q.ValidateOptions() || !q.ValidateRules() || !q.ValidateScoring() {
+			return nil, errs.ErrInvalidContent
+		}
+	}
+
+	if !document.ValidateReferences() {
+		return nil, errs.ErrInvalidContent
+	}
+
+	return f.createFromDocument(ctx, document)
+}
+
+func (f *formUseCase) Clone(ctx context.Context, id string) (*models.Form, error) {
+	currentuser, ok := ctx.Value(f.ctxUserKey).(*models.User)
+	if !ok {
+		return nil, errs.ErrUnauthorized
+	}
+
+	foundform, err := f.formRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// template is cloned by anyone, other forms by those who see them
+	if !foundform.Is_template {
+		err = f.authorizer.AuthorizeForm(ctx, id, models.ActionFormView)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	document, err := f.readDocument(ctx, foundform)
+	if err != nil {
+		return nil, err
+	}
+
+	// answering period is not copied, clone is planned anew
+	document.Form = &models.Form{
+		User_id:       currentuser.Id,
+		Title:         foundform.Title,
+		Description:   foundform.Description,
+		One_response:  foundform.One_response,
+		Max_responses: foundform.Max_responses,
+		Edit_window:   foundform.Edit_window,
+		Time_limit:    foundform.Time_limit,
+		Access:        foundform.Access,
+		Is_quiz:       foundform.Is_quiz,
+		Show_correct:  foundform.Show_correct,
+		// draws take questions of bank of clone owner
+		Draws: foundform.Draws,
+	}
+
+	return f.createFromDocument(ctx, document)
+}
+
+func (f *formUseCase) SetTemplate(ctx context.Context, id string, is_template bool) (*models.Form, error) {
+	err := f.authorizer.AuthorizeForm(ctx, id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = f.formRepo.UpdateTemplate(ctx, id, is_template)
+	if err != nil {
+		return nil, err
+	}
+
+	return f.GetById(ctx, id)
+}
+
+func (f *formUseCase) GetTemplates(ctx context.Context, sets types.GetSets) ([]*models.Form, error) {
+	return f.formRepo.GetTemplates(ctx, sets)
+}
+
+// Link secret is shown to those who may edit form only.
+func (f *formUseCase) hideAccessToken(ctx context.Context, foundform *models.Form) {
+	if foundform.Access_token == "" {
+		return
+	}
+
+	if f.authorizer.AuthorizeForm(ctx, foundform.Id, models.ActionFormEdit) != nil {
+		foundform.Access_token = ""
+	}
+}
+
+// Reads form with its sections and questions, their ids are ids of document.
+func (f *formUseCase) readDocument(ctx context.Context, foundform *models.Form) (*models.FormDocument, error) {
+	sections, err := f.sectionRepo.GetByFormId(ctx, foundform.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := f.questionRepo.GetAllByFormId(ctx, foundform.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.FormDocument{
+		Schema_version: models.FormDocumentSchemaVersion,
+		Form:           foundform,
+		Sections:       sections,
+		Questions:      questions,
+	}, nil
+}
+
+// Creates new draft form with sections and questions of document in one transaction.
+// Sections and questions are appended in document order, document ids are replaced
+// with ids of created ones. Conditions are set after all questions are created,
+// as they may refer to later questions.
+func (f *formUseCase) createFromDocument(ctx context.Context, document *models.FormDocument) (*models.Form, error) {
+	model := document.Form
+
+	err := prepareCreate(model)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdform *models.Form
+
+	err = f.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		createdform, err = f.formRepo.Create(ctx, model)
+		if err != nil {
+			return err
+		}
+
+		sectionids := make(map[string]string, len(document.Sections))
+		createdsections := make([]*models.Section, len(document.Sections))
+
+		for i, s := range document.Sections {
+			createdsections[i], err = f.sectionRepo.Create(ctx, &models.Section{
+				Form_id:     createdform.Id,
+				Title:       s.Title,
+				Description: s.Description,
+			})
+			if err != nil {
+				return err
+			}
+
+			sectionids[s.Id] = createdsections[i].Id
+		}
+
+		questionids := make(map[string]string, len(document.Questions))
+		createdquestions := make([]*models.Question, len(document.Questions))
+
+		for i, q := range document.Questions {
+			createdquestions[i], err = f.questionRepo.Create(ctx, &models.Question{
+				Form_id:    createdform.Id,
+				Section_id: sectionids[q.Section_id],
+				Header:     q.Header,
+				Type:       q.Type,
+				Options:    q.Options,
+				Required:   q.Required,
+				Rules:      q.Rules,
+				Correct:    q.Correct,
+				Points:     q.Points,
+			})
+			if err != nil {
+				return err
+			}
+
+			if q.Id != "" {
+				questionids[q.Id] = createdquestions[i].Id
+			}
+		}
+
+		for i, q := range document.Questions {
+			if q.Condition == nil {
+				continue
+			}
+
+			createdquestions[i].Condition = remapCondition(q.Condition, questionids)
+
+			_, err = f.questionRepo.Update(ctx, createdquestions[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		for i, s := range document.Sections {
+			if s.Condition == nil {
+				continue
+			}
+
+			createdsections[i].Condition = remapCondition(s.Condition, questionids)
+
+			_, err = f.sectionRepo.Update(ctx, createdsections[i])
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdform, nil
+}
+
+// Returns copy of condition referring to question by its new id.
+func remapCondition(c *models.Condition, questionids map[string]string) *models.Condition {
+	return &models.Condition{
+		Question_id: questionids[c.Question_id],
+		Op:          c.Op,
+		Value:       c.Value,
+	}
+}
+
+// Sets defaults of new form and validates it.
+// Every form starts as draft accessible to authenticated users, if access is not set.
+// Correct answers of quiz are never shown, if their visibility is not set.
+func prepareCreate(model *models.Form) error {
+	if model.Access == "" {
+		model.Access = models.FormAccessAuthenticated
+	}
+
+	if model.Show_correct == "" {
+		model.Show_correct = models.FormShowCorrectNever
+	}
+
+	if !model.ValidateSchedule() || !model.ValidateLimits() || !models.ValidateFormAccess(model.Access) ||
+		!models.ValidateFormShowCorrect(model.Show_correct) {
+		return errs.ErrInvalidContent
+	}
+
+	model.Status = models.FormStatusDraft
+
+	return setAccessToken(model)
 }
@@ -3,27 +3,44 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	mockauthz "quizapp/internal/authz/mock"
 	"quizapp/internal/form/mock"
 	"quizapp/internal/form/usecase"
+	mockq "quizapp/internal/question/mock"
+	mocksec "quizapp/internal/section/mock"
+	mockv "quizapp/internal/version/mock"
 	"quizapp/models"
 	"quizapp/pkg/errs"
+	mocktx "quizapp/pkg/transactor/mock"
 	"quizapp/pkg/types"
+	"strconv"
 	"testing"
+	"time"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 )
 
+var (
+	_opensAt  = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	_closesAt = time.Date(2023, 6, 1, 0, 0, 0, 0, time.UTC)
+)
+
 func TestFormUseCase_Create(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
 	ctxUserKey := "ctxuserkey"
 
-	uc := usecase.NewFormUseCase(mockRepo, ctxUserKey)
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
 
 	type mockBehavior func(ctx context.Context, model *models.Form)
 
@@ -43,11 +60,21 @@ func TestFormUseCase_Create(t *testing.T) {
 				Description: "desc",
 			},
 			mockBehavior: func(ctx context.Context, model *models.Form) {
-				mockRepo.EXPECT().Create(ctx, model).Return(&models.Form{
+				mockRepo.EXPECT().Create(ctx, &models.Form{
+					User_id:     model.User_id,
+					Title:       model.Title,
+					Description: model.Description,
+					Status:      models.FormStatusDraft,
+					Access:      models.FormAccessAuthenticated,
+
+					Show_correct: models.FormShowCorrectNever,
+				}).Return(&models.Form{
 					Id:          "1",
 					User_id:     model.User_id,
 					Title:       model.Title,
 					Description: model.Description,
+					Status:      models.FormStatusDraft,
+					Access:      models.FormAccessAuthenticated,
 				}, nil)
 			},
 			expectedModel: models.Form{
@@ -55,7 +82,96 @@ func TestFormUseCase_Create(t *testing.T) {
 				User_id:     "5",
 				Title:       "title",
 				Description: "desc",
+				Status:      models.FormStatusDraft,
+				Access:      models.FormAccessAuthenticated,
+			},
+		},
+		{
+			nameTest: "ok_workspace",
+			ctx:      context.Background(),
+			model: models.Form{
+				User_id:      "5",
+				Workspace_id: "7",
+				Title:        "title",
+				Description:  "desc",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, model.Workspace_id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().Create(ctx, &models.Form{
+					User_id:      model.User_id,
+					Workspace_id: model.Workspace_id,
+					Title:        model.Title,
+					Description:  model.Description,
+					Status:       models.FormStatusDraft,
+					Access:       models.FormAccessAuthenticated,
+
+					Show_correct: models.FormShowCorrectNever,
+				}).Return(&models.Form{
+					Id:           "1",
+					User_id:      model.User_id,
+					Workspace_id: model.Workspace_id,
+					Title:        model.Title,
+					Description:  model.Description,
+					Status:       models.FormStatusDraft,
+					Access:       models.FormAccessAuthenticated,
+				}, nil)
+			},
+			expectedModel: models.Form{
+				Id:           "1",
+				User_id:      "5",
+				Workspace_id: "7",
+				Title:        "title",
+				Description:  "desc",
+				Status:       models.FormStatusDraft,
+				Access:       models.FormAccessAuthenticated,
+			},
+		},
+		{
+			nameTest: "workspace_viewer",
+			ctx:      context.Background(),
+			model: models.Form{
+				User_id:      "5",
+				Workspace_id: "7",
+				Title:        "title",
+				Description:  "desc",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, model.Workspace_id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+		},
+		{
+			nameTest: "unknown_access",
+			ctx:      context.Background(),
+			model: models.Form{
+				User_id:     "5",
+				Title:       "title",
+				Description: "desc",
+				Access:      "friends",
 			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {},
+		},
+		{
+			nameTest: "unknown_show_correct",
+			ctx:      context.Background(),
+			model: models.Form{
+				User_id:      "5",
+				Title:        "title",
+				Description:  "desc",
+				Show_correct: "sometimes",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {},
+		},
+		{
+			nameTest: "closes_before_opens",
+			ctx:      context.Background(),
+			model: models.Form{
+				User_id:     "5",
+				Title:       "title",
+				Description: "desc",
+				Opens_at:    &_closesAt,
+				Closes_at:   &_opensAt,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {},
 		},
 	}
 
@@ -66,9 +182,13 @@ func TestFormUseCase_Create(t *testing.T) {
 			got, err := uc.Create(testCase.ctx, &testCase.model)
 
 			switch testCase.nameTest {
-			case "ok":
+			case "ok", "ok_workspace":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedModel, *got)
+			case "closes_before_opens", "unknown_access", "unknown_show_correct":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "workspace_viewer":
+				assert.Equal(t, errs.ErrForbidden, err)
 			default:
 				assert.Error(t, errors.New("No case"), "No case")
 			}
@@ -82,17 +202,23 @@ func TestFormUseCase_GetByUserId(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
 	ctxUserKey := "ctxuserkey"
 
-	uc := usecase.NewFormUseCase(mockRepo, ctxUserKey)
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
 
-	type mockBehavior func(ctx context.Context, user_id string, sets types.GetSets)
+	type mockBehavior func(ctx context.Context, user_id, status string, sets types.GetSets)
 
 	testTable := []struct {
 		nameTest       string
 		ctx            context.Context
 		user_id        string
+		status         string
 		sets           types.GetSets
 		mockBehavior   mockBehavior
 		expectedModels []*models.Form
@@ -102,8 +228,8 @@ func TestFormUseCase_GetByUserId(t *testing.T) {
 			ctx:      context.Background(),
 			user_id:  "5",
 			sets:     types.GetSets{},
-			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
-				mockRepo.EXPECT().GetByUserId(ctx, user_id, sets).Return([]*models.Form{
+			mockBehavior: func(ctx context.Context, user_id, status string, sets types.GetSets) {
+				mockRepo.EXPECT().GetByUserId(ctx, user_id, status, sets).Return([]*models.Form{
 					{
 						Id:          "1",
 						User_id:     user_id,
@@ -133,18 +259,28 @@ func TestFormUseCase_GetByUserId(t *testing.T) {
 				},
 			},
 		},
+		{
+			nameTest:     "unknown_status",
+			ctx:          context.Background(),
+			user_id:      "5",
+			status:       "deleted",
+			sets:         types.GetSets{},
+			mockBehavior: func(ctx context.Context, user_id, status string, sets types.GetSets) {},
+		},
 	}
 
 	for _, testCase := range testTable {
 		t.Run(testCase.nameTest, func(t *testing.T) {
-			testCase.mockBehavior(testCase.ctx, testCase.user_id, testCase.sets)
+			testCase.mockBehavior(testCase.ctx, testCase.user_id, testCase.status, testCase.sets)
 
-			got, err := uc.GetByUserId(testCase.ctx, testCase.user_id, testCase.sets)
+			got, err := uc.GetByUserId(testCase.ctx, testCase.user_id, testCase.status, testCase.sets)
 
 			switch testCase.nameTest {
 			case "ok":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedModels, got)
+			case "unknown_status":
+				assert.Equal(t, errs.ErrInvalidContent, err)
 			default:
 				assert.Error(t, errors.New("No case"), "No case")
 			}
@@ -158,10 +294,15 @@ func TestFormUseCase_GetById(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
 	ctxUserKey := "ctxuserkey"
 
-	uc := usecase.NewFormUseCase(mockRepo, ctxUserKey)
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
 
 	type mockBehavior func(ctx context.Context, id string)
 
@@ -191,6 +332,46 @@ func TestFormUseCase_GetById(t *testing.T) {
 				Description: "desc1",
 			},
 		},
+		{
+			// members and workspace editors see link as well as owner
+			nameTest: "token_shown_to_editor",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "6"}),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.Form{
+					Id:           id,
+					User_id:      "5",
+					Access:       models.FormAccessLink,
+					Access_token: "secret",
+				}, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormEdit).Return(nil)
+			},
+			expectedModel: models.Form{
+				Id:           "1",
+				User_id:      "5",
+				Access:       models.FormAccessLink,
+				Access_token: "secret",
+			},
+		},
+		{
+			nameTest: "token_hidden_from_others",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "7"}),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.Form{
+					Id:           id,
+					User_id:      "5",
+					Access:       models.FormAccessLink,
+					Access_token: "secret",
+				}, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+			expectedModel: models.Form{
+				Id:      "1",
+				User_id: "5",
+				Access:  models.FormAccessLink,
+			},
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -200,7 +381,7 @@ func TestFormUseCase_GetById(t *testing.T) {
 			got, err := uc.GetById(testCase.ctx, testCase.id)
 
 			switch testCase.nameTest {
-			case "ok":
+			case "ok", "token_shown_to_editor", "token_hidden_from_others":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedModel, *got)
 			default:
@@ -216,10 +397,15 @@ func TestFormUseCase_Delete(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
 	ctxUserKey := "ctxuserkey"
 
-	uc := usecase.NewFormUseCase(mockRepo, ctxUserKey)
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
 
 	type mockBehavior func(ctx context.Context, id string)
 
@@ -234,7 +420,7 @@ func TestFormUseCase_Delete(t *testing.T) {
 			ctx:      context.Background(),
 			id:       "1",
 			mockBehavior: func(ctx context.Context, id string) {
-				mockRepo.EXPECT().ValidateIsOwner(ctx, id).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormDelete).Return(nil)
 				mockRepo.EXPECT().Delete(ctx, id).Return(nil)
 			},
 		},
@@ -243,7 +429,7 @@ func TestFormUseCase_Delete(t *testing.T) {
 			ctx:      context.Background(),
 			id:       "1",
 			mockBehavior: func(ctx context.Context, id string) {
-				mockRepo.EXPECT().ValidateIsOwner(ctx, id).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormDelete).Return(nil)
 				mockRepo.EXPECT().Delete(ctx, id).Return(errors.New("repo_delete_error"))
 			},
 		},
@@ -252,7 +438,7 @@ func TestFormUseCase_Delete(t *testing.T) {
 			ctx:      context.Background(),
 			id:       "1",
 			mockBehavior: func(ctx context.Context, id string) {
-				mockRepo.EXPECT().ValidateIsOwner(ctx, id).Return(errs.ErrForbidden)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormDelete).Return(errs.ErrForbidden)
 			},
 		},
 	}
@@ -283,10 +469,15 @@ func TestFormUseCase_Update(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
 	ctxUserKey := "ctxuserkey"
 
-	uc := usecase.NewFormUseCase(mockRepo, ctxUserKey)
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
 
 	type mockBehavior func(ctx context.Context, model *models.Form)
 
@@ -307,12 +498,13 @@ func TestFormUseCase_Update(t *testing.T) {
 				Description: "desc",
 			},
 			mockBehavior: func(ctx context.Context, model *models.Form) {
-				mockRepo.EXPECT().ValidateIsOwner(ctx, model.Id).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(&models.Form{Id: "1", User_id: "9"}, nil)
 				mockRepo.EXPECT().Update(ctx, model).Return(model, nil)
 			},
 			expectedModel: models.Form{
 				Id:          "1",
-				User_id:     "5",
+				User_id:     "9",
 				Title:       "title",
 				Description: "desc",
 			},
@@ -327,7 +519,7 @@ func TestFormUseCase_Update(t *testing.T) {
 				Description: "desc",
 			},
 			mockBehavior: func(ctx context.Context, model *models.Form) {
-				mockRepo.EXPECT().ValidateIsOwner(ctx, model.Id).Return(errors.New("user_is_not_an_owner"))
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(errors.New("user_is_not_an_owner"))
 			},
 		},
 		{
@@ -340,10 +532,24 @@ func TestFormUseCase_Update(t *testing.T) {
 				Description: "desc",
 			},
 			mockBehavior: func(ctx context.Context, model *models.Form) {
-				mockRepo.EXPECT().ValidateIsOwner(ctx, model.Id).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(&models.Form{Id: "1", User_id: "5"}, nil)
 				mockRepo.EXPECT().Update(ctx, model).Return(nil, errors.New("repo_update_error"))
 			},
 		},
+		{
+			nameTest: "repo_get_error",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "5"}),
+			model: models.Form{
+				Id:          "1",
+				Title:       "title",
+				Description: "desc",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(nil, errs.ErrContentNotFound)
+			},
+		},
 		{
 			nameTest: "unauthorized",
 			ctx:      context.Background(),
@@ -354,7 +560,7 @@ func TestFormUseCase_Update(t *testing.T) {
 				Description: "desc",
 			},
 			mockBehavior: func(ctx context.Context, model *models.Form) {
-				mockRepo.EXPECT().ValidateIsOwner(ctx, model.Id).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(errs.ErrUnauthorized)
 			},
 		},
 	}
@@ -371,6 +577,8 @@ func TestFormUseCase_Update(t *testing.T) {
 				assert.Equal(t, testCase.expectedModel, *got)
 			case "user_is_not_an_owner", "repo_update_error":
 				assert.NotEqual(t, nil, err)
+			case "repo_get_error":
+				assert.Equal(t, errs.ErrContentNotFound, err)
 			case "unauthorized":
 				assert.Equal(t, errs.ErrUnauthorized, err)
 			default:
@@ -379,3 +587,1490 @@ func TestFormUseCase_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestFormUseCase_Publish(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		id            string
+		mockBehavior  mockBehavior
+		expectedModel models.Form
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormEdit).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, id).Return(&models.FormVersion{Id: "3", Form_id: id}, nil)
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.Form{
+					Id:     id,
+					Status: models.FormStatusDraft,
+				}, nil)
+				mockRepo.EXPECT().UpdateStatus(ctx, id, models.FormStatusOpen).Return(nil)
+			},
+			expectedModel: models.Form{
+				Id:     "1",
+				Status: models.FormStatusOpen,
+			},
+		},
+		{
+			nameTest: "no_versions",
+			ctx:      context.Background(),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormEdit).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, id).Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "not_draft",
+			ctx:      context.Background(),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormEdit).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, id).Return(&models.FormVersion{Id: "3", Form_id: id}, nil)
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.Form{
+					Id:     id,
+					Status: models.FormStatusClosed,
+				}, nil)
+			},
+		},
+		{
+			nameTest: "user_not_an_owner",
+			ctx:      context.Background(),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := uc.Publish(testCase.ctx, testCase.id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedModel, *got)
+			case "no_versions", "not_draft":
+				assert.Equal(t, errs.ErrInvalidTransition, err)
+			case "user_not_an_owner":
+				assert.Equal(t, errs.ErrForbidden, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormUseCase_Transitions(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type transition func(ctx context.Context, id string) (*models.Form, error)
+
+	testTable := []struct {
+		nameTest   string
+		ctx        context.Context
+		id         string
+		transition transition
+		action     string
+		from, to   string
+	}{
+		{
+			nameTest:   "close_open",
+			ctx:        context.Background(),
+			id:         "1",
+			transition: uc.Close,
+			action:     models.ActionFormClose,
+			from:       models.FormStatusOpen,
+			to:         models.FormStatusClosed,
+		},
+		{
+			nameTest:   "close_draft",
+			ctx:        context.Background(),
+			id:         "1",
+			transition: uc.Close,
+			action:     models.ActionFormClose,
+			from:       models.FormStatusDraft,
+		},
+		{
+			nameTest:   "reopen_closed",
+			ctx:        context.Background(),
+			id:         "1",
+			transition: uc.Reopen,
+			action:     models.ActionFormEdit,
+			from:       models.FormStatusClosed,
+			to:         models.FormStatusOpen,
+		},
+		{
+			nameTest:   "reopen_archived",
+			ctx:        context.Background(),
+			id:         "1",
+			transition: uc.Reopen,
+			action:     models.ActionFormEdit,
+			from:       models.FormStatusArchived,
+		},
+		{
+			nameTest:   "archive_open",
+			ctx:        context.Background(),
+			id:         "1",
+			transition: uc.Archive,
+			action:     models.ActionFormClose,
+			from:       models.FormStatusOpen,
+			to:         models.FormStatusArchived,
+		},
+		{
+			nameTest:   "archive_archived",
+			ctx:        context.Background(),
+			id:         "1",
+			transition: uc.Archive,
+			action:     models.ActionFormClose,
+			from:       models.FormStatusArchived,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			mockAuthz.EXPECT().AuthorizeForm(testCase.ctx, testCase.id, testCase.action).Return(nil)
+			mockRepo.EXPECT().GetById(testCase.ctx, testCase.id).Return(&models.Form{
+				Id:     testCase.id,
+				Status: testCase.from,
+			}, nil)
+
+			if testCase.to != "" {
+				mockRepo.EXPECT().UpdateStatus(testCase.ctx, testCase.id, testCase.to).Return(nil)
+			}
+
+			got, err := testCase.transition(testCase.ctx, testCase.id)
+
+			switch testCase.nameTest {
+			case "close_open", "reopen_closed", "archive_open":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.to, got.Status)
+			case "close_draft", "reopen_archived", "archive_archived":
+				assert.Equal(t, errs.ErrInvalidTransition, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormUseCase_SetSchedule(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.Form)
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		model         models.Form
+		mockBehavior  mockBehavior
+		expectedModel models.Form
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:        "1",
+				Opens_at:  &_opensAt,
+				Closes_at: &_closesAt,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().UpdateSchedule(ctx, model).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(&models.Form{
+					Id:        model.Id,
+					Status:    models.FormStatusOpen,
+					Opens_at:  model.Opens_at,
+					Closes_at: model.Closes_at,
+				}, nil)
+			},
+			expectedModel: models.Form{
+				Id:        "1",
+				Status:    models.FormStatusOpen,
+				Opens_at:  &_opensAt,
+				Closes_at: &_closesAt,
+			},
+		},
+		{
+			nameTest: "closes_before_opens",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:        "1",
+				Opens_at:  &_closesAt,
+				Closes_at: &_opensAt,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {},
+		},
+		{
+			nameTest: "user_not_an_owner",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id: "1",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.SetSchedule(testCase.ctx, &testCase.model)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedModel, *got)
+			case "closes_before_opens":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "user_not_an_owner":
+				assert.Equal(t, errs.ErrForbidden, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormUseCase_SetLimits(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.Form)
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		model         models.Form
+		mockBehavior  mockBehavior
+		expectedModel models.Form
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:            "1",
+				One_response:  true,
+				Max_responses: 100,
+				Edit_window:   time.Hour,
+				Time_limit:    30 * time.Minute,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().UpdateLimits(ctx, model).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(&models.Form{
+					Id:            model.Id,
+					Status:        models.FormStatusOpen,
+					One_response:  model.One_response,
+					Max_responses: model.Max_responses,
+					Edit_window:   model.Edit_window,
+					Time_limit:    model.Time_limit,
+				}, nil)
+			},
+			expectedModel: models.Form{
+				Id:            "1",
+				Status:        models.FormStatusOpen,
+				One_response:  true,
+				Max_responses: 100,
+				Edit_window:   time.Hour,
+				Time_limit:    30 * time.Minute,
+			},
+		},
+		{
+			nameTest: "negative_limits",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:            "1",
+				Max_responses: -1,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {},
+		},
+		{
+			nameTest: "user_not_an_owner",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id: "1",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.SetLimits(testCase.ctx, &testCase.model)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedModel, *got)
+			case "negative_limits":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "user_not_an_owner":
+				assert.Equal(t, errs.ErrForbidden, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormUseCase_GetPublic(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	latest := &models.FormVersion{
+		Id:      "3",
+		Form_id: "1",
+		Title:   "title",
+	}
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		id            string
+		token         string
+		mockBehavior  mockBehavior
+		expectedModel models.Form
+	}{
+		{
+			nameTest: "ok_link",
+			ctx:      context.Background(),
+			id:       "1",
+			token:    "secret",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.Form{
+					Id:           id,
+					Status:       models.FormStatusOpen,
+					Access:       models.FormAccessLink,
+					Access_token: "secret",
+				}, nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, id).Return(latest, nil)
+			},
+			expectedModel: models.Form{
+				Id:     "1",
+				Status: models.FormStatusOpen,
+				Access: models.FormAccessLink,
+			},
+		},
+		{
+			nameTest: "invalid_token",
+			ctx:      context.Background(),
+			id:       "1",
+			token:    "guess",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.Form{
+					Id:           id,
+					Status:       models.FormStatusOpen,
+					Access:       models.FormAccessLink,
+					Access_token: "secret",
+				}, nil)
+			},
+		},
+		{
+			nameTest: "authenticated_only",
+			ctx:      context.Background(),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.Form{
+					Id:     id,
+					Status: models.FormStatusOpen,
+					Access: models.FormAccessAuthenticated,
+				}, nil)
+			},
+		},
+		{
+			nameTest: "draft",
+			ctx:      context.Background(),
+			id:       "1",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.Form{
+					Id:     id,
+					Status: models.FormStatusDraft,
+					Access: models.FormAccessAnonymous,
+				}, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, gotv, err := uc.GetPublic(testCase.ctx, testCase.id, testCase.token)
+
+			switch testCase.nameTest {
+			case "ok_link":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedModel, *got)
+				assert.Equal(t, latest, gotv)
+			case "invalid_token":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "authenticated_only":
+				assert.Equal(t, errs.ErrUnauthorized, err)
+			case "draft":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormUseCase_SetAccess(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.Form)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		model        models.Form
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok_link",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:     "1",
+				Access: models.FormAccessLink,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().UpdateAccess(ctx, model).DoAndReturn(func(ctx context.Context, model *models.Form) error {
+					assert.Len(t, model.Access_token, 32)
+					return nil
+				})
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(model, nil)
+			},
+		},
+		{
+			nameTest: "ok_anonymous",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:           "1",
+				Access:       models.FormAccessAnonymous,
+				Access_token: "secret",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().UpdateAccess(ctx, &models.Form{
+					Id:     model.Id,
+					Access: models.FormAccessAnonymous,
+				}).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(model, nil)
+			},
+		},
+		{
+			nameTest: "unknown_access",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:     "1",
+				Access: "friends",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {},
+		},
+		{
+			nameTest: "user_not_an_owner",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:     "1",
+				Access: models.FormAccessAnonymous,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.SetAccess(testCase.ctx, &testCase.model)
+
+			switch testCase.nameTest {
+			case "ok_link", "ok_anonymous":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.model.Access, got.Access)
+			case "unknown_access":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "user_not_an_owner":
+				assert.Equal(t, errs.ErrForbidden, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormUseCase_SetQuiz(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.Form)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		model        models.Form
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:           "1",
+				Is_quiz:      true,
+				Show_correct: models.FormShowCorrectAfterClose,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().UpdateQuiz(ctx, model).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(model, nil)
+			},
+		},
+		{
+			nameTest: "unknown_show_correct",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:           "1",
+				Is_quiz:      true,
+				Show_correct: "sometimes",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {},
+		},
+		{
+			nameTest: "user_not_an_owner",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:           "1",
+				Show_correct: models.FormShowCorrectNever,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.SetQuiz(testCase.ctx, &testCase.model)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, &testCase.model, got)
+			case "unknown_show_correct":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "user_not_an_owner":
+				assert.Equal(t, errs.ErrForbidden, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestFormUseCase_SetDraws(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.Form)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		model        models.Form
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:    "1",
+				Draws: []*models.Draw{{Tag: "go", Count: 2}, {Count: 1}},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().UpdateDraws(ctx, model).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(model, nil)
+			},
+		},
+		{
+			nameTest: "ok_no_draws",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id: "1",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().UpdateDraws(ctx, model).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Id).Return(model, nil)
+			},
+		},
+		{
+			nameTest: "zero_count",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:    "1",
+				Draws: []*models.Draw{{Tag: "go"}},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {},
+		},
+		{
+			nameTest: "repeated_tag",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:    "1",
+				Draws: []*models.Draw{{Tag: "go", Count: 1}, {Tag: "go", Count: 2}},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {},
+		},
+		{
+			nameTest: "user_not_an_owner",
+			ctx:      context.Background(),
+			model: models.Form{
+				Id:    "1",
+				Draws: []*models.Draw{{Tag: "go", Count: 2}},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Form) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.SetDraws(testCase.ctx, &testCase.model)
+
+			switch testCase.nameTest {
+			case "ok", "ok_no_draws":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, &testCase.model, got)
+			case "zero_count", "repeated_tag":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "user_not_an_owner":
+				assert.Equal(t, errs.ErrForbidden, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+// runs transaction body in place
+func expectTx(ctx context.Context, mockTx *mocktx.MockTransactor) {
+	mockTx.EXPECT().WithinTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+}
+
+func TestFormUseCase_Export(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	foundform := &models.Form{
+		Id:          "5",
+		User_id:     "1",
+		Title:       "title",
+		Description: "desc",
+		Status:      models.FormStatusOpen,
+		Access:      models.FormAccessAuthenticated,
+	}
+
+	sections := []*models.Section{
+		{Id: "3", Form_id: "5", Title: "about", Position: 1},
+	}
+
+	questions := []*models.Question{
+		{Id: "7", Form_id: "5", Section_id: "3", Header: "name", Type: models.QuestionTypeText},
+	}
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		id               string
+		mockBehavior     mockBehavior
+		expectedDocument *models.FormDocument
+		expectedErr      error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "5",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormView).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, id).Return(foundform, nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, id).Return(sections, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, id).Return(questions, nil)
+			},
+			expectedDocument: &models.FormDocument{
+				Schema_version: models.FormDocumentSchemaVersion,
+				Form:           foundform,
+				Sections:       sections,
+				Questions:      questions,
+			},
+		},
+		{
+			nameTest: "user_is_not_an_owner",
+			ctx:      context.Background(),
+			id:       "5",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "sections_error",
+			ctx:      context.Background(),
+			id:       "5",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormView).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, id).Return(foundform, nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, id).Return(nil, errs.ErrInvalidContent)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "questions_error",
+			ctx:      context.Background(),
+			id:       "5",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormView).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, id).Return(foundform, nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, id).Return(sections, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, id).Return(nil, errs.ErrInvalidContent)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := uc.Export(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedDocument, got)
+		})
+	}
+}
+
+func TestFormUseCase_Import(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, document *models.FormDocument)
+
+	newDocument := func(schema_version int, questions ...*models.Question) *models.FormDocument {
+		return &models.FormDocument{
+			Schema_version: schema_version,
+			Form: &models.Form{
+				User_id:       "3",
+				Title:         "title",
+				Description:   "desc",
+				Max_responses: 10,
+			},
+			Questions: questions,
+		}
+	}
+
+	withSections := func(document *models.FormDocument, sections ...*models.Section) *models.FormDocument {
+		document.Sections = sections
+		return document
+	}
+
+	color := func() *models.Question {
+		return &models.Question{Id: "q1", Header: "color", Type: models.QuestionTypeSingleChoice, Options: models.QuestionOptions{Choices: []string{"red", "blue"}}}
+	}
+
+	createdform := &models.Form{
+		Id:            "9",
+		User_id:       "3",
+		Title:         "title",
+		Description:   "desc",
+		Status:        models.FormStatusDraft,
+		Access:        models.FormAccessAuthenticated,
+		Max_responses: 10,
+	}
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		document      *models.FormDocument
+		mockBehavior  mockBehavior
+		expectedModel *models.Form
+		expectedErr   error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			document: newDocument(1,
+				&models.Question{Header: "name"},
+				&models.Question{Header: "color", Type: models.QuestionTypeSingleChoice, Options: models.QuestionOptions{Choices: []string{"red"}}},
+			),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {
+				expectTx(ctx, mockTx)
+				mockRepo.EXPECT().Create(ctx, &models.Form{
+					User_id:       "3",
+					Title:         "title",
+					Description:   "desc",
+					Status:        models.FormStatusDraft,
+					Access:        models.FormAccessAuthenticated,
+					Max_responses: 10,
+					Show_correct:  models.FormShowCorrectNever,
+				}).Return(createdform, nil)
+				mockRepoQ.EXPECT().Create(ctx, &models.Question{
+					Form_id: "9",
+					Header:  "name",
+					Type:    models.QuestionTypeText,
+				}).Return(&models.Question{Id: "20"}, nil)
+				mockRepoQ.EXPECT().Create(ctx, &models.Question{
+					Form_id: "9",
+					Header:  "color",
+					Type:    models.QuestionTypeSingleChoice,
+					Options: models.QuestionOptions{Choices: []string{"red"}},
+				}).Return(&models.Question{Id: "21"}, nil)
+			},
+			expectedModel: createdform,
+		},
+		{
+			nameTest: "ok_sections_and_conditions",
+			ctx:      context.Background(),
+			document: withSections(newDocument(2,
+				&models.Question{Id: "q2", Section_id: "s1", Header: "why", Condition: &models.Condition{Question_id: "q1", Op: models.ConditionOpEquals, Value: "red"}},
+				&models.Question{Id: "q1", Section_id: "s1", Header: "color", Type: models.QuestionTypeSingleChoice, Options: models.QuestionOptions{Choices: []string{"red", "blue"}}},
+				&models.Question{Header: "comment"},
+			),
+				&models.Section{Id: "s1", Title: "colors"},
+				&models.Section{Id: "s2", Title: "end", Condition: &models.Condition{Question_id: "q2", Op: models.ConditionOpAnswered}},
+			),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {
+				expectTx(ctx, mockTx)
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(createdform, nil)
+				mockRepoS.EXPECT().Create(ctx, &models.Section{Form_id: "9", Title: "colors"}).Return(&models.Section{Id: "30", Form_id: "9", Title: "colors", Position: 1}, nil)
+				mockRepoS.EXPECT().Create(ctx, &models.Section{Form_id: "9", Title: "end"}).Return(&models.Section{Id: "31", Form_id: "9", Title: "end", Position: 2}, nil)
+				mockRepoQ.EXPECT().Create(ctx, &models.Question{Form_id: "9", Section_id: "30", Header: "why", Type: models.QuestionTypeText}).
+					Return(&models.Question{Id: "20", Form_id: "9", Section_id: "30", Header: "why", Type: models.QuestionTypeText, Position: 1}, nil)
+				mockRepoQ.EXPECT().Create(ctx, &models.Question{Form_id: "9", Section_id: "30", Header: "color", Type: models.QuestionTypeSingleChoice, Options: models.QuestionOptions{Choices: []string{"red", "blue"}}}).
+					Return(&models.Question{Id: "21", Form_id: "9", Section_id: "30", Header: "color", Type: models.QuestionTypeSingleChoice, Options: models.QuestionOptions{Choices: []string{"red", "blue"}}, Position: 2}, nil)
+				mockRepoQ.EXPECT().Create(ctx, &models.Question{Form_id: "9", Header: "comment", Type: models.QuestionTypeText}).
+					Return(&models.Question{Id: "22", Form_id: "9", Header: "comment", Type: models.QuestionTypeText, Position: 3}, nil)
+				// conditions refer to created questions
+				mockRepoQ.EXPECT().Update(ctx, &models.Question{
+					Id: "20", Form_id: "9", Section_id: "30", Header: "why", Type: models.QuestionTypeText, Position: 1,
+					Condition: &models.Condition{Question_id: "21", Op: models.ConditionOpEquals, Value: "red"},
+				}).Return(&models.Question{}, nil)
+				mockRepoS.EXPECT().Update(ctx, &models.Section{
+					Id: "31", Form_id: "9", Title: "end", Position: 2,
+					Condition: &models.Condition{Question_id: "20", Op: models.ConditionOpAnswered},
+				}).Return(&models.Section{}, nil)
+			},
+			expectedModel: createdform,
+		},
+		{
+			nameTest:     "unsupported_schema_version",
+			ctx:          context.Background(),
+			document:     newDocument(models.FormDocumentSchemaVersion + 1),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "invalid_question",
+			ctx:      context.Background(),
+			document: newDocument(1,
+				&models.Question{Header: "color", Type: models.QuestionTypeSingleChoice},
+			),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "points_without_correct_answer",
+			ctx:      context.Background(),
+			document: newDocument(1,
+				&models.Question{Header: "name", Points: 2},
+			),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "no_form",
+			ctx:          context.Background(),
+			document:     &models.FormDocument{Schema_version: 2},
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "invalid_draws",
+			ctx:      context.Background(),
+			document: func() *models.FormDocument {
+				document := newDocument(2)
+				document.Form.Draws = []*models.Draw{{Tag: "go", Count: 0}}
+				return document
+			}(),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "section_without_title",
+			ctx:          context.Background(),
+			document:     withSections(newDocument(2), &models.Section{Id: "s1"}),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "duplicate_section_id",
+			ctx:          context.Background(),
+			document:     withSections(newDocument(2), &models.Section{Id: "s1", Title: "a"}, &models.Section{Id: "s1", Title: "b"}),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "duplicate_question_id",
+			ctx:          context.Background(),
+			document:     newDocument(2, color(), color()),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "question_of_unknown_section",
+			ctx:          context.Background(),
+			document:     newDocument(2, &models.Question{Header: "name", Section_id: "s1"}),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "condition_on_unknown_question",
+			ctx:      context.Background(),
+			document: newDocument(2,
+				&models.Question{Header: "name", Condition: &models.Condition{Question_id: "q1", Op: models.ConditionOpAnswered}},
+			),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "condition_on_itself",
+			ctx:      context.Background(),
+			document: newDocument(2,
+				&models.Question{Id: "q1", Header: "name", Condition: &models.Condition{Question_id: "q1", Op: models.ConditionOpAnswered}},
+			),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "condition_with_impossible_value",
+			ctx:      context.Background(),
+			document: newDocument(2, color(),
+				&models.Question{Header: "why", Condition: &models.Condition{Question_id: "q1", Op: models.ConditionOpEquals, Value: "green"}},
+			),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "section_condition_on_unknown_question",
+			ctx:      context.Background(),
+			document: withSections(newDocument(2, color()),
+				&models.Section{Id: "s1", Title: "end", Condition: &models.Condition{Question_id: "q2", Op: models.ConditionOpAnswered}},
+			),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "question_create_error",
+			ctx:      context.Background(),
+			document: newDocument(1, &models.Question{Header: "name"}),
+			mockBehavior: func(ctx context.Context, document *models.FormDocument) {
+				expectTx(ctx, mockTx)
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(createdform, nil)
+				mockRepoQ.EXPECT().Create(ctx, gomock.Any()).Return(nil, errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.document)
+
+			got, err := uc.Import(testCase.ctx, testCase.document)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedModel, got)
+		})
+	}
+}
+
+func TestFormUseCase_Clone(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	newForm := func(is_template bool) *models.Form {
+		return &models.Form{
+			Id:            "5",
+			User_id:       "1",
+			Title:         "retro",
+			Description:   "sprint retro",
+			Status:        models.FormStatusOpen,
+			Opens_at:      &_opensAt,
+			Closes_at:     &_closesAt,
+			Max_responses: 20,
+			Time_limit:    10 * time.Minute,
+			Access:        models.FormAccessAuthenticated,
+			Is_template:   is_template,
+			Is_quiz:       true,
+			Show_correct:  models.FormShowCorrectAfterSubmission,
+			Draws:         []*models.Draw{{Tag: "go", Count: 2}},
+		}
+	}
+
+	sections := []*models.Section{
+		{Id: "3", Form_id: "5", Title: "good", Position: 1},
+	}
+
+	questions := []*models.Question{
+		{Id: "7", Form_id: "5", Section_id: "3", Header: "what went well", Type: models.QuestionTypeText, Correct: "teamwork", Points: 1},
+		{Id: "8", Form_id: "5", Header: "why", Type: models.QuestionTypeText, Condition: &models.Condition{Question_id: "7", Op: models.ConditionOpAnswered}},
+	}
+
+	clone := &models.Form{
+		User_id:       "2",
+		Title:         "retro",
+		Description:   "sprint retro",
+		Status:        models.FormStatusDraft,
+		Max_responses: 20,
+		Time_limit:    10 * time.Minute,
+		Access:        models.FormAccessAuthenticated,
+		Is_quiz:       true,
+		Show_correct:  models.FormShowCorrectAfterSubmission,
+		Draws:         []*models.Draw{{Tag: "go", Count: 2}},
+	}
+
+	createdform := &models.Form{
+		Id:            "9",
+		User_id:       "2",
+		Title:         "retro",
+		Description:   "sprint retro",
+		Status:        models.FormStatusDraft,
+		Max_responses: 20,
+		Access:        models.FormAccessAuthenticated,
+	}
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		id            string
+		mockBehavior  mockBehavior
+		expectedModel *models.Form
+		expectedErr   error
+	}{
+		{
+			nameTest: "ok_template",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "2"}),
+			id:       "5",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(newForm(true), nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, id).Return(sections, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, id).Return(questions, nil)
+				expectTx(ctx, mockTx)
+				mockRepo.EXPECT().Create(ctx, clone).Return(createdform, nil)
+				mockRepoS.EXPECT().Create(ctx, &models.Section{Form_id: "9", Title: "good"}).Return(&models.Section{Id: "30", Form_id: "9", Title: "good"}, nil)
+				mockRepoQ.EXPECT().Create(ctx, &models.Question{
+					Form_id:    "9",
+					Section_id: "30",
+					Header:     "what went well",
+					Type:       models.QuestionTypeText,
+					Correct:    "teamwork",
+					Points:     1,
+				}).Return(&models.Question{Id: "20", Form_id: "9", Section_id: "30"}, nil)
+				mockRepoQ.EXPECT().Create(ctx, &models.Question{
+					Form_id: "9",
+					Header:  "why",
+					Type:    models.QuestionTypeText,
+				}).Return(&models.Question{Id: "21", Form_id: "9", Header: "why", Type: models.QuestionTypeText}, nil)
+				mockRepoQ.EXPECT().Update(ctx, &models.Question{
+					Id: "21", Form_id: "9", Header: "why", Type: models.QuestionTypeText,
+					Condition: &models.Condition{Question_id: "20", Op: models.ConditionOpAnswered},
+				}).Return(&models.Question{}, nil)
+			},
+			expectedModel: createdform,
+		},
+		{
+			// collaborators, moderators and admins clone forms they see
+			nameTest: "ok_form_of_member",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "2"}),
+			id:       "5",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(newForm(false), nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormView).Return(nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, id).Return([]*models.Section{}, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, id).Return([]*models.Question{}, nil)
+				expectTx(ctx, mockTx)
+				mockRepo.EXPECT().Create(ctx, clone).Return(createdform, nil)
+			},
+			expectedModel: createdform,
+		},
+		{
+			nameTest: "not_template_of_other_user",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "2"}),
+			id:       "5",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(newForm(false), nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest:     "unauthorized",
+			ctx:          context.Background(),
+			id:           "5",
+			mockBehavior: func(ctx context.Context, id string) {},
+			expectedErr:  errs.ErrUnauthorized,
+		},
+		{
+			nameTest: "no_form",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "2"}),
+			id:       "5",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepo.EXPECT().GetById(ctx, id).Return(nil, errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := uc.Clone(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedModel, got)
+		})
+	}
+}
+
+func TestFormUseCase_SetTemplate(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, id string, is_template bool)
+
+	updatedform := &models.Form{
+		Id:          "5",
+		User_id:     "1",
+		Title:       "retro",
+		Status:      models.FormStatusDraft,
+		Access:      models.FormAccessAuthenticated,
+		Is_template: true,
+	}
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		id            string
+		is_template   bool
+		mockBehavior  mockBehavior
+		expectedModel *models.Form
+		expectedErr   error
+	}{
+		{
+			nameTest:    "ok",
+			ctx:         context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "1"}),
+			id:          "5",
+			is_template: true,
+			mockBehavior: func(ctx context.Context, id string, is_template bool) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().UpdateTemplate(ctx, id, is_template).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, id).Return(updatedform, nil)
+			},
+			expectedModel: updatedform,
+		},
+		{
+			nameTest:    "user_is_not_an_owner",
+			ctx:         context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "2"}),
+			id:          "5",
+			is_template: true,
+			mockBehavior: func(ctx context.Context, id string, is_template bool) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "update_error",
+			ctx:      context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "1"}),
+			id:       "5",
+			mockBehavior: func(ctx context.Context, id string, is_template bool) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, id, models.ActionFormEdit).Return(nil)
+				mockRepo.EXPECT().UpdateTemplate(ctx, id, is_template).Return(errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id, testCase.is_template)
+
+			got, err := uc.SetTemplate(testCase.ctx, testCase.id, testCase.is_template)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedModel, got)
+		})
+	}
+}
+
+func TestFormUseCase_ExportImport(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewFormUseCase(mockRepo, mockRepoV, mockRepoQ, mockRepoS, mockAuthz, mockTx, ctxUserKey)
+
+	ctx := context.Background()
+
+	draws := []*models.Draw{{Tag: "go", Count: 2}}
+
+	sections := []*models.Section{
+		{Id: "3", Form_id: "5", Title: "first", Position: 1},
+		{Id: "4", Form_id: "5", Title: "second", Position: 2, Condition: &models.Condition{Question_id: "7", Op: models.ConditionOpEquals, Value: "yes"}},
+	}
+
+	questions := []*models.Question{
+		{Id: "7", Form_id: "5", Section_id: "3", Header: "continue", Type: models.QuestionTypeSingleChoice, Options: models.QuestionOptions{Choices: []string{"yes", "no"}}, Position: 1},
+		{Id: "8", Form_id: "5", Section_id: "4", Header: "why", Type: models.QuestionTypeText, Position: 2,
+			Condition: &models.Condition{Question_id: "7", Op: models.ConditionOpAnswered}},
+	}
+
+	mockAuthz.EXPECT().AuthorizeForm(ctx, "5", models.ActionFormView).Return(nil)
+	mockRepo.EXPECT().GetById(ctx, "5").Return(&models.Form{Id: "5", User_id: "1", Title: "poll", Draws: draws}, nil)
+	mockRepoS.EXPECT().GetByFormId(ctx, "5").Return(sections, nil)
+	mockRepoQ.EXPECT().GetAllByFormId(ctx, "5").Return(questions, nil)
+
+	document, err := uc.Export(ctx, "5")
+	assert.Equal(t, nil, err)
+
+	// only portable part of form goes through document
+	document.Form = &models.Form{User_id: "2", Title: document.Form.Title, Draws: document.Form.Draws}
+
+	var (
+		createdsections  []*models.Section
+		createdquestions []*models.Question
+	)
+
+	expectTx(ctx, mockTx)
+	mockRepo.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, model *models.Form) (*models.Form, error) {
+		assert.Equal(t, draws, model.Draws)
+		model.Id = "9"
+		return model, nil
+	})
+	mockRepoS.EXPECT().Create(ctx, gomock.Any()).Times(2).DoAndReturn(func(ctx context.Context, model *models.Section) (*models.Section, error) {
+		model.Id = strconv.Itoa(30 + len(createdsections))
+		createdsections = append(createdsections, model)
+		return model, nil
+	})
+	mockRepoQ.EXPECT().Create(ctx, gomock.Any()).Times(2).DoAndReturn(func(ctx context.Context, model *models.Question) (*models.Question, error) {
+		model.Id = strconv.Itoa(20 + len(createdquestions))
+		createdquestions = append(createdquestions, model)
+		return model, nil
+	})
+	mockRepoQ.EXPECT().Update(ctx, gomock.Any()).Return(&models.Question{}, nil)
+	mockRepoS.EXPECT().Update(ctx, gomock.Any()).Return(&models.Section{}, nil)
+
+	createdform, err := uc.Import(ctx, document)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "9", createdform.Id)
+
+	assert.Equal(t, []*models.Section{
+		{Id: "30", Form_id: "9", Title: "first"},
+		{Id: "31", Form_id: "9", Title: "second", Condition: &models.Condition{Question_id: "20", Op: models.ConditionOpEquals, Value: "yes"}},
+	}, createdsections)
+	assert.Equal(t, []*models.Question{
+		{Id: "20", Form_id: "9", Section_id: "30", Header: "continue", Type: models.QuestionTypeSingleChoice, Options: models.QuestionOptions{Choices: []string{"yes", "no"}}},
+		{Id: "21", Form_id: "9", Section_id: "31", Header: "why", Type: models.QuestionTypeText,
+			Condition: &models.Condition{Question_id: "20", Op: models.ConditionOpAnswered}},
+	}, createdquestions)
+}
@@ -0,0 +1,10 @@
+package member
+
+import "github.com/gin-gonic/gin"
+
+// Member HTTP Handlers interface
+type Handlers interface {
+	Invite() gin.HandlerFunc
+	GetByFormId() gin.HandlerFunc
+	Remove() gin.HandlerFunc
+}
@@ -0,0 +1,167 @@
+package http
+
+import (
+	"net/http"
+	"quizapp/internal/member"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/types"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type memberInviteRequest struct {
+	User_id string `json:"user_id" binding:"required"`
+	Role    string `json:"role" binding:"required" enums:"viewer,editor,owner"`
+}
+
+type memberResponse struct {
+	Form_id    string    `json:"form_id"`
+	User_id    string    `json:"user_id"`
+	Role       string    `json:"role"`
+	Created_at time.Time `json:"created_at"`
+}
+
+type membersResponse struct {
+	Members []*memberResponse `json:"members"`
+}
+
+type memberHandlers struct {
+	memberUC   member.UseCase
+	ctxUserKey string
+}
+
+func NewMemberHandlers(memberUC member.UseCase, ctxUserKey string) member.Handlers {
+	return &memberHandlers{
+		memberUC:   memberUC,
+		ctxUserKey: ctxUserKey,
+	}
+}
+
+// Invite godoc
+// @Summary Invite form member
+// @Description Share form with user as viewer, editor or owner, role of member is changed, if user is member already. Viewers read form and results, editors edit form and questions too, owners share all rights of form creator
+// @Tags Members
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param data body memberInviteRequest true "user id and role"
+// @Success 200 {object} memberResponse
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid json, unknown role, no such user or user created form"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner nor member with owner role"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/members [post]
+func (h *memberHandlers) Invite() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(memberInviteRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		savedmember, err := h.memberUC.Invite(c, &models.FormMember{
+			Form_id: c.Param("formid"),
+			User_id: request.User_id,
+			Role:    request.Role,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, memberBLToResponse(savedmember))
+	}
+}
+
+// GetByFormId godoc
+// @Summary Get form members
+// @Description Get members of form with their roles, form creator is not listed
+// @Tags Members
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param limit query int true "limit" minimum(1)
+// @Param offset query int true "offset" minimum(0)
+// @Success 200 {object} membersResponse "Found"
+// @Failure 204 {object} membersResponse "No members"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User may not view form"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/members [get]
+func (h *memberHandlers) GetByFormId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, offset, ok := types.ValidateGetSets(c.Query("limit"), c.Query("offset"))
+		if !ok {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		foundmembers, err := h.memberUC.GetByFormId(c, c.Param("formid"), types.GetSets{
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		status := http.StatusOK
+
+		if len(foundmembers) == 0 {
+			status = http.StatusNoContent
+		}
+
+		c.JSON(status, &membersResponse{
+			Members: membersBLToResponse(foundmembers),
+		})
+	}
+}
+
+// Remove godoc
+// @Summary Remove form member
+// @Description Remove member from form, any member may leave form
+// @Tags Members
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param userid path string true "user id"
+// @Success 200   "Removed"
+// @Failure 204   "No such form or user is not a member"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner nor member with owner role"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/members/{userid} [delete]
+func (h *memberHandlers) Remove() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.memberUC.Remove(c, c.Param("formid"), c.Param("userid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+func memberBLToResponse(modelBL *models.FormMember) *memberResponse {
+	return &memberResponse{
+		Form_id:    modelBL.Form_id,
+		User_id:    modelBL.User_id,
+		Role:       modelBL.Role,
+		Created_at: modelBL.Created_at,
+	}
+}
+
+func membersBLToResponse(modelsBL []*models.FormMember) []*memberResponse {
+	res := make([]*memberResponse, len(modelsBL))
+
+	for i, m := range modelsBL {
+		res[i] = memberBLToResponse(m)
+	}
+
+	return res
+}
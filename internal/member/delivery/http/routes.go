@@ -0,0 +1,14 @@
+package http
+
+import (
+	"quizapp/internal/member"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Map form member routes
+func MapMemberRoutes(memberGroup *gin.RouterGroup, h member.Handlers) {
+	memberGroup.POST("", h.Invite())
+	memberGroup.GET("", h.GetByFormId())
+	memberGroup.DELETE("/:userid", h.Remove())
+}
@@ -0,0 +1,35 @@
+package member
+
+import (
+	"context"
+	"quizapp/models"
+	"quizapp/pkg/types"
+)
+
+type Repo interface {
+	// Adds member to form or changes role of existing member.
+	// Returns saved model & nil, if saved.
+	// Returns nil & ErrInvalidContent, if invalid inputs or no such user or form.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Upsert(ctx context.Context, member *models.FormMember) (*models.FormMember, error)
+
+	// Returns slice of form members & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.FormMember, error)
+
+	// Returns found model & nil, if user is member of form.
+	// Returns nil & ErrContentNotFound, if user is not member of form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetById(ctx context.Context, form_id, user_id string) (*models.FormMember, error)
+
+	// Returns nil, if deleted.
+	// Returns ErrContentNotFound, if user is not member of form.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	Delete(ctx context.Context, form_id, user_id string) error
+}
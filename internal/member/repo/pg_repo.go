@@ -0,0 +1,191 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/member"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"quizapp/pkg/types"
+	"strconv"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type MemberDB struct {
+	FormId, UserId int
+	Role           string
+	CreatedAt      time.Time
+}
+
+type memberRepo struct {
+	*postgres.Postgres
+}
+
+func NewMemberRepo(db *postgres.Postgres) member.Repo {
+	return &memberRepo{db}
+}
+
+func (m *memberRepo) Upsert(ctx context.Context, modelBL *models.FormMember) (*models.FormMember, error) {
+	modelDB, err := memberBLToDB(modelBL)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	// invited member keeps date of joining, if role is changed
+	sql, args, err := m.Builder.
+		Insert("form_member_").
+		Columns("form_id_, user_id_, role_").
+		Values(modelDB.FormId, modelDB.UserId, modelDB.Role).
+		Suffix("ON CONFLICT (form_id_, user_id_) DO UPDATE SET role_ = EXCLUDED.role_ RETURNING \"created_at_\"").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case postgres.PermDenied:
+				return nil, errs.ErrForbidden
+			case postgres.ForeignKeyViolation:
+				return nil, errs.ErrInvalidContent
+			}
+		}
+
+		return nil, err
+	}
+
+	return memberDBToBL(modelDB), nil
+}
+
+func (m *memberRepo) GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.FormMember, error) {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := m.Builder.
+		Select("user_id_, role_, created_at_").
+		From("form_member_").
+		Where(squirrel.Eq{"form_id_": intformid}).
+		OrderBy("created_at_, user_id_").
+		Limit(sets.Limit).
+		Offset(sets.Offset).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := m.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.FormMember, 0)
+
+	for rows.Next() {
+		modelDB := MemberDB{FormId: intformid}
+
+		err = rows.Scan(&modelDB.UserId, &modelDB.Role, &modelDB.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, memberDBToBL(&modelDB))
+	}
+
+	return res, nil
+}
+
+func (m *memberRepo) GetById(ctx context.Context, form_id, user_id string) (*models.FormMember, error) {
+	modelDB, err := memberBLToDB(&models.FormMember{Form_id: form_id, User_id: user_id})
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := m.Builder.
+		Select("role_, created_at_").
+		From("form_member_").
+		Where(squirrel.Eq{"form_id_": modelDB.FormId, "user_id_": modelDB.UserId}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = m.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.Role, &modelDB.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return memberDBToBL(modelDB), nil
+}
+
+func (m *memberRepo) Delete(ctx context.Context, form_id, user_id string) error {
+	modelDB, err := memberBLToDB(&models.FormMember{Form_id: form_id, User_id: user_id})
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := m.Builder.
+		Delete("form_member_").
+		Where(squirrel.Eq{"form_id_": modelDB.FormId, "user_id_": modelDB.UserId}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := m.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func memberDBToBL(modelDB *MemberDB) *models.FormMember {
+	return &models.FormMember{
+		Form_id:    strconv.Itoa(modelDB.FormId),
+		User_id:    strconv.Itoa(modelDB.UserId),
+		Role:       modelDB.Role,
+		Created_at: modelDB.CreatedAt,
+	}
+}
+
+func memberBLToDB(modelBL *models.FormMember) (*MemberDB, error) {
+	form_id, err := strconv.Atoi(modelBL.Form_id)
+	if err != nil {
+		return nil, err
+	}
+
+	user_id, err := strconv.Atoi(modelBL.User_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &MemberDB{
+		FormId: form_id,
+		UserId: user_id,
+		Role:   modelBL.Role,
+	}, nil
+}
@@ -0,0 +1,332 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/member/repo"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"quizapp/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+)
+
+func TestMemberRepo_Upsert(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewMemberRepo(&db)
+
+	type mockBehavior func(ctx context.Context, member *models.FormMember)
+
+	created := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		nameTest       string
+		ctx            context.Context
+		member         models.FormMember
+		mockBehavior   mockBehavior
+		expectedMember models.FormMember
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			member:   models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleEditor},
+			mockBehavior: func(ctx context.Context, member *models.FormMember) {
+				pgxRows := pgxpoolmock.NewRows([]string{"created_at_"}).AddRow(created).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO form_member_ (form_id_, user_id_, role_) VALUES ($1,$2,$3) ON CONFLICT (form_id_, user_id_) DO UPDATE SET role_ = EXCLUDED.role_ RETURNING \"created_at_\"", 3, 5, member.Role).Return(pgxRows)
+			},
+			expectedMember: models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleEditor, Created_at: created},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			member:       models.FormMember{Form_id: "3", User_id: "5r4", Role: models.MemberRoleEditor},
+			mockBehavior: func(ctx context.Context, member *models.FormMember) {},
+		},
+		{
+			nameTest: "no_user",
+			ctx:      context.Background(),
+			member:   models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleViewer},
+			mockBehavior: func(ctx context.Context, member *models.FormMember) {
+				pgxRows := pgxpoolmock.NewRows([]string{"created_at_"}).AddRow(nil).RowError(0, &pgconn.PgError{Code: postgres.ForeignKeyViolation}).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO form_member_ (form_id_, user_id_, role_) VALUES ($1,$2,$3) ON CONFLICT (form_id_, user_id_) DO UPDATE SET role_ = EXCLUDED.role_ RETURNING \"created_at_\"", 3, 5, member.Role).Return(pgxRows)
+			},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			member:   models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleViewer},
+			mockBehavior: func(ctx context.Context, member *models.FormMember) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO form_member_ (form_id_, user_id_, role_) VALUES ($1,$2,$3) ON CONFLICT (form_id_, user_id_) DO UPDATE SET role_ = EXCLUDED.role_ RETURNING \"created_at_\"", 3, 5, member.Role).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.member)
+
+			got, err := r.Upsert(testCase.ctx, &testCase.member)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedMember, *got)
+			case "invalid_inputs", "no_user":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestMemberRepo_GetByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewMemberRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string, sets types.GetSets)
+
+	created := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		form_id         string
+		sets            types.GetSets
+		mockBehavior    mockBehavior
+		expectedMembers []*models.FormMember
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "3",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"user_id_", "role_", "created_at_"}).
+					AddRow(5, "viewer", created).
+					AddRow(6, "owner", created).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, "SELECT user_id_, role_, created_at_ FROM form_member_ WHERE form_id_ = $1 ORDER BY created_at_, user_id_ LIMIT 10 OFFSET 0", 3).Return(pgxRows, nil)
+			},
+			expectedMembers: []*models.FormMember{
+				{Form_id: "3", User_id: "5", Role: models.MemberRoleViewer, Created_at: created},
+				{Form_id: "3", User_id: "6", Role: models.MemberRoleOwner, Created_at: created},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			form_id:  "3",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				mockPool.EXPECT().Query(ctx, "SELECT user_id_, role_, created_at_ FROM form_member_ WHERE form_id_ = $1 ORDER BY created_at_, user_id_ LIMIT 10 OFFSET 0", 3).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.sets)
+
+			got, err := r.GetByFormId(testCase.ctx, testCase.form_id, testCase.sets)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedMembers, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestMemberRepo_GetById(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewMemberRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id, user_id string)
+
+	created := time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		form_id, user_id string
+		mockBehavior     mockBehavior
+		expectedMember   models.FormMember
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "3",
+			user_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"role_", "created_at_"}).AddRow("editor", created).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT role_, created_at_ FROM form_member_ WHERE form_id_ = $1 AND user_id_ = $2", 3, 5).Return(pgxRows)
+			},
+			expectedMember: models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleEditor, Created_at: created},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {},
+		},
+		{
+			nameTest: "not_member",
+			ctx:      context.Background(),
+			form_id:  "3",
+			user_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"role_", "created_at_"}).AddRow(nil, nil).RowError(0, pgx.ErrNoRows).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT role_, created_at_ FROM form_member_ WHERE form_id_ = $1 AND user_id_ = $2", 3, 5).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			got, err := r.GetById(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedMember, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "not_member":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestMemberRepo_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewMemberRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id, user_id string)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		form_id, user_id string
+		mockBehavior     mockBehavior
+		expectedErr      error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "3",
+			user_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM form_member_ WHERE form_id_ = $1 AND user_id_ = $2", 3, 5).Return(pgxmock.NewResult("DELETE", 1), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "3",
+			user_id:      "5r4",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "not_member",
+			ctx:      context.Background(),
+			form_id:  "3",
+			user_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM form_member_ WHERE form_id_ = $1 AND user_id_ = $2", 3, 5).Return(pgxmock.NewResult("DELETE", 0), nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			err := r.Delete(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -0,0 +1,36 @@
+package member
+
+import (
+	"context"
+	"quizapp/models"
+	"quizapp/pkg/types"
+)
+
+type UseCase interface {
+	// Adds user to form with role or changes role of member.
+	// Returns saved model & nil, if saved.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs, unknown role, no such user or user created form.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither form owner nor member with owner role or permission denied.
+	// Returns nil & other err else.
+	Invite(ctx context.Context, member *models.FormMember) (*models.FormMember, error)
+
+	// Returns slice of form members & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user may not view form.
+	// Returns nil & other err else.
+	GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.FormMember, error)
+
+	// Removes member from form, any member may leave form.
+	// Returns nil, if removed.
+	// Returns ErrContentNotFound, if no such form or user is not member of form.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if user is neither form owner nor member with owner role or permission denied.
+	// Returns other errors else.
+	Remove(ctx context.Context, form_id, user_id string) error
+}
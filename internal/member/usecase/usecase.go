@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"context"
+	"quizapp/internal/authz"
+	"quizapp/internal/form"
+	"quizapp/internal/member"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/types"
+)
+
+type memberUseCase struct {
+	memberRepo member.Repo
+	formRepo   form.Repo
+	authorizer authz.Authorizer
+	ctxUserKey string
+}
+
+func NewMemberUseCase(memberRepo member.Repo, formRepo form.Repo, authorizer authz.Authorizer, ctxUserKey string) member.UseCase {
+	return &memberUseCase{
+		memberRepo: memberRepo,
+		formRepo:   formRepo,
+		authorizer: authorizer,
+		ctxUserKey: ctxUserKey,
+	}
+}
+
+func (m *memberUseCase) Invite(ctx context.Context, model *models.FormMember) (*models.FormMember, error) {
+	if !models.ValidateMemberRole(model.Role) {
+		return nil, errs.ErrInvalidContent
+	}
+
+	err := m.authorizer.AuthorizeForm(ctx, model.Form_id, models.ActionMemberManage)
+	if err != nil {
+		return nil, err
+	}
+
+	owner_id, err := m.formRepo.GetOwnerId(ctx, model.Form_id)
+	if err != nil {
+		return nil, err
+	}
+
+	// creator of form owns it without membership, so can not be demoted
+	if model.User_id == owner_id {
+		return nil, errs.ErrInvalidContent
+	}
+
+	return m.memberRepo.Upsert(ctx, model)
+}
+
+func (m *memberUseCase) GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.FormMember, error) {
+	err := m.authorizer.AuthorizeForm(ctx, form_id, models.ActionFormView)
+	if err != nil {
+		return nil, err
+	}
+
+	return m.memberRepo.GetByFormId(ctx, form_id, sets)
+}
+
+func (m *memberUseCase) Remove(ctx context.Context, form_id, user_id string) error {
+	currentuser, ok := ctx.Value(m.ctxUserKey).(*models.User)
+	if !ok {
+		return errs.ErrUnauthorized
+	}
+
+	// member leaves form on own will
+	if currentuser.Id != user_id {
+		err := m.authorizer.AuthorizeForm(ctx, form_id, models.ActionMemberManage)
+		if err != nil {
+			return err
+		}
+	}
+
+	return m.memberRepo.Delete(ctx, form_id, user_id)
+}
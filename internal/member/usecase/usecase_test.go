@@ -0,0 +1,249 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	mockauthz "quizapp/internal/authz/mock"
+	mockf "quizapp/internal/form/mock"
+	"quizapp/internal/member/mock"
+	"quizapp/internal/member/usecase"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/types"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemberUseCase_Invite(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewMemberUseCase(mockRepo, mockRepoF, mockAuthz, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.FormMember)
+
+	ctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "4"})
+
+	testTable := []struct {
+		nameTest       string
+		ctx            context.Context
+		model          models.FormMember
+		mockBehavior   mockBehavior
+		expectedMember *models.FormMember
+		expectedErr    error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      ctx,
+			model:    models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleEditor},
+			mockBehavior: func(ctx context.Context, model *models.FormMember) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionMemberManage).Return(nil)
+				mockRepoF.EXPECT().GetOwnerId(ctx, model.Form_id).Return("4", nil)
+				mockRepo.EXPECT().Upsert(ctx, model).Return(&models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleEditor}, nil)
+			},
+			expectedMember: &models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleEditor},
+		},
+		{
+			nameTest:     "unknown_role",
+			ctx:          ctx,
+			model:        models.FormMember{Form_id: "3", User_id: "5", Role: "admin"},
+			mockBehavior: func(ctx context.Context, model *models.FormMember) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "forbidden",
+			ctx:      ctx,
+			model:    models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleViewer},
+			mockBehavior: func(ctx context.Context, model *models.FormMember) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionMemberManage).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "invite_creator",
+			ctx:      ctx,
+			model:    models.FormMember{Form_id: "3", User_id: "4", Role: models.MemberRoleViewer},
+			mockBehavior: func(ctx context.Context, model *models.FormMember) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionMemberManage).Return(nil)
+				mockRepoF.EXPECT().GetOwnerId(ctx, model.Form_id).Return("4", nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "repo_error",
+			ctx:      ctx,
+			model:    models.FormMember{Form_id: "3", User_id: "5", Role: models.MemberRoleOwner},
+			mockBehavior: func(ctx context.Context, model *models.FormMember) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionMemberManage).Return(nil)
+				mockRepoF.EXPECT().GetOwnerId(ctx, model.Form_id).Return("4", nil)
+				mockRepo.EXPECT().Upsert(ctx, model).Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.Invite(testCase.ctx, &testCase.model)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedMember, got)
+		})
+	}
+}
+
+func TestMemberUseCase_GetByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewMemberUseCase(mockRepo, mockRepoF, mockAuthz, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, form_id string, sets types.GetSets)
+
+	ctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "5"})
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		form_id         string
+		sets            types.GetSets
+		mockBehavior    mockBehavior
+		expectedMembers []*models.FormMember
+		expectedErr     error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      ctx,
+			form_id:  "3",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(nil)
+				mockRepo.EXPECT().GetByFormId(ctx, form_id, sets).Return([]*models.FormMember{{Form_id: "3", User_id: "5", Role: models.MemberRoleViewer}}, nil)
+			},
+			expectedMembers: []*models.FormMember{{Form_id: "3", User_id: "5", Role: models.MemberRoleViewer}},
+		},
+		{
+			nameTest: "forbidden",
+			ctx:      ctx,
+			form_id:  "3",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.sets)
+
+			got, err := uc.GetByFormId(testCase.ctx, testCase.form_id, testCase.sets)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedMembers, got)
+		})
+	}
+}
+
+func TestMemberUseCase_Remove(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewMemberUseCase(mockRepo, mockRepoF, mockAuthz, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, form_id, user_id string)
+
+	ctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "5"})
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		form_id, user_id string
+		mockBehavior     mockBehavior
+		expectedErr      error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      ctx,
+			form_id:  "3",
+			user_id:  "6",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionMemberManage).Return(nil)
+				mockRepo.EXPECT().Delete(ctx, form_id, user_id).Return(nil)
+			},
+		},
+		{
+			nameTest: "leave",
+			ctx:      ctx,
+			form_id:  "3",
+			user_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				mockRepo.EXPECT().Delete(ctx, form_id, user_id).Return(nil)
+			},
+		},
+		{
+			nameTest: "forbidden",
+			ctx:      ctx,
+			form_id:  "3",
+			user_id:  "6",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionMemberManage).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest:     "unauthorized",
+			ctx:          context.Background(),
+			form_id:      "3",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {},
+			expectedErr:  errs.ErrUnauthorized,
+		},
+		{
+			nameTest: "not_member",
+			ctx:      ctx,
+			form_id:  "3",
+			user_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				mockRepo.EXPECT().Delete(ctx, form_id, user_id).Return(errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			err := uc.Remove(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
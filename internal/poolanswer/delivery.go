@@ -5,6 +5,14 @@ import "github.com/gin-gonic/gin"
 // Question HTTP Handlers interface
 type Handlers interface {
 	Create() gin.HandlerFunc
+	Start() gin.HandlerFunc
+	Submit() gin.HandlerFunc
 	GetByFormId() gin.HandlerFunc
 	GetByPoolAnswerId() gin.HandlerFunc
+	Update() gin.HandlerFunc
+	Delete() gin.HandlerFunc
+	GetByUser() gin.HandlerFunc
+	PublicCreate() gin.HandlerFunc
+	Export() gin.HandlerFunc
+	GetResult() gin.HandlerFunc
 }
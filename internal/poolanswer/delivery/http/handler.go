@@ -1,28 +1,67 @@
 package http
 
 import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
 	"net/http"
 	"quizapp/internal/answer"
 	"quizapp/internal/form"
 	"quizapp/internal/poolanswer"
 	"quizapp/models"
 	"quizapp/pkg/errs"
+	"quizapp/pkg/questiondto"
 	"quizapp/pkg/types"
+	"quizapp/pkg/xlsx"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 )
 
+// Bank question drawn into attempt, correct answer is not shown
+type drawnQuestionResponse struct {
+	Id       string              `json:"id"`
+	Header   string              `json:"header"`
+	Type     string              `json:"type"`
+	Options  questiondto.Options `json:"options"`
+	Required bool                `json:"required"`
+	Rules    questiondto.Rules   `json:"rules"`
+	Points   int                 `json:"points,omitempty"`
+}
+
+type answeredQuestionResponse struct {
+	Header  string              `json:"header"`
+	Type    string              `json:"type"`
+	Options questiondto.Options `json:"options"`
+}
+
 type answerResponse struct {
-	Id             string `json:"id"`
-	Question_id    string `json:"question_id"`
-	Pool_answer_id string `json:"pool_answer_id"`
-	Value          string `json:"value"`
+	Id             string                    `json:"id"`
+	Question_id    string                    `json:"question_id"`
+	Pool_answer_id string                    `json:"pool_answer_id"`
+	Value          string                    `json:"value"`
+	Question       *answeredQuestionResponse `json:"question,omitempty"`
 }
 
 type poolAnswerResponse struct {
-	Id      string `json:"id"`
-	User_id string `json:"user_id"`
-	Form_id string `json:"form_id"`
+	Id         string    `json:"id"`
+	User_id    string    `json:"user_id,omitempty"`
+	Form_id    string    `json:"form_id"`
+	Version_id string    `json:"version_id"`
+	Created_at time.Time `json:"created_at"`
+	// Points scored, set for pool answers of quiz only
+	Score *int `json:"score,omitempty"`
+	// Attempt of timed form is pending till answers are submitted
+	Pending      bool       `json:"pending,omitempty"`
+	Deadline     *time.Time `json:"deadline,omitempty"`
+	Submitted_at *time.Time `json:"submitted_at,omitempty"`
+	// Seconds left till deadline, set for pending attempts only
+	Remaining_time *int `json:"remaining_time,omitempty"`
+	// Bank questions drawn into attempt, answered along version questions
+	Questions []*drawnQuestionResponse `json:"questions,omitempty"`
 }
 
 type poolsAnswerResponse struct {
@@ -34,6 +73,27 @@ type answersResponse struct {
 	Answers     []*answerResponse   `json:"answers"`
 }
 
+type gradedAnswerResponse struct {
+	Question_id string `json:"question_id"`
+	Header      string `json:"header"`
+	Type        string `json:"type"`
+	// Empty, if question is not answered
+	Value string `json:"value,omitempty"`
+	// Correct answer, hidden from author until form shows correct answers
+	Correct    string `json:"correct,omitempty"`
+	Is_correct bool   `json:"is_correct"`
+	Points     int    `json:"points"`
+	// Points for correct answer, 0 for not scored question
+	Max_points int `json:"max_points"`
+}
+
+type quizResultResponse struct {
+	Pool_answer *poolAnswerResponse     `json:"pool_answer"`
+	Score       int                     `json:"score"`
+	Max_score   int                     `json:"max_score"`
+	Answers     []*gradedAnswerResponse `json:"answers"`
+}
+
 type answerRequest struct {
 	Question_id string `json:"question_id" binding:"required"`
 	Value       string `json:"value" binding:"required"`
@@ -48,6 +108,17 @@ type poolAnswerCreatResponse struct {
 	Answers     []*answerResponse   `json:"answers"`
 }
 
+type answerErrResponse struct {
+	Question_id string `json:"question_id,omitempty"`
+	Reason      string `json:"reason" enums:"no_answers,unknown_question,duplicate_answer,invalid_value,hidden_question,required,rule_violation"`
+	// Broken question rule, set for rule_violation only
+	Rule string `json:"rule,omitempty" enums:"pattern,min_length,max_length,format"`
+}
+
+type answersErrResponse struct {
+	Errors []*answerErrResponse `json:"errors"`
+}
+
 type answersHandlers struct {
 	paUC       poolanswer.UseCase
 	aUC        answer.UseCase
@@ -66,18 +137,139 @@ func NewAnswersHandlers(paUC poolanswer.UseCase, aUC answer.UseCase, fUC form.Us
 
 // Create godoc
 // @Summary Create answers
-// @Description Create answers: pool answer by form id, answers with question id and value
+// @Description Create answers to latest published form version: pool answer by form id, answers with question id and value valid for question type
 // @Tags Answers
 // @Security JWTToken
 // @Param data body poolAnswerCreatRequest true "answers"
 // @Param formid path string true "form id"
+// @Param token query string false "secret of form link"
 // @Success 201 {object} poolAnswerCreatResponse
-// @Failure 400   "Invalid params"
+// @Failure 204   "No such form or form is not published"
+// @Failure 400 {object} answersErrResponse "Invalid params or rejected answers"
 // @Failure 401   "Unauthorized"
-// @Failure 403   "Permission denied"
+// @Failure 403   "Invalid link token or permission denied"
+// @Failure 409   "Form is not accepting answers, user already answered or form is answered within attempts"
 // @Failure 500   "Other err"
 // @Router /forms/{formid}/poolsanswer [post]
 func (h *answersHandlers) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		h.create(c, currentuser.Id)
+	}
+}
+
+// PublicCreate godoc
+// @Summary Create answers as guest
+// @Description Create answers to latest published form version without registration, if form is anonymous or link token is valid
+// @Tags Public
+// @Param data body poolAnswerCreatRequest true "answers"
+// @Param formid path string true "form id"
+// @Param token query string false "secret of form link"
+// @Success 201 {object} poolAnswerCreatResponse
+// @Failure 204   "No such form or form is not published"
+// @Failure 400 {object} answersErrResponse "Invalid params or rejected answers"
+// @Failure 401   "Form is accessible to authenticated users only"
+// @Failure 403   "Invalid link token or permission denied"
+// @Failure 409   "Form is not accepting answers or is answered within attempts"
+// @Failure 500   "Other err"
+// @Router /public/forms/{formid}/poolsanswer [post]
+func (h *answersHandlers) PublicCreate() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		h.create(c, "")
+	}
+}
+
+// Creates pool answer of user, guest has empty user_id
+func (h *answersHandlers) create(c *gin.Context, user_id string) {
+	answersDTO := new(poolAnswerCreatRequest)
+
+	err := c.ShouldBindJSON(answersDTO)
+	if err != nil {
+		c.AbortWithStatus(http.StatusBadRequest)
+		return
+	}
+
+	poolanswer := &models.PoolAnswer{
+		User_id: user_id,
+		Form_id: c.Param("formid"),
+	}
+
+	createdpa, createdanswers, err := h.paUC.Create(c, poolanswer, answersDTOToBL(answersDTO.Answers), c.Query("token"))
+	if err != nil {
+		var answersErr *errs.AnswersErr
+		if errors.As(err, &answersErr) {
+			c.AbortWithStatusJSON(errs.MatchHttpErr(err), answersErrToDTO(answersErr))
+			return
+		}
+
+		c.AbortWithStatus(errs.MatchHttpErr(err))
+		return
+	}
+
+	c.JSON(http.StatusCreated, &poolAnswerCreatResponse{
+		Pool_answer: poolAnswerBLToDTO(createdpa),
+		Answers:     answersBLToDTO(createdanswers),
+	})
+}
+
+// Start godoc
+// @Summary Start attempt
+// @Description Start attempt to answer timed form: pending pool answer with deadline set by form time limit, answers are submitted to it before deadline. Pending attempt, which is not expired, is returned instead of new one
+// @Tags Answers
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param token query string false "secret of form link"
+// @Success 201 {object} poolAnswerResponse "Started or pending attempt"
+// @Failure 204   "No such form or form is not published"
+// @Failure 400   "Invalid params or form is not timed"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Invalid link token or permission denied"
+// @Failure 409   "Form is not accepting answers or user already answered"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/poolsanswer/start [post]
+func (h *answersHandlers) Start() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		startedpa, err := h.paUC.Start(c, &models.PoolAnswer{
+			User_id: currentuser.Id,
+			Form_id: c.Param("formid"),
+		}, c.Query("token"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, poolAnswerBLToDTO(startedpa))
+	}
+}
+
+// Submit godoc
+// @Summary Submit attempt
+// @Description Submit answers of own pending attempt before its deadline, answers are valid for form version shown on attempt start
+// @Tags Answers
+// @Security JWTToken
+// @Param data body poolAnswerCreatRequest true "answers"
+// @Param formid path string true "form id"
+// @Param poolanswerid path string true "pool answer id"
+// @Success 200 {object} poolAnswerCreatResponse "Submitted"
+// @Failure 204   "No such attempt"
+// @Failure 400 {object} answersErrResponse "Invalid params or rejected answers"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not the attempt author"
+// @Failure 409   "Attempt is already submitted, its deadline is over or form is not accepting answers"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/poolsanswer/{poolanswerid}/submit [post]
+func (h *answersHandlers) Submit() gin.HandlerFunc {
 	return func(c *gin.Context) {
 		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
 		if !ok {
@@ -94,19 +286,168 @@ func (h *answersHandlers) Create() gin.HandlerFunc {
 		}
 
 		poolanswer := &models.PoolAnswer{
+			Id:      c.Param("poolanswerid"),
 			User_id: currentuser.Id,
 			Form_id: c.Param("formid"),
 		}
 
-		createdpa, createdanswers, err := h.paUC.Create(c, poolanswer, answersDTOToBL(answersDTO.Answers))
+		submittedpa, submittedanswers, err := h.paUC.Submit(c, poolanswer, answersDTOToBL(answersDTO.Answers))
 		if err != nil {
+			var answersErr *errs.AnswersErr
+			if errors.As(err, &answersErr) {
+				c.AbortWithStatusJSON(errs.MatchHttpErr(err), answersErrToDTO(answersErr))
+				return
+			}
+
 			c.AbortWithStatus(errs.MatchHttpErr(err))
 			return
 		}
 
-		c.JSON(http.StatusCreated, &poolAnswerCreatResponse{
-			Pool_answer: poolAnswerBLToDTO(createdpa),
-			Answers:     answersBLToDTO(createdanswers),
+		c.JSON(http.StatusOK, &poolAnswerCreatResponse{
+			Pool_answer: poolAnswerBLToDTO(submittedpa),
+			Answers:     answersBLToDTO(submittedanswers),
+		})
+	}
+}
+
+// Update godoc
+// @Summary Update answers
+// @Description Replace answers of own pool answer within form edit window, answers are valid for answered form version
+// @Tags Answers
+// @Security JWTToken
+// @Param data body poolAnswerCreatRequest true "answers"
+// @Param formid path string true "form id"
+// @Param poolanswerid path string true "pool answer id"
+// @Success 200 {object} poolAnswerCreatResponse "Updated"
+// @Failure 204   "No such pool answer"
+// @Failure 400 {object} answersErrResponse "Invalid params or rejected answers"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not the pool answer author"
+// @Failure 409   "Pool answer is not editable"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/poolsanswer/{poolanswerid} [put]
+func (h *answersHandlers) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		answersDTO := new(poolAnswerCreatRequest)
+
+		err := c.ShouldBindJSON(answersDTO)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		poolanswer := &models.PoolAnswer{
+			Id:      c.Param("poolanswerid"),
+			User_id: currentuser.Id,
+			Form_id: c.Param("formid"),
+		}
+
+		updatedpa, updatedanswers, err := h.paUC.Update(c, poolanswer, answersDTOToBL(answersDTO.Answers))
+		if err != nil {
+			var answersErr *errs.AnswersErr
+			if errors.As(err, &answersErr) {
+				c.AbortWithStatusJSON(errs.MatchHttpErr(err), answersErrToDTO(answersErr))
+				return
+			}
+
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, &poolAnswerCreatResponse{
+			Pool_answer: poolAnswerBLToDTO(updatedpa),
+			Answers:     answersBLToDTO(updatedanswers),
+		})
+	}
+}
+
+// Delete godoc
+// @Summary Delete answers
+// @Description Withdraw own pool answer with its answers within form edit window. Form owner, members with owner role, moderators and admins remove pool answers of others at any time
+// @Tags Answers
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param poolanswerid path string true "pool answer id"
+// @Success 200   "Deleted"
+// @Failure 204   "No such pool answer"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the pool answer author nor the form owner, member with owner role, moderator or admin"
+// @Failure 409   "Pool answer is not editable"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/poolsanswer/{poolanswerid} [delete]
+func (h *answersHandlers) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		err := h.paUC.Delete(c, &models.PoolAnswer{
+			Id:      c.Param("poolanswerid"),
+			User_id: currentuser.Id,
+			Form_id: c.Param("formid"),
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+// GetByUser godoc
+// @Summary Get my answers
+// @Description Get pool answers of current user across forms
+// @Tags Answers
+// @Security JWTToken
+// @Param limit query int true "limit" minimum(1)
+// @Param offset query int true "offset" minimum(0)
+// @Success 200 {object} poolsAnswerResponse "Found"
+// @Failure 204 {object} poolsAnswerResponse "No pools answer"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 500   "Other err"
+// @Router /me/poolsanswer [get]
+func (h *answersHandlers) GetByUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		limit, offset, ok := types.ValidateGetSets(c.Query("limit"), c.Query("offset"))
+		if !ok {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		pools_answer, err := h.paUC.GetByUserId(c, currentuser.Id, types.GetSets{
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		status := http.StatusOK
+
+		if len(pools_answer) == 0 {
+			status = http.StatusNoContent
+		}
+
+		c.JSON(status, &poolsAnswerResponse{
+			Pools_answer: poolsanswerBLToDTO(pools_answer),
 		})
 	}
 }
@@ -123,7 +464,7 @@ func (h *answersHandlers) Create() gin.HandlerFunc {
 // @Failure 204 {object} poolsAnswerResponse "No such form or pools answer"
 // @Failure 400   "Invalid params"
 // @Failure 401   "Unauthorized"
-// @Failure 403   "User is not the form owner"
+// @Failure 403   "User is neither the form owner or member nor moderator or admin"
 // @Failure 500   "Other err"
 // @Router /forms/{formid}/poolsanswer [get]
 func (h *answersHandlers) GetByFormId() gin.HandlerFunc {
@@ -157,7 +498,7 @@ func (h *answersHandlers) GetByFormId() gin.HandlerFunc {
 
 // GetByPoolAnswerId godoc
 // @Summary Get answers
-// @Description Get answers by pool answer id
+// @Description Get answers by pool answer id with questions as shown in answered form version, available to form owner, members, moderators, admins and pool answer author
 // @Tags Answers
 // @Security JWTToken
 // @Param poolanswerid path string true "pool answer id"
@@ -168,7 +509,7 @@ func (h *answersHandlers) GetByFormId() gin.HandlerFunc {
 // @Failure 204 {object} answersResponse "No such answers"
 // @Failure 400   "Invalid params"
 // @Failure 401   "Unauthorized"
-// @Failure 403   "User is not the form owner"
+// @Failure 403   "User is neither the form owner, member, moderator or admin nor the pool answer author"
 // @Failure 500   "Other err"
 // @Router /forms/{formid}/poolsanswer/{poolanswerid} [get]
 func (h *answersHandlers) GetByPoolAnswerId() gin.HandlerFunc {
@@ -211,13 +552,117 @@ func (h *answersHandlers) GetByPoolAnswerId() gin.HandlerFunc {
 	}
 }
 
+// GetResult godoc
+// @Summary Get quiz result
+// @Description Get graded answers of scored pool answer over questions shown to respondent. Author sees correct answers as allowed by form, form owner, members, moderators and admins see them always
+// @Tags Answers
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param poolanswerid path string true "pool answer id"
+// @Success 200 {object} quizResultResponse "Found"
+// @Failure 204   "No such pool answer or it is not scored"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner, member, moderator or admin nor the pool answer author"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/poolsanswer/{poolanswerid}/result [get]
+func (h *answersHandlers) GetResult() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		result, err := h.paUC.GetResult(c, &models.PoolAnswer{
+			Id:      c.Param("poolanswerid"),
+			User_id: currentuser.Id,
+			Form_id: c.Param("formid"),
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, quizResultBLToDTO(result))
+	}
+}
+
+// Export godoc
+// @Summary Export answers
+// @Description Export pool answers of form as table with one row per pool answer and one column per question of latest and answered versions and per drawn bank question, answers are written under the question as it was shown. Rows are written as they are read, response is cut on error after first row. CSV cells starting with =, +, -, @, tab or carriage return, which are not numbers, are prefixed with quote, so spreadsheets do not run them as formulas
+// @Tags Answers
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param format query string false "table format" Enums(csv, xlsx) default(csv)
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Success 200 {file} file "Table"
+// @Failure 204   "No such form or form is not published"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner or member nor moderator or admin"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/poolsanswer/export [get]
+func (h *answersHandlers) Export() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		format := c.DefaultQuery("format", exportCSV)
+
+		contentType, ok := exportContentTypes[format]
+		if !ok {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		var table tableWriter
+
+		err := h.paUC.Export(c, c.Param("formid"), func(row []string) error {
+			// headers are sent with first row, so errors before it get own status
+			if table == nil {
+				c.Header("Content-Type", contentType)
+				c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=\"form_%s.%s\"", c.Param("formid"), format))
+				c.Status(http.StatusOK)
+
+				table = newTableWriter(format, c.Writer)
+			}
+
+			return table.Write(row)
+		})
+		if err != nil {
+			if table == nil {
+				c.AbortWithStatus(errs.MatchHttpErr(err))
+				return
+			}
+
+			c.Abort()
+			_ = c.Error(err)
+			return
+		}
+
+		err = table.Close()
+		if err != nil {
+			_ = c.Error(err)
+		}
+	}
+}
+
 func answerBLToDTO(answerBL *models.Answer) *answerResponse {
-	return &answerResponse{
+	res := &answerResponse{
 		Id:             answerBL.Id,
 		Question_id:    answerBL.Question_id,
 		Pool_answer_id: answerBL.Pool_answer_id,
 		Value:          answerBL.Value,
 	}
+
+	if q := answerBL.Question; q != nil {
+		res.Question = &answeredQuestionResponse{
+			Header:  q.Header,
+			Type:    q.Type,
+			Options: questiondto.OptionsBLToDTO(&q.Options),
+		}
+	}
+
+	return res
 }
 
 func answerDTOToBL(answerDTO *answerRequest) *models.Answer {
@@ -248,11 +693,70 @@ func answersBLToDTO(answers []*models.Answer) []*answerResponse {
 }
 
 func poolAnswerBLToDTO(paBL *models.PoolAnswer) *poolAnswerResponse {
-	return &poolAnswerResponse{
-		Id:      paBL.Id,
-		Form_id: paBL.Form_id,
-		User_id: paBL.User_id,
+	res := &poolAnswerResponse{
+		Id:           paBL.Id,
+		Form_id:      paBL.Form_id,
+		Version_id:   paBL.Version_id,
+		User_id:      paBL.User_id,
+		Score:        paBL.Score,
+		Pending:      paBL.Pending,
+		Deadline:     paBL.Deadline,
+		Submitted_at: paBL.Submitted_at,
+		Created_at:   paBL.Created_at,
+	}
+
+	if paBL.Pending {
+		remaining := int(paBL.Remaining(time.Now()) / time.Second)
+		res.Remaining_time = &remaining
+	}
+
+	if len(paBL.Questions) != 0 {
+		res.Questions = drawnQuestionsBLToDTO(paBL.Questions)
+	}
+
+	return res
+}
+
+func drawnQuestionsBLToDTO(questionsBL []*models.Question) []*drawnQuestionResponse {
+	res := make([]*drawnQuestionResponse, len(questionsBL))
+
+	for i, q := range questionsBL {
+		res[i] = &drawnQuestionResponse{
+			Id:       q.Id,
+			Header:   q.Header,
+			Type:     q.Type,
+			Options:  questiondto.OptionsBLToDTO(&q.Options),
+			Required: q.Required,
+			Rules:    questiondto.RulesBLToDTO(&q.Rules),
+			Points:   q.Points,
+		}
+	}
+
+	return res
+}
+
+func quizResultBLToDTO(resultBL *models.QuizResult) *quizResultResponse {
+	res := &quizResultResponse{
+		Pool_answer: poolAnswerBLToDTO(resultBL.Pool_answer),
+		Score:       resultBL.Score,
+		Max_score:   resultBL.Max_score,
+		Answers:     make([]*gradedAnswerResponse, len(resultBL.Answers)),
 	}
+
+	for i, a := range resultBL.Answers {
+		res.Answers[i] = &gradedAnswerResponse{
+			Question_id: a.Question.Id,
+			Header:      a.Question.Header,
+			Type:        a.Question.Type,
+			Value:       a.Value,
+			Correct:     a.Question.Correct,
+			Is_correct:  a.Is_correct,
+			Points:      a.Points,
+			Max_points:  a.Question.Points,
+		}
+	}
+
+	return res
 }
 
 func poolsanswerBLToDTO(paBL []*models.PoolAnswer) []*poolAnswerResponse {
@@ -264,3 +768,74 @@ func poolsanswerBLToDTO(paBL []*models.PoolAnswer) []*poolAnswerResponse {
 
 	return res
 }
+
+func answersErrToDTO(answersErr *errs.AnswersErr) *answersErrResponse {
+	res := &answersErrResponse{
+		Errors: make([]*answerErrResponse, len(answersErr.Errs)),
+	}
+
+	for i, e := range answersErr.Errs {
+		res.Errors[i] = &answerErrResponse{
+			Question_id: e.Question_id,
+			Reason:      e.Reason,
+			Rule:        e.Rule,
+		}
+	}
+
+	return res
+}
+
+const (
+	exportCSV  = "csv"
+	exportXLSX = "xlsx"
+)
+
+var exportContentTypes = map[string]string{
+	exportCSV:  "text/csv; charset=utf-8",
+	exportXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+}
+
+// Table of export, Close completes it
+type tableWriter interface {
+	Write(row []string) error
+	Close() error
+}
+
+type csvTableWriter struct {
+	*csv.Writer
+}
+
+// Spreadsheets evaluate cells starting with these as formulas
+const csvFormulaPrefixes = "=+-@\t\r"
+
+// Quotes cells, which spreadsheet would run as formula, numbers are kept as is.
+// Inline strings of xlsx are never evaluated, so only csv needs it.
+func (w csvTableWriter) Write(row []string) error {
+	escaped := make([]string, len(row))
+
+	for i, cell := range row {
+		escaped[i] = cell
+
+		if cell != "" && strings.ContainsRune(csvFormulaPrefixes, rune(cell[0])) {
+			_, err := strconv.ParseFloat(cell, 64)
+			if err != nil {
+				escaped[i] = "'" + cell
+			}
+		}
+	}
+
+	return w.Writer.Write(escaped)
+}
+
+func (w csvTableWriter) Close() error {
+	w.Flush()
+	return w.Error()
+}
+
+func newTableWriter(format string, w io.Writer) tableWriter {
+	if format == exportXLSX {
+		return xlsx.NewWriter(w)
+	}
+
+	return csvTableWriter{csv.NewWriter(w)}
+}
@@ -9,6 +9,22 @@ import (
 // Map answers routes
 func MapPARoutes(answersGroup *gin.RouterGroup, h poolanswer.Handlers) {
 	answersGroup.POST("", h.Create())
+	answersGroup.POST("/start", h.Start())
 	answersGroup.GET("", h.GetByFormId())
+	answersGroup.GET("/export", h.Export())
 	answersGroup.GET("/:poolanswerid", h.GetByPoolAnswerId())
+	answersGroup.GET("/:poolanswerid/result", h.GetResult())
+	answersGroup.POST("/:poolanswerid/submit", h.Submit())
+	answersGroup.PUT("/:poolanswerid", h.Update())
+	answersGroup.DELETE("/:poolanswerid", h.Delete())
+}
+
+// Map answers routes open to guests
+func MapPublicPARoutes(answersGroup *gin.RouterGroup, h poolanswer.Handlers) {
+	answersGroup.POST("", h.PublicCreate())
+}
+
+// Map current user answers routes
+func MapMyPARoutes(meGroup *gin.RouterGroup, h poolanswer.Handlers) {
+	meGroup.GET("/poolsanswer", h.GetByUser())
 }
@@ -4,21 +4,52 @@ import (
 	"context"
 	"quizapp/models"
 	"quizapp/pkg/types"
+	"time"
 )
 
 type Repo interface {
 	// Returns created model & nil, if created.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrAlreadyAnswered, if single model of user for form exists.
 	// Returns nil & ErrForbidden, if permission denied.
 	// Returns nil & other err else.
 	Create(ctx context.Context, pool_answer *models.PoolAnswer) (*models.PoolAnswer, error)
 
+	// Pending attempts are skipped.
 	// Returns slice & nil, if get smth.
 	// Returns empty slice & nil, if get nothing.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & other err else.
 	GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.PoolAnswer, error)
 
+	// Returns slice & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.PoolAnswer, error)
+
+	// Returns latest pending attempt of user for form & nil, if get.
+	// Returns nil & ErrContentNotFound, if user has no pending attempt.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetPending(ctx context.Context, form_id, user_id string) (*models.PoolAnswer, error)
+
+	// Marks pending attempt as answered at submitted_at with score, nil score marks it as not graded.
+	// Returns nil, if submitted.
+	// Returns ErrContentNotFound, if no such pending attempt.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	Submit(ctx context.Context, id string, score *int, submitted_at time.Time) error
+
+	// Sets score of pool answer, nil score marks it as not graded.
+	// Returns nil, if updated.
+	// Returns ErrContentNotFound, if nothing to update.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	UpdateScore(ctx context.Context, id string, score *int) error
+
 	// Returns nil, if deleted.
 	// Returns ErrContentNotFound, if nothing to delete.
 	// Returns ErrInvalidContent, if invalid inputs.
@@ -26,9 +57,32 @@ type Repo interface {
 	// Returns other errors else.
 	Delete(ctx context.Context, id string) error
 
+	// Returns number of form pool answers, pending attempts are not counted, & nil.
+	// Returns 0 & ErrInvalidContent, if invalid inputs.
+	// Returns 0 & other err else.
+	CountByFormId(ctx context.Context, form_id string) (int, error)
+
 	// Returns found model, if get.
 	// Returns nil & ErrContentNotFound, if get nothing.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & other err else.
 	GetById(ctx context.Context, id string) (*models.PoolAnswer, error)
+
+	// Returns ids of versions answered by form pool answers, except pending attempts, ordered by id & nil.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetVersionIdsByFormId(ctx context.Context, form_id string) ([]string, error)
+
+	// Returns bank questions drawn into form pool answers, except pending attempts,
+	// once per id, header and type, ordered by id & nil.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetDrawnByFormId(ctx context.Context, form_id string) ([]*models.Question, error)
+
+	// Calls fn for each form pool answer, except pending attempts, ordered by id with its answer values by question id.
+	// Pool answers are read one by one as fetched, iteration stops on first fn err.
+	// Returns nil, if all pool answers passed.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns fn err or other err else.
+	ForEachByFormId(ctx context.Context, form_id string, fn func(pool_answer *models.PoolAnswer, values map[string]string) error) error
 }
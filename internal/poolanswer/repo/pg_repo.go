@@ -2,13 +2,16 @@ package repo
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"quizapp/internal/poolanswer"
 	"quizapp/models"
 	"quizapp/pkg/errs"
 	"quizapp/pkg/postgres"
+	"quizapp/pkg/snapshot"
 	"quizapp/pkg/types"
 	"strconv"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/jackc/pgconn"
@@ -16,9 +19,17 @@ import (
 )
 
 type PoolAnswerDB struct {
-	ID     int
-	UserID int
-	FormID int
+	ID          int
+	UserID      *int
+	FormID      int
+	VersionID   int
+	Single      bool
+	Score       *int
+	Pending     bool
+	Deadline    *time.Time
+	Questions   []byte
+	SubmittedAt *time.Time
+	CreatedAt   time.Time
 }
 
 type poolAnswerRepo struct {
@@ -37,19 +48,25 @@ func (p *poolAnswerRepo) Create(ctx context.Context, poolanswer *models.PoolAnsw
 
 	sql, args, err := p.Builder.
 		Insert("pool_answer_").
-		Columns("user_id_, form_id_").
-		Values(poolanswerDB.UserID, poolanswerDB.FormID).
-		Suffix("RETURNING \"id_\"").
+		Columns("user_id_, form_id_, version_id_, single_, score_, pending_, deadline_, questions_, submitted_at_").
+		Values(poolanswerDB.UserID, poolanswerDB.FormID, poolanswerDB.VersionID, poolanswerDB.Single, poolanswerDB.Score,
+			poolanswerDB.Pending, poolanswerDB.Deadline, string(poolanswerDB.Questions), poolanswerDB.SubmittedAt).
+		Suffix("RETURNING \"id_\", \"created_at_\"").
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = p.Pool.QueryRow(ctx, sql, args...).Scan(&poolanswerDB.ID)
+	err = p.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&poolanswerDB.ID, &poolanswerDB.CreatedAt)
 	if err != nil {
 		var pgErr *pgconn.PgError
-		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
-			return nil, errs.ErrForbidden
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case postgres.PermDenied:
+				return nil, errs.ErrForbidden
+			case postgres.UniqueViolation:
+				return nil, errs.ErrAlreadyAnswered
+			}
 		}
 
 		return nil, err
@@ -65,9 +82,9 @@ func (p *poolAnswerRepo) GetByFormId(ctx context.Context, form_id string, sets t
 	}
 
 	sql, args, err := p.Builder.
-		Select("id_, user_id_").
+		Select("id_, user_id_, version_id_, single_, score_, pending_, deadline_, submitted_at_, created_at_").
 		From("pool_answer_").
-		Where(squirrel.Eq{"form_id_": intid}).
+		Where(squirrel.Eq{"form_id_": intid, "pending_": false}).
 		Limit(sets.Limit).
 		Offset(sets.Offset).
 		ToSql()
@@ -75,7 +92,7 @@ func (p *poolAnswerRepo) GetByFormId(ctx context.Context, form_id string, sets t
 		return nil, err
 	}
 
-	rows, err := p.Pool.Query(ctx, sql, args...)
+	rows, err := p.Conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -86,7 +103,7 @@ func (p *poolAnswerRepo) GetByFormId(ctx context.Context, form_id string, sets t
 	for rows.Next() {
 		paDB := PoolAnswerDB{FormID: intid}
 
-		err = rows.Scan(&paDB.ID, &paDB.UserID)
+		err = rows.Scan(&paDB.ID, &paDB.UserID, &paDB.VersionID, &paDB.Single, &paDB.Score, &paDB.Pending, &paDB.Deadline, &paDB.SubmittedAt, &paDB.CreatedAt)
 		if err != nil {
 			return nil, err
 		}
@@ -102,6 +119,50 @@ func (p *poolAnswerRepo) GetByFormId(ctx context.Context, form_id string, sets t
 	return res, nil
 }
 
+func (p *poolAnswerRepo) GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.PoolAnswer, error) {
+	intid, err := strconv.Atoi(user_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := p.Builder.
+		Select("id_, form_id_, version_id_, single_, score_, pending_, deadline_, submitted_at_, created_at_").
+		From("pool_answer_").
+		Where(squirrel.Eq{"user_id_": intid}).
+		Limit(sets.Limit).
+		Offset(sets.Offset).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := p.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.PoolAnswer, 0)
+
+	for rows.Next() {
+		paDB := PoolAnswerDB{UserID: &intid}
+
+		err = rows.Scan(&paDB.ID, &paDB.FormID, &paDB.VersionID, &paDB.Single, &paDB.Score, &paDB.Pending, &paDB.Deadline, &paDB.SubmittedAt, &paDB.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		paBL, err := paDBToBL(&paDB)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, paBL)
+	}
+
+	return res, nil
+}
+
 func (p *poolAnswerRepo) GetById(ctx context.Context, id string) (*models.PoolAnswer, error) {
 	intid, err := strconv.Atoi(id)
 	if err != nil {
@@ -109,7 +170,7 @@ func (p *poolAnswerRepo) GetById(ctx context.Context, id string) (*models.PoolAn
 	}
 
 	sql, args, err := p.Builder.
-		Select("user_id_, form_id_").
+		Select("user_id_, form_id_, version_id_, single_, score_, pending_, deadline_, questions_, submitted_at_, created_at_").
 		From("pool_answer_").
 		Where(squirrel.Eq{"id_": intid}).
 		ToSql()
@@ -118,7 +179,44 @@ func (p *poolAnswerRepo) GetById(ctx context.Context, id string) (*models.PoolAn
 	}
 
 	modelDB := PoolAnswerDB{ID: intid}
-	err = p.Pool.QueryRow(ctx, sql, args...).Scan(&modelDB.UserID, &modelDB.FormID)
+	err = p.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.UserID, &modelDB.FormID, &modelDB.VersionID,
+		&modelDB.Single, &modelDB.Score, &modelDB.Pending, &modelDB.Deadline, &modelDB.Questions, &modelDB.SubmittedAt, &modelDB.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return paDBToBL(&modelDB)
+}
+
+func (p *poolAnswerRepo) GetPending(ctx context.Context, form_id, user_id string) (*models.PoolAnswer, error) {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	intuserid, err := strconv.Atoi(user_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := p.Builder.
+		Select("id_, version_id_, single_, score_, deadline_, questions_, submitted_at_, created_at_").
+		From("pool_answer_").
+		Where(squirrel.Eq{"form_id_": intformid, "user_id_": intuserid, "pending_": true}).
+		OrderBy("id_ DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	modelDB := PoolAnswerDB{UserID: &intuserid, FormID: intformid, Pending: true}
+	err = p.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.ID, &modelDB.VersionID, &modelDB.Single,
+		&modelDB.Score, &modelDB.Deadline, &modelDB.Questions, &modelDB.SubmittedAt, &modelDB.CreatedAt)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, errs.ErrContentNotFound
@@ -130,6 +228,73 @@ func (p *poolAnswerRepo) GetById(ctx context.Context, id string) (*models.PoolAn
 	return paDBToBL(&modelDB)
 }
 
+func (p *poolAnswerRepo) Submit(ctx context.Context, id string, score *int, submitted_at time.Time) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	// condition on pending_ makes concurrent submits of attempt pass once
+	sql, args, err := p.Builder.
+		Update("pool_answer_").
+		Set("pending_", false).
+		Set("score_", score).
+		Set("submitted_at_", submitted_at).
+		Where(squirrel.Eq{"id_": intid, "pending_": true}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := p.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (p *poolAnswerRepo) UpdateScore(ctx context.Context, id string, score *int) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := p.Builder.
+		Update("pool_answer_").
+		Set("score_", score).
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := p.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
 func (p *poolAnswerRepo) Delete(ctx context.Context, id string) error {
 	intid, err := strconv.Atoi(id)
 	if err != nil {
@@ -144,7 +309,7 @@ func (p *poolAnswerRepo) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	res, err := p.Pool.Exec(ctx, sql, args...)
+	res, err := p.Conn(ctx).Exec(ctx, sql, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
@@ -161,11 +326,186 @@ func (p *poolAnswerRepo) Delete(ctx context.Context, id string) error {
 	return nil
 }
 
+func (p *poolAnswerRepo) CountByFormId(ctx context.Context, form_id string) (int, error) {
+	intid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return 0, errs.ErrInvalidContent
+	}
+
+	sql, args, err := p.Builder.
+		Select("COUNT(*)").
+		From("pool_answer_").
+		Where(squirrel.Eq{"form_id_": intid, "pending_": false}).
+		ToSql()
+	if err != nil {
+		return 0, err
+	}
+
+	var count int
+	err = p.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&count)
+	if err != nil {
+		return 0, err
+	}
+
+	return count, nil
+}
+
+func (p *poolAnswerRepo) GetVersionIdsByFormId(ctx context.Context, form_id string) ([]string, error) {
+	intid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := p.Builder.
+		Select("DISTINCT version_id_").
+		From("pool_answer_").
+		Where(squirrel.Eq{"form_id_": intid, "pending_": false}).
+		OrderBy("version_id_").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := p.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]string, 0)
+
+	for rows.Next() {
+		var id int
+
+		err = rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, strconv.Itoa(id))
+	}
+
+	return res, rows.Err()
+}
+
+func (p *poolAnswerRepo) GetDrawnByFormId(ctx context.Context, form_id string) ([]*models.Question, error) {
+	intid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	// choices of drawn question are shuffled per attempt, so they do not tell questions apart
+	sql, args, err := p.Builder.
+		Select("DISTINCT ON ((q->>'id')::int, q->>'header', q->>'type') q").
+		From("pool_answer_ p, jsonb_array_elements(p.questions_) AS q").
+		Where(squirrel.Eq{"p.form_id_": intid, "p.pending_": false}).
+		OrderBy("(q->>'id')::int, q->>'header', q->>'type'").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := p.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.Question, 0)
+
+	for rows.Next() {
+		var questionJSON []byte
+
+		err = rows.Scan(&questionJSON)
+		if err != nil {
+			return nil, err
+		}
+
+		var questionDB snapshot.Question
+		err = json.Unmarshal(questionJSON, &questionDB)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, snapshot.QuestionDBToBL(&questionDB))
+	}
+
+	return res, rows.Err()
+}
+
+func (p *poolAnswerRepo) ForEachByFormId(ctx context.Context, form_id string, fn func(pool_answer *models.PoolAnswer, values map[string]string) error) error {
+	intid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	// answers are pivoted to object of values by question id
+	sql, args, err := p.Builder.
+		Select("p.id_, p.user_id_, p.version_id_, p.single_, p.score_, p.pending_, p.deadline_, p.questions_, p.submitted_at_, p.created_at_, " +
+			"COALESCE(jsonb_object_agg(a.question_id_, a.value_) FILTER (WHERE a.id_ IS NOT NULL), '{}')").
+		From("pool_answer_ p").
+		LeftJoin("answer_ a ON a.pool_answer_id_ = p.id_").
+		Where(squirrel.Eq{"p.form_id_": intid, "p.pending_": false}).
+		GroupBy("p.id_").
+		OrderBy("p.id_").
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	rows, err := p.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var valuesDB []byte
+		paDB := PoolAnswerDB{FormID: intid}
+
+		err = rows.Scan(&paDB.ID, &paDB.UserID, &paDB.VersionID, &paDB.Single, &paDB.Score, &paDB.Pending, &paDB.Deadline, &paDB.Questions, &paDB.SubmittedAt, &paDB.CreatedAt, &valuesDB)
+		if err != nil {
+			return err
+		}
+
+		var values map[string]string
+		err = json.Unmarshal(valuesDB, &values)
+		if err != nil {
+			return err
+		}
+
+		paBL, err := paDBToBL(&paDB)
+		if err != nil {
+			return err
+		}
+
+		err = fn(paBL, values)
+		if err != nil {
+			return err
+		}
+	}
+
+	return rows.Err()
+}
+
 func paDBToBL(paDB *PoolAnswerDB) (*models.PoolAnswer, error) {
+	questions, err := drawnQuestionsDBToBL(paDB.Questions)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.PoolAnswer{
-		Id:      strconv.Itoa(paDB.ID),
-		User_id: strconv.Itoa(paDB.UserID),
-		Form_id: strconv.Itoa(paDB.FormID),
+		Id:           strconv.Itoa(paDB.ID),
+		User_id:      userIdToBL(paDB.UserID),
+		Form_id:      strconv.Itoa(paDB.FormID),
+		Version_id:   strconv.Itoa(paDB.VersionID),
+		Single:       paDB.Single,
+		Score:        paDB.Score,
+		Pending:      paDB.Pending,
+		Deadline:     paDB.Deadline,
+		Questions:    questions,
+		Submitted_at: paDB.SubmittedAt,
+		Created_at:   paDB.CreatedAt,
 	}, nil
 }
 
@@ -182,12 +522,14 @@ func paBLToDB(paBL *models.PoolAnswer) (*PoolAnswerDB, error) {
 		}
 	}
 
-	var uid int
+	// guest pool answer has no user
+	var uid *int
 	if paBL.User_id != "" {
-		uid, err = strconv.Atoi(paBL.User_id)
+		intuid, err := strconv.Atoi(paBL.User_id)
 		if err != nil {
 			return nil, err
 		}
+		uid = &intuid
 	}
 
 	var fid int
@@ -198,9 +540,48 @@ func paBLToDB(paBL *models.PoolAnswer) (*PoolAnswerDB, error) {
 		}
 	}
 
+	var vid int
+	if paBL.Version_id != "" {
+		vid, err = strconv.Atoi(paBL.Version_id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// attempt without drawn questions is stored with empty array
+	questions, err := snapshot.MarshalQuestions(paBL.Questions)
+	if err != nil {
+		return nil, err
+	}
+
 	return &PoolAnswerDB{
-		ID:     id,
-		UserID: uid,
-		FormID: fid,
+		ID:          id,
+		UserID:      uid,
+		FormID:      fid,
+		VersionID:   vid,
+		Single:      paBL.Single,
+		Score:       paBL.Score,
+		Pending:     paBL.Pending,
+		Deadline:    paBL.Deadline,
+		Questions:   questions,
+		SubmittedAt: paBL.Submitted_at,
+		CreatedAt:   paBL.Created_at,
 	}, nil
 }
+
+// attempt without drawn questions has nil questions
+func drawnQuestionsDBToBL(questionsJSON []byte) ([]*models.Question, error) {
+	questions, err := snapshot.UnmarshalQuestions(questionsJSON)
+	if err != nil || len(questions) == 0 {
+		return nil, err
+	}
+
+	return questions, nil
+}
+
+func userIdToBL(uid *int) string {
+	if uid == nil {
+		return ""
+	}
+	return strconv.Itoa(*uid)
+}
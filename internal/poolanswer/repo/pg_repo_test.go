@@ -10,17 +10,22 @@ import (
 	"quizapp/pkg/types"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/Masterminds/squirrel"
 	"github.com/driftprogramming/pgxpoolmock"
 	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
 	"github.com/pashagolub/pgxmock"
 
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	_builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	_builder   = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	_createdAt = time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+	_deadline  = _createdAt.Add(30 * time.Minute)
 )
 
 func TestPoolAnswerRepo_Create(t *testing.T) {
@@ -50,20 +55,115 @@ func TestPoolAnswerRepo_Create(t *testing.T) {
 			nameTest: "ok",
 			ctx:      context.Background(),
 			pool_answer: models.PoolAnswer{
-				Form_id: "12",
-				User_id: "14",
+				Form_id:    "12",
+				Version_id: "3",
+				User_id:    "14",
+				Single:     true,
+				Score:      intRef(3),
 			},
 			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
-				pgxRows := pgxpoolmock.NewRows([]string{"id_"}).AddRow(345).ToPgxRows()
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "created_at_"}).AddRow(345, _createdAt).ToPgxRows()
 				pgxRows.Next()
 				formidint, _ := strconv.Atoi(pool_answer.Form_id)
 				useridint, _ := strconv.Atoi(pool_answer.User_id)
-				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO pool_answer_ (user_id_, form_id_) VALUES ($1,$2) RETURNING \"id_\"", useridint, formidint).Return(pgxRows)
+				versionidint, _ := strconv.Atoi(pool_answer.Version_id)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO pool_answer_ (user_id_, form_id_, version_id_, single_, score_, pending_, deadline_, questions_, submitted_at_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING \"id_\", \"created_at_\"", &useridint, formidint, versionidint, pool_answer.Single, pool_answer.Score, pool_answer.Pending, pool_answer.Deadline, "[]", pool_answer.Submitted_at).Return(pgxRows)
 			},
 			expectedpoolsanswer: models.PoolAnswer{
-				Id:      "345",
-				Form_id: "12",
-				User_id: "14",
+				Id:         "345",
+				Form_id:    "12",
+				Version_id: "3",
+				User_id:    "14",
+				Single:     true,
+				Score:      intRef(3),
+				Created_at: _createdAt,
+			},
+		},
+		{
+			nameTest: "ok_attempt",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id:    "12",
+				Version_id: "3",
+				User_id:    "14",
+				Pending:    true,
+				Deadline:   &_deadline,
+				Questions: []*models.Question{
+					{
+						Id:      "901",
+						Header:  "q",
+						Type:    models.QuestionTypeSingleChoice,
+						Options: models.QuestionOptions{Choices: []string{"b", "a"}},
+						Correct: "a",
+						Points:  1,
+					},
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "created_at_"}).AddRow(347, _createdAt).ToPgxRows()
+				pgxRows.Next()
+				formidint, _ := strconv.Atoi(pool_answer.Form_id)
+				useridint, _ := strconv.Atoi(pool_answer.User_id)
+				versionidint, _ := strconv.Atoi(pool_answer.Version_id)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO pool_answer_ (user_id_, form_id_, version_id_, single_, score_, pending_, deadline_, questions_, submitted_at_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING \"id_\", \"created_at_\"", &useridint, formidint, versionidint, false, (*int)(nil), true, &_deadline, `[{"id":901,"header":"q","type":"single_choice","options":{"choices":["b","a"]},"correct":"a","points":1}]`, (*time.Time)(nil)).Return(pgxRows)
+			},
+			expectedpoolsanswer: models.PoolAnswer{
+				Id:         "347",
+				Form_id:    "12",
+				Version_id: "3",
+				User_id:    "14",
+				Pending:    true,
+				Deadline:   &_deadline,
+				Questions: []*models.Question{
+					{
+						Id:      "901",
+						Header:  "q",
+						Type:    models.QuestionTypeSingleChoice,
+						Options: models.QuestionOptions{Choices: []string{"b", "a"}},
+						Correct: "a",
+						Points:  1,
+					},
+				},
+				Created_at: _createdAt,
+			},
+		},
+		{
+			nameTest: "ok_guest",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id:    "12",
+				Version_id: "3",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "created_at_"}).AddRow(346, _createdAt).ToPgxRows()
+				pgxRows.Next()
+				formidint, _ := strconv.Atoi(pool_answer.Form_id)
+				versionidint, _ := strconv.Atoi(pool_answer.Version_id)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO pool_answer_ (user_id_, form_id_, version_id_, single_, score_, pending_, deadline_, questions_, submitted_at_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING \"id_\", \"created_at_\"", (*int)(nil), formidint, versionidint, false, (*int)(nil), false, (*time.Time)(nil), "[]", (*time.Time)(nil)).Return(pgxRows)
+			},
+			expectedpoolsanswer: models.PoolAnswer{
+				Id:         "346",
+				Form_id:    "12",
+				Version_id: "3",
+				Created_at: _createdAt,
+			},
+		},
+		{
+			nameTest: "already_answered",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id:    "12",
+				Version_id: "3",
+				User_id:    "14",
+				Single:     true,
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "created_at_"}).AddRow(nil, nil).RowError(0, &pgconn.PgError{Code: postgres.UniqueViolation}).ToPgxRows()
+				pgxRows.Next()
+				formidint, _ := strconv.Atoi(pool_answer.Form_id)
+				useridint, _ := strconv.Atoi(pool_answer.User_id)
+				versionidint, _ := strconv.Atoi(pool_answer.Version_id)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO pool_answer_ (user_id_, form_id_, version_id_, single_, score_, pending_, deadline_, questions_, submitted_at_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING \"id_\", \"created_at_\"", &useridint, formidint, versionidint, pool_answer.Single, pool_answer.Score, pool_answer.Pending, pool_answer.Deadline, "[]", pool_answer.Submitted_at).Return(pgxRows)
 			},
 		},
 		{
@@ -95,7 +195,8 @@ func TestPoolAnswerRepo_Create(t *testing.T) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 				formidint, _ := strconv.Atoi(pool_answer.Form_id)
 				useridint, _ := strconv.Atoi(pool_answer.User_id)
-				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO pool_answer_ (user_id_, form_id_) VALUES ($1,$2) RETURNING \"id_\"", useridint, formidint).Return(pgxRows)
+				versionidint, _ := strconv.Atoi(pool_answer.Version_id)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO pool_answer_ (user_id_, form_id_, version_id_, single_, score_, pending_, deadline_, questions_, submitted_at_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9) RETURNING \"id_\", \"created_at_\"", &useridint, formidint, versionidint, pool_answer.Single, pool_answer.Score, pool_answer.Pending, pool_answer.Deadline, "[]", pool_answer.Submitted_at).Return(pgxRows)
 			},
 		},
 	}
@@ -107,9 +208,11 @@ func TestPoolAnswerRepo_Create(t *testing.T) {
 			got, err := r.Create(testCase.ctx, &testCase.pool_answer)
 
 			switch testCase.nameTest {
-			case "ok":
+			case "ok", "ok_attempt", "ok_guest":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedpoolsanswer, *got)
+			case "already_answered":
+				assert.Equal(t, errs.ErrAlreadyAnswered, err)
 			case "invalid_inputs_form_id", "invalid_inputs_user_id":
 				assert.Equal(t, errs.ErrInvalidContent, err)
 			case "no_rows":
@@ -149,15 +252,29 @@ func TestPoolAnswerRepo_GetById(t *testing.T) {
 			ctx:      context.Background(),
 			id:       "345",
 			mockBehavior: func(ctx context.Context, id string) {
-				pgxRows := pgxpoolmock.NewRows([]string{"user_id_", "form_id_"}).AddRow(12, 14).ToPgxRows()
+				pgxRows := pgxpoolmock.NewRows([]string{"user_id_", "form_id_", "version_id_", "single_", "score_", "pending_", "deadline_", "questions_", "submitted_at_", "created_at_"}).AddRow(intRef(12), 14, 3, false, nil, true, &_deadline, []byte(`[{"id":901,"header":"q","type":"single_choice","options":{"choices":["b","a"]},"correct":"a","points":1}]`), nil, _createdAt).ToPgxRows()
 				pgxRows.Next()
 				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, form_id_ FROM pool_answer_ WHERE id_ = $1", idint).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, form_id_, version_id_, single_, score_, pending_, deadline_, questions_, submitted_at_, created_at_ FROM pool_answer_ WHERE id_ = $1", idint).Return(pgxRows)
 			},
 			expectedpoolsanswer: models.PoolAnswer{
-				Id:      "345",
-				Form_id: "14",
-				User_id: "12",
+				Id:         "345",
+				Form_id:    "14",
+				Version_id: "3",
+				User_id:    "12",
+				Pending:    true,
+				Deadline:   &_deadline,
+				Questions: []*models.Question{
+					{
+						Id:      "901",
+						Header:  "q",
+						Type:    models.QuestionTypeSingleChoice,
+						Options: models.QuestionOptions{Choices: []string{"b", "a"}},
+						Correct: "a",
+						Points:  1,
+					},
+				},
+				Created_at: _createdAt,
 			},
 		},
 		{
@@ -173,7 +290,7 @@ func TestPoolAnswerRepo_GetById(t *testing.T) {
 			mockBehavior: func(ctx context.Context, id string) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, form_id_ FROM pool_answer_ WHERE id_ = $1", idint).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, form_id_, version_id_, single_, score_, pending_, deadline_, questions_, submitted_at_, created_at_ FROM pool_answer_ WHERE id_ = $1", idint).Return(pgxRows)
 			},
 		},
 	}
@@ -229,20 +346,25 @@ func TestPoolAnswerRepo_GetByFormId(t *testing.T) {
 			form_id:  "12",
 			sets:     types.GetSets{},
 			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
-				pgxRows := pgxpoolmock.NewRows([]string{"id_", "user_id_"}).AddRow(345, 14).AddRow(346, 15).ToPgxRows()
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "user_id_", "version_id_", "single_", "score_", "pending_", "deadline_", "submitted_at_", "created_at_"}).AddRow(345, intRef(14), 3, true, intRef(5), false, nil, nil, _createdAt).AddRow(346, nil, 4, false, nil, false, nil, nil, _createdAt).ToPgxRows()
 				formidint, _ := strconv.Atoi(form_id)
-				mockPool.EXPECT().Query(ctx, "SELECT id_, user_id_ FROM pool_answer_ WHERE form_id_ = $1 LIMIT 0 OFFSET 0", formidint).Return(pgxRows, nil)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, user_id_, version_id_, single_, score_, pending_, deadline_, submitted_at_, created_at_ FROM pool_answer_ WHERE form_id_ = $1 AND pending_ = $2 LIMIT 0 OFFSET 0", formidint, false).Return(pgxRows, nil)
 			},
 			expectedpoolsanswers: []*models.PoolAnswer{
 				{
-					Id:      "345",
-					Form_id: "12",
-					User_id: "14",
+					Id:         "345",
+					Form_id:    "12",
+					Version_id: "3",
+					User_id:    "14",
+					Single:     true,
+					Score:      intRef(5),
+					Created_at: _createdAt,
 				},
 				{
-					Id:      "346",
-					Form_id: "12",
-					User_id: "15",
+					Id:         "346",
+					Form_id:    "12",
+					Version_id: "4",
+					Created_at: _createdAt,
 				},
 			},
 		},
@@ -260,7 +382,7 @@ func TestPoolAnswerRepo_GetByFormId(t *testing.T) {
 			sets:     types.GetSets{},
 			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
 				formidint, _ := strconv.Atoi(form_id)
-				mockPool.EXPECT().Query(ctx, "SELECT id_, user_id_ FROM pool_answer_ WHERE form_id_ = $1 LIMIT 0 OFFSET 0", formidint).Return(nil, errors.New("query_error"))
+				mockPool.EXPECT().Query(ctx, "SELECT id_, user_id_, version_id_, single_, score_, pending_, deadline_, submitted_at_, created_at_ FROM pool_answer_ WHERE form_id_ = $1 AND pending_ = $2 LIMIT 0 OFFSET 0", formidint, false).Return(nil, errors.New("query_error"))
 			},
 		},
 		{
@@ -272,7 +394,7 @@ func TestPoolAnswerRepo_GetByFormId(t *testing.T) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 				pgxRows.Next()
 				formidint, _ := strconv.Atoi(form_id)
-				mockPool.EXPECT().Query(ctx, "SELECT id_, user_id_ FROM pool_answer_ WHERE form_id_ = $1 LIMIT 0 OFFSET 0", formidint).Return(pgxRows, nil)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, user_id_, version_id_, single_, score_, pending_, deadline_, submitted_at_, created_at_ FROM pool_answer_ WHERE form_id_ = $1 AND pending_ = $2 LIMIT 0 OFFSET 0", formidint, false).Return(pgxRows, nil)
 			},
 			expectedpoolsanswers: []*models.PoolAnswer{},
 		},
@@ -302,6 +424,116 @@ func TestPoolAnswerRepo_GetByFormId(t *testing.T) {
 	}
 }
 
+func TestPoolAnswerRepo_GetByUserId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewPoolAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, user_id string, sets types.GetSets)
+
+	testTable := []struct {
+		nameTest             string
+		ctx                  context.Context
+		user_id              string
+		sets                 types.GetSets
+		mockBehavior         mockBehavior
+		expectedpoolsanswers []*models.PoolAnswer
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			user_id:  "12",
+			sets:     types.GetSets{},
+			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "form_id_", "version_id_", "single_", "score_", "pending_", "deadline_", "submitted_at_", "created_at_"}).AddRow(345, 14, 3, true, nil, true, &_deadline, nil, _createdAt).AddRow(346, 15, 4, false, nil, false, nil, nil, _createdAt).ToPgxRows()
+				useridint, _ := strconv.Atoi(user_id)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, form_id_, version_id_, single_, score_, pending_, deadline_, submitted_at_, created_at_ FROM pool_answer_ WHERE user_id_ = $1 LIMIT 0 OFFSET 0", useridint).Return(pgxRows, nil)
+			},
+			expectedpoolsanswers: []*models.PoolAnswer{
+				{
+					Id:         "345",
+					Form_id:    "14",
+					Version_id: "3",
+					User_id:    "12",
+					Single:     true,
+					Pending:    true,
+					Deadline:   &_deadline,
+					Created_at: _createdAt,
+				},
+				{
+					Id:         "346",
+					Form_id:    "15",
+					Version_id: "4",
+					User_id:    "12",
+					Created_at: _createdAt,
+				},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			user_id:      "5r4",
+			sets:         types.GetSets{},
+			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			user_id:  "12",
+			sets:     types.GetSets{},
+			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
+				useridint, _ := strconv.Atoi(user_id)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, form_id_, version_id_, single_, score_, pending_, deadline_, submitted_at_, created_at_ FROM pool_answer_ WHERE user_id_ = $1 LIMIT 0 OFFSET 0", useridint).Return(nil, errors.New("query_error"))
+			},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			user_id:  "12",
+			sets:     types.GetSets{},
+			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				pgxRows.Next()
+				useridint, _ := strconv.Atoi(user_id)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, form_id_, version_id_, single_, score_, pending_, deadline_, submitted_at_, created_at_ FROM pool_answer_ WHERE user_id_ = $1 LIMIT 0 OFFSET 0", useridint).Return(pgxRows, nil)
+			},
+			expectedpoolsanswers: []*models.PoolAnswer{},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.user_id, types.GetSets{})
+
+			got, err := r.GetByUserId(testCase.ctx, testCase.user_id, types.GetSets{})
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedpoolsanswers, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			case "no_rows":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, []*models.PoolAnswer{}, got)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
 func TestPoolAnswerRepo_Delete(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -380,3 +612,618 @@ func TestPoolAnswerRepo_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolAnswerRepo_UpdateScore(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewPoolAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string, score *int)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		score        *int
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "345",
+			score:    intRef(4),
+			mockBehavior: func(ctx context.Context, id string, score *int) {
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE pool_answer_ SET score_ = $1 WHERE id_ = $2", score, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			score:        intRef(4),
+			mockBehavior: func(ctx context.Context, id string, score *int) {},
+		},
+		{
+			nameTest: "no_pool_answer_to_update",
+			ctx:      context.Background(),
+			id:       "345",
+			score:    intRef(4),
+			mockBehavior: func(ctx context.Context, id string, score *int) {
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE pool_answer_ SET score_ = $1 WHERE id_ = $2", score, idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+		{
+			nameTest: "exec_error",
+			ctx:      context.Background(),
+			id:       "345",
+			score:    intRef(4),
+			mockBehavior: func(ctx context.Context, id string, score *int) {
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().Exec(ctx, "UPDATE pool_answer_ SET score_ = $1 WHERE id_ = $2", score, idint).Return(nil, errors.New("exec_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id, testCase.score)
+
+			err := r.UpdateScore(testCase.ctx, testCase.id, testCase.score)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_pool_answer_to_update":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			case "exec_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPoolAnswerRepo_GetPending(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewPoolAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id, user_id string)
+
+	const selectSQL = "SELECT id_, version_id_, single_, score_, deadline_, questions_, submitted_at_, created_at_ FROM pool_answer_ " +
+		"WHERE form_id_ = $1 AND pending_ = $2 AND user_id_ = $3 ORDER BY id_ DESC LIMIT 1"
+
+	testTable := []struct {
+		nameTest            string
+		ctx                 context.Context
+		form_id, user_id    string
+		mockBehavior        mockBehavior
+		expectedpoolsanswer models.PoolAnswer
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "14",
+			user_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "version_id_", "single_", "score_", "deadline_", "questions_", "submitted_at_", "created_at_"}).AddRow(345, 3, true, nil, &_deadline, []byte("[]"), nil, _createdAt).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, selectSQL, 14, true, 12).Return(pgxRows)
+			},
+			expectedpoolsanswer: models.PoolAnswer{
+				Id:         "345",
+				Form_id:    "14",
+				Version_id: "3",
+				User_id:    "12",
+				Single:     true,
+				Pending:    true,
+				Deadline:   &_deadline,
+				Created_at: _createdAt,
+			},
+		},
+		{
+			nameTest:     "invalid_inputs_form_id",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			user_id:      "12",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {},
+		},
+		{
+			nameTest:     "invalid_inputs_user_id",
+			ctx:          context.Background(),
+			form_id:      "14",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {},
+		},
+		{
+			nameTest: "no_pending",
+			ctx:      context.Background(),
+			form_id:  "14",
+			user_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "version_id_", "single_", "score_", "deadline_", "questions_", "submitted_at_", "created_at_"}).AddRow(nil, nil, nil, nil, nil, nil, nil, nil).RowError(0, pgx.ErrNoRows).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, selectSQL, 14, true, 12).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			got, err := r.GetPending(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedpoolsanswer, *got)
+			case "invalid_inputs_form_id", "invalid_inputs_user_id":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_pending":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPoolAnswerRepo_Submit(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewPoolAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string, score *int)
+
+	const updateSQL = "UPDATE pool_answer_ SET pending_ = $1, score_ = $2, submitted_at_ = $3 WHERE id_ = $4 AND pending_ = $5"
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		score        *int
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "345",
+			score:    intRef(4),
+			mockBehavior: func(ctx context.Context, id string, score *int) {
+				mockPool.EXPECT().Exec(ctx, updateSQL, false, score, _createdAt, 345, true).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string, score *int) {},
+		},
+		{
+			nameTest: "not_pending",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string, score *int) {
+				mockPool.EXPECT().Exec(ctx, updateSQL, false, score, _createdAt, 345, true).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+		{
+			nameTest: "permission_denied",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string, score *int) {
+				mockPool.EXPECT().Exec(ctx, updateSQL, false, score, _createdAt, 345, true).Return(nil, &pgconn.PgError{Code: postgres.PermDenied})
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id, testCase.score)
+
+			err := r.Submit(testCase.ctx, testCase.id, testCase.score, _createdAt)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "not_pending":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			case "permission_denied":
+				assert.Equal(t, errs.ErrForbidden, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPoolAnswerRepo_CountByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewPoolAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		form_id       string
+		mockBehavior  mockBehavior
+		expectedCount int
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"count"}).AddRow(7).ToPgxRows()
+				pgxRows.Next()
+				idint, _ := strconv.Atoi(form_id)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT COUNT(*) FROM pool_answer_ WHERE form_id_ = $1 AND pending_ = $2", idint, false).Return(pgxRows)
+			},
+			expectedCount: 7,
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			mockBehavior: func(ctx context.Context, form_id string) {},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				idint, _ := strconv.Atoi(form_id)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT COUNT(*) FROM pool_answer_ WHERE form_id_ = $1 AND pending_ = $2", idint, false).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			got, err := r.CountByFormId(testCase.ctx, testCase.form_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedCount, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPoolAnswerRepo_GetVersionIdsByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewPoolAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	const selectSQL = "SELECT DISTINCT version_id_ FROM pool_answer_ WHERE form_id_ = $1 AND pending_ = $2 ORDER BY version_id_"
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		mockBehavior mockBehavior
+		expectedIds  []string
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"version_id_"}).AddRow(3).AddRow(7).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(pgxRows, nil)
+			},
+			expectedIds: []string{"3", "7"},
+		},
+		{
+			nameTest: "no_answers",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"version_id_"}).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(pgxRows, nil)
+			},
+			expectedIds: []string{},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			mockBehavior: func(ctx context.Context, form_id string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			got, err := r.GetVersionIdsByFormId(testCase.ctx, testCase.form_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedIds, got)
+		})
+	}
+}
+
+func TestPoolAnswerRepo_GetDrawnByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewPoolAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	const selectSQL = "SELECT DISTINCT ON ((q->>'id')::int, q->>'header', q->>'type') q " +
+		"FROM pool_answer_ p, jsonb_array_elements(p.questions_) AS q WHERE p.form_id_ = $1 AND p.pending_ = $2 " +
+		"ORDER BY (q->>'id')::int, q->>'header', q->>'type'"
+
+	testTable := []struct {
+		nameTest          string
+		ctx               context.Context
+		form_id           string
+		mockBehavior      mockBehavior
+		expectedQuestions []*models.Question
+		expectedErr       error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"q"}).
+					AddRow([]byte(`{"id": 90, "header": "2+2", "type": "number", "options": {}, "correct": "4", "points": 1}`)).
+					AddRow([]byte(`{"id": 91, "header": "color", "type": "single_choice", "options": {"choices": ["red", "blue"]}, "rules": {}}`)).
+					ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(pgxRows, nil)
+			},
+			expectedQuestions: []*models.Question{
+				{Id: "90", Header: "2+2", Type: models.QuestionTypeNumber, Correct: "4", Points: 1},
+				{Id: "91", Header: "color", Type: models.QuestionTypeSingleChoice, Options: models.QuestionOptions{Choices: []string{"red", "blue"}}},
+			},
+		},
+		{
+			nameTest: "nothing_drawn",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"q"}).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(pgxRows, nil)
+			},
+			expectedQuestions: []*models.Question{},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			mockBehavior: func(ctx context.Context, form_id string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			got, err := r.GetDrawnByFormId(testCase.ctx, testCase.form_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedQuestions, got)
+		})
+	}
+}
+
+func TestPoolAnswerRepo_ForEachByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewPoolAnswerRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	const selectSQL = "SELECT p.id_, p.user_id_, p.version_id_, p.single_, p.score_, p.pending_, p.deadline_, p.questions_, p.submitted_at_, p.created_at_, " +
+		"COALESCE(jsonb_object_agg(a.question_id_, a.value_) FILTER (WHERE a.id_ IS NOT NULL), '{}') " +
+		"FROM pool_answer_ p LEFT JOIN answer_ a ON a.pool_answer_id_ = p.id_ WHERE p.form_id_ = $1 AND p.pending_ = $2 GROUP BY p.id_ ORDER BY p.id_"
+
+	columns := []string{"id_", "user_id_", "version_id_", "single_", "score_", "pending_", "deadline_", "questions_", "submitted_at_", "created_at_", "values_"}
+
+	testTable := []struct {
+		nameTest       string
+		ctx            context.Context
+		form_id        string
+		fnErr          error
+		mockBehavior   mockBehavior
+		expectedPA     []*models.PoolAnswer
+		expectedValues []map[string]string
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows(columns).
+					AddRow(1, intRef(3), 7, false, nil, false, nil, []byte(`[]`), nil, _createdAt, []byte(`{"34": "a", "35": "[\"b\"]", "90": "4"}`)).
+					AddRow(2, nil, 7, false, nil, false, nil, []byte(`[{"id": 90, "header": "2+2", "type": "number", "options": {}}]`), nil, _createdAt, []byte(`{}`)).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(pgxRows, nil)
+			},
+			expectedPA: []*models.PoolAnswer{
+				{Id: "1", User_id: "3", Form_id: "12", Version_id: "7", Created_at: _createdAt},
+				{Id: "2", Form_id: "12", Version_id: "7", Created_at: _createdAt, Questions: []*models.Question{
+					{Id: "90", Header: "2+2", Type: models.QuestionTypeNumber},
+				}},
+			},
+			expectedValues: []map[string]string{
+				{"34": "a", "35": `["b"]`, "90": "4"},
+				{},
+			},
+		},
+		{
+			nameTest: "fn_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			fnErr:    errors.New("write_error"),
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows(columns).
+					AddRow(1, intRef(3), 7, false, nil, false, nil, []byte(`[]`), nil, _createdAt, []byte(`{}`)).
+					AddRow(2, intRef(4), 7, false, nil, false, nil, []byte(`[]`), nil, _createdAt, []byte(`{}`)).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(pgxRows, nil)
+			},
+			expectedPA: []*models.PoolAnswer{
+				{Id: "1", User_id: "3", Form_id: "12", Version_id: "7", Created_at: _createdAt},
+			},
+			expectedValues: []map[string]string{{}},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			mockBehavior: func(ctx context.Context, form_id string) {},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			var (
+				gotPA     []*models.PoolAnswer
+				gotValues []map[string]string
+			)
+
+			err := r.ForEachByFormId(testCase.ctx, testCase.form_id, func(pool_answer *models.PoolAnswer, values map[string]string) error {
+				gotPA = append(gotPA, pool_answer)
+				gotValues = append(gotValues, values)
+				return testCase.fnErr
+			})
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedPA, gotPA)
+				assert.Equal(t, testCase.expectedValues, gotValues)
+			case "fn_error":
+				assert.Equal(t, testCase.fnErr, err)
+				assert.Equal(t, testCase.expectedPA, gotPA)
+				assert.Equal(t, testCase.expectedValues, gotValues)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func intRef(i int) *int {
+	return &i
+}
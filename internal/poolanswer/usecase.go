@@ -7,18 +7,88 @@ import (
 )
 
 type UseCase interface {
+	// Pins pool answer to latest published form version.
+	// Pool answer of guest has empty user_id, token is a secret of form link.
+	// Pool answer of quiz is scored.
+	// Closes form, if it gets max responses.
 	// Returns created model & nil, if created.
+	// Returns nil & ErrContentNotFound, if no such form or form is not published.
+	// Returns nil & ErrUnauthorized, if guest answers form accessible to authenticated users only.
+	// Returns nil & ErrFormNotOpen, if form is not open, out of its answering period or has max responses.
+	// Returns nil & ErrAttemptRequired, if form is timed or draws bank questions.
+	// Returns nil & ErrAlreadyAnswered, if form takes one response per user and user already answered.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
-	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & AnswersErr, if answers are missing, duplicated,
+	// refer to questions not shown in version or have values invalid for question type.
+	// Returns nil & ErrForbidden, if token does not match form link or permission denied.
 	// Returns nil & other err else.
-	Create(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) (*models.PoolAnswer, []*models.Answer, error)
+	Create(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer, token string) (*models.PoolAnswer, []*models.Answer, error)
+
+	// Starts attempt of user to answer timed form or form with draws: pending pool answer pinned to latest published version
+	// with bank questions drawn by form draws and, if form is timed, deadline set by form time limit from now,
+	// never after form closes.
+	// Pending attempt of user, which is not expired, is returned instead of new one, expired one is deleted.
+	// Returns started or pending attempt & nil, if get.
+	// Returns nil & ErrContentNotFound, if no such form or form is not published.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrInvalidContent, if invalid inputs or form neither is timed nor draws bank questions.
+	// Returns nil & ErrFormNotOpen, if form is not open or out of its answering period.
+	// Returns nil & ErrAlreadyAnswered, if form takes one response per user and user already started it.
+	// Returns nil & ErrForbidden, if token does not match form link or permission denied.
+	// Returns nil & other err else.
+	Start(ctx context.Context, pool_answer *models.PoolAnswer, token string) (*models.PoolAnswer, error)
+
+	// Submits answers of pending attempt, validated against version and bank questions shown on its start.
+	// Attempt of quiz is scored. Closes form, if it gets max responses.
+	// Returns submitted attempt, created answers & nil, if submitted.
+	// Returns nil & ErrContentNotFound, if no such attempt.
+	// Returns nil & ErrInvalidContent, if invalid inputs or attempt is of other form.
+	// Returns nil & ErrForbidden, if user is not an author of attempt or permission denied.
+	// Returns nil & ErrAlreadyAnswered, if attempt is already submitted.
+	// Returns nil & ErrDeadlineExceeded, if attempt deadline is over.
+	// Returns nil & ErrFormNotOpen, if form is not open or has max responses.
+	// Returns nil & AnswersErr, if answers are missing, duplicated,
+	// refer to questions not shown in version or have values invalid for question type.
+	// Returns nil & other err else.
+	Submit(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) (*models.PoolAnswer, []*models.Answer, error)
+
+	// Replaces answers of pool answer, validated against answered form version and questions drawn into it.
+	// Scored pool answer is regraded.
+	// Returns found pool answer, new answers & nil, if updated.
+	// Returns nil & ErrContentNotFound, if no such pool answer.
+	// Returns nil & ErrInvalidContent, if invalid inputs or pool answer is of other form.
+	// Returns nil & ErrForbidden, if user is not an author of pool answer or permission denied.
+	// Returns nil & ErrNotEditable, if form is not accepting answers, edit window is over
+	// or pool answer is an attempt, which is pending or past its deadline.
+	// Returns nil & AnswersErr, if answers are missing, duplicated,
+	// refer to questions not shown in version or have values invalid for question type.
+	// Returns nil & other err else.
+	Update(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) (*models.PoolAnswer, []*models.Answer, error)
+
+	// Deletes pool answer with its answers.
+	// Form owner, members with owner role, moderators and admins delete pool answers of others regardless of edit window.
+	// Returns nil, if deleted.
+	// Returns ErrContentNotFound, if no such pool answer.
+	// Returns ErrInvalidContent, if invalid inputs or pool answer is of other form.
+	// Returns ErrForbidden, if user is neither an author of pool answer nor form owner, member with owner role, moderator or admin
+	// or permission denied.
+	// Returns ErrNotEditable, if form is not accepting answers, edit window is over
+	// or pool answer is an attempt, which is pending or past its deadline.
+	// Returns other errors else.
+	Delete(ctx context.Context, pool_answer *models.PoolAnswer) error
+
+	// Returns slice of user pool answers across forms & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.PoolAnswer, error)
 
 	// Returns created model & nil, if created.
 	// Returns empty slice & nil, if get nothing.
 	// Returns nil & ErrContentNotFound, if no such form.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & ErrUnauthorized, if user unauthorized.
-	// Returns nil & ErrForbidden, if user is not form owner.
+	// Returns nil & ErrForbidden, if user is neither form owner or member nor moderator or admin.
 	// Returns nil & other err else.
 	GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.PoolAnswer, error)
 
@@ -27,4 +97,26 @@ type UseCase interface {
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & other err else.
 	GetById(ctx context.Context, id string) (*models.PoolAnswer, error)
+
+	// Grades answers of scored pool answer over questions shown for them.
+	// Author sees correct answers only as allowed by form, form owner, members, moderators and admins see them always.
+	// Returns result & nil, if get.
+	// Returns nil & ErrContentNotFound, if no such pool answer or it is not scored.
+	// Returns nil & ErrInvalidContent, if invalid inputs or pool answer is of other form.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither an author of pool answer nor form owner, member, moderator or admin.
+	// Returns nil & other err else.
+	GetResult(ctx context.Context, pool_answer *models.PoolAnswer) (*models.QuizResult, error)
+
+	// Writes header and one row per form pool answer with one column per question of latest
+	// and answered versions and per bank question drawn into pool answers. Question reworded
+	// or retyped between versions gets column per wording, answers are written by question id
+	// under the question as it was shown. Multiple choice values are joined by "; ".
+	// Returns nil, if exported.
+	// Returns ErrContentNotFound, if no such form or form is not published.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if user is neither form owner or member nor moderator or admin.
+	// Returns write err or other err else.
+	Export(ctx context.Context, form_id string, write func(row []string) error) error
 }
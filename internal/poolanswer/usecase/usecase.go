@@ -2,49 +2,367 @@ package usecase
 
 import (
 	"context"
+	"encoding/json"
 	"quizapp/internal/answer"
+	"quizapp/internal/authz"
+	"quizapp/internal/bank"
 	"quizapp/internal/form"
 	"quizapp/internal/poolanswer"
+	"quizapp/internal/version"
 	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/transactor"
 	"quizapp/pkg/types"
+	"strings"
+	"time"
 )
 
 type poolAnswerUseCase struct {
 	poolAnswerRepo poolanswer.Repo
 	answerRepo     answer.Repo
 	formRepo       form.Repo
+	versionRepo    version.Repo
+	bankRepo       bank.Repo
+	authorizer     authz.Authorizer
+	transactor     transactor.Transactor
 }
 
-func NewPoolAnswerUseCase(poolAnswerRepo poolanswer.Repo, answerRepo answer.Repo, formRepo form.Repo) poolanswer.UseCase {
+func NewPoolAnswerUseCase(poolAnswerRepo poolanswer.Repo, answerRepo answer.Repo, formRepo form.Repo, versionRepo version.Repo, bankRepo bank.Repo, authorizer authz.Authorizer, transactor transactor.Transactor) poolanswer.UseCase {
 	return &poolAnswerUseCase{
 		poolAnswerRepo: poolAnswerRepo,
 		answerRepo:     answerRepo,
 		formRepo:       formRepo,
+		versionRepo:    versionRepo,
+		bankRepo:       bankRepo,
+		authorizer:     authorizer,
+		transactor:     transactor,
 	}
 }
 
-func (pauc *poolAnswerUseCase) Create(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) (*models.PoolAnswer, []*models.Answer, error) {
-	createdpoolanswer, err := pauc.poolAnswerRepo.Create(ctx, pool_answer)
+func (pauc *poolAnswerUseCase) Create(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer, token string) (*models.PoolAnswer, []*models.Answer, error) {
+	foundform, err := pauc.formRepo.GetById(ctx, pool_answer.Form_id)
 	if err != nil {
 		return nil, nil, err
 	}
 
-	var i int
-	for ; i < len(answers) && err == nil; i++ {
-		answers[i].Pool_answer_id = createdpoolanswer.Id
-		answers[i], err = pauc.answerRepo.Create(ctx, answers[i])
+	if !foundform.IsAccessible(pool_answer.User_id, token) {
+		if pool_answer.User_id == "" {
+			return nil, nil, errs.ErrUnauthorized
+		}
+
+		return nil, nil, errs.ErrForbidden
+	}
+
+	// answers to timed form or form with draws are submitted within attempt
+	if foundform.UsesAttempts() {
+		return nil, nil, errs.ErrAttemptRequired
+	}
+
+	now := time.Now()
+
+	if !foundform.IsAcceptingAnswers(now) {
+		return nil, nil, errs.ErrFormNotOpen
+	}
+
+	// answers are accepted for latest published version only
+	latest, err := pauc.versionRepo.GetLatestByFormId(ctx, pool_answer.Form_id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = validateAnswers(latest, answers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pool_answer.Version_id = latest.Id
+	pool_answer.Single = foundform.One_response
+	pool_answer.Submitted_at = &now
+
+	// pool answers of quiz are scored once answered, mode changes do not regrade them
+	if foundform.Is_quiz {
+		score := latest.Grade(answerValues(answers)).Score
+		pool_answer.Score = &score
+	}
+
+	var (
+		createdpoolanswer *models.PoolAnswer
+		createdanswers    []*models.Answer
+	)
+
+	err = pauc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		count, err := pauc.reserveResponse(ctx, foundform)
+		if err != nil {
+			return err
+		}
+
+		createdpoolanswer, err = pauc.poolAnswerRepo.Create(ctx, pool_answer)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range answers {
+			a.Pool_answer_id = createdpoolanswer.Id
+		}
+
+		createdanswers, err = pauc.answerRepo.CreateBatch(ctx, answers)
+		if err != nil {
+			return err
+		}
+
+		return pauc.closeIfFull(ctx, foundform, count)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return createdpoolanswer, createdanswers, nil
+}
+
+func (pauc *poolAnswerUseCase) Start(ctx context.Context, pool_answer *models.PoolAnswer, token string) (*models.PoolAnswer, error) {
+	foundform, err := pauc.formRepo.GetById(ctx, pool_answer.Form_id)
+	if err != nil {
+		return nil, err
+	}
+
+	// attempt is resumed and submitted by its author, so guests can not start it
+	if pool_answer.User_id == "" {
+		return nil, errs.ErrUnauthorized
+	}
+
+	if !foundform.IsAccessible(pool_answer.User_id, token) {
+		return nil, errs.ErrForbidden
+	}
+
+	if !foundform.UsesAttempts() {
+		return nil, errs.ErrInvalidContent
+	}
+
+	now := time.Now()
+
+	if !foundform.IsAcceptingAnswers(now) {
+		return nil, errs.ErrFormNotOpen
+	}
+
+	pending, err := pauc.poolAnswerRepo.GetPending(ctx, foundform.Id, pool_answer.User_id)
+	switch {
+	case err == nil && !pending.IsExpired(now):
+		return pending, nil
+	case err == errs.ErrContentNotFound:
+		pending = nil
+	case err != nil:
+		return nil, err
+	}
+
+	latest, err := pauc.versionRepo.GetLatestByFormId(ctx, foundform.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	drawn, err := pauc.drawQuestions(ctx, foundform)
+	if err != nil {
+		return nil, err
+	}
+
+	pool_answer.Version_id = latest.Id
+	pool_answer.Single = foundform.One_response
+	pool_answer.Pending = true
+	pool_answer.Questions = drawn
+
+	if foundform.IsTimed() {
+		deadline := foundform.AttemptDeadline(now)
+		pool_answer.Deadline = &deadline
+	}
+
+	var createdpoolanswer *models.PoolAnswer
+	err = pauc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		// expired attempt can not be submitted anymore, so it is dropped to let user start again
+		// without conflict with one response per user
+		if pending != nil {
+			err := pauc.poolAnswerRepo.Delete(ctx, pending.Id)
+			if err != nil && err != errs.ErrContentNotFound {
+				return err
+			}
+		}
+
+		createdpoolanswer, err = pauc.poolAnswerRepo.Create(ctx, pool_answer)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdpoolanswer, nil
+}
+
+func (pauc *poolAnswerUseCase) Submit(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) (*models.PoolAnswer, []*models.Answer, error) {
+	foundpa, err := pauc.poolAnswerRepo.GetById(ctx, pool_answer.Id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if foundpa.Form_id != pool_answer.Form_id {
+		return nil, nil, errs.ErrInvalidContent
 	}
 
+	if foundpa.User_id == "" || foundpa.User_id != pool_answer.User_id {
+		return nil, nil, errs.ErrForbidden
+	}
+
+	if !foundpa.Pending {
+		return nil, nil, errs.ErrAlreadyAnswered
+	}
+
+	now := time.Now()
+
+	if foundpa.IsExpired(now) {
+		return nil, nil, errs.ErrDeadlineExceeded
+	}
+
+	foundform, err := pauc.formRepo.GetById(ctx, foundpa.Form_id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// deadline is never after form closes, so answering period is kept by it
+	if foundform.Status != models.FormStatusOpen {
+		return nil, nil, errs.ErrFormNotOpen
+	}
+
+	// attempt is answered to version and bank questions shown on its start
+	foundversion, err := pauc.versionRepo.GetById(ctx, foundpa.Version_id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	shown := foundversion.WithDrawn(foundpa.Questions)
+
+	err = validateAnswers(shown, answers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	if foundform.Is_quiz {
+		score := shown.Grade(answerValues(answers)).Score
+		foundpa.Score = &score
+	}
+
+	var submittedanswers []*models.Answer
+
+	err = pauc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		count, err := pauc.reserveResponse(ctx, foundform)
+		if err != nil {
+			return err
+		}
+
+		err = pauc.poolAnswerRepo.Submit(ctx, foundpa.Id, foundpa.Score, now)
+		if err != nil {
+			// attempt was submitted concurrently
+			if err == errs.ErrContentNotFound {
+				return errs.ErrAlreadyAnswered
+			}
+
+			return err
+		}
+
+		for _, a := range answers {
+			a.Pool_answer_id = foundpa.Id
+		}
+
+		submittedanswers, err = pauc.answerRepo.CreateBatch(ctx, answers)
+		if err != nil {
+			return err
+		}
+
+		return pauc.closeIfFull(ctx, foundform, count)
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+
+	foundpa.Pending = false
+	foundpa.Submitted_at = &now
+
+	return foundpa, submittedanswers, nil
+}
+
+func (pauc *poolAnswerUseCase) Update(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) (*models.PoolAnswer, []*models.Answer, error) {
+	foundpa, err := pauc.getEditable(ctx, pool_answer)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// edited answers stay pinned to answered version and drawn questions
+	foundversion, err := pauc.versionRepo.GetById(ctx, foundpa.Version_id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	answered := foundversion.WithDrawn(foundpa.Questions)
+
+	err = validateAnswers(answered, answers)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var updatedanswers []*models.Answer
+
+	err = pauc.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		_, err = pauc.answerRepo.DeleteByPoolAnswerId(ctx, foundpa.Id)
+		if err != nil {
+			return err
+		}
+
+		for _, a := range answers {
+			a.Pool_answer_id = foundpa.Id
+		}
+
+		updatedanswers, err = pauc.answerRepo.CreateBatch(ctx, answers)
+		if err != nil {
+			return err
+		}
+
+		// scored pool answer is regraded with new answers
+		if foundpa.Score != nil {
+			score := answered.Grade(answerValues(answers)).Score
+			foundpa.Score = &score
+
+			return pauc.poolAnswerRepo.UpdateScore(ctx, foundpa.Id, foundpa.Score)
+		}
+
+		return nil
+	})
 	if err != nil {
-		pauc.poolAnswerRepo.Delete(ctx, createdpoolanswer.Id)
 		return nil, nil, err
 	}
 
-	return createdpoolanswer, answers, err
+	return foundpa, updatedanswers, nil
+}
+
+func (pauc *poolAnswerUseCase) Delete(ctx context.Context, pool_answer *models.PoolAnswer) error {
+	foundpa, err := pauc.getEditable(ctx, pool_answer)
+	if err == errs.ErrForbidden {
+		// pool answer of someone else is removed by moderation regardless of edit window
+		err = pauc.authorizer.AuthorizeForm(ctx, pool_answer.Form_id, models.ActionResponseModerate)
+		if err != nil {
+			return err
+		}
+
+		return pauc.poolAnswerRepo.Delete(ctx, pool_answer.Id)
+	}
+	if err != nil {
+		return err
+	}
+
+	return pauc.poolAnswerRepo.Delete(ctx, foundpa.Id)
+}
+
+func (pauc *poolAnswerUseCase) GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.PoolAnswer, error) {
+	return pauc.poolAnswerRepo.GetByUserId(ctx, user_id, sets)
 }
 
 func (pauc *poolAnswerUseCase) GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.PoolAnswer, error) {
-	err := pauc.formRepo.ValidateIsOwner(ctx, form_id)
+	err := pauc.authorizer.AuthorizeForm(ctx, form_id, models.ActionResponseView)
 	if err != nil {
 		return nil, err
 	}
@@ -55,3 +373,346 @@ func (pauc *poolAnswerUseCase) GetByFormId(ctx context.Context, form_id string,
 func (pauc *poolAnswerUseCase) GetById(ctx context.Context, id string) (*models.PoolAnswer, error) {
 	return pauc.poolAnswerRepo.GetById(ctx, id)
 }
+
+func (pauc *poolAnswerUseCase) GetResult(ctx context.Context, pool_answer *models.PoolAnswer) (*models.QuizResult, error) {
+	foundpa, err := pauc.poolAnswerRepo.GetById(ctx, pool_answer.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if foundpa.Form_id != pool_answer.Form_id {
+		return nil, errs.ErrInvalidContent
+	}
+
+	// pool answers created while form was not a quiz have no result
+	if foundpa.Score == nil {
+		return nil, errs.ErrContentNotFound
+	}
+
+	foundform, err := pauc.formRepo.GetById(ctx, foundpa.Form_id)
+	if err != nil {
+		return nil, err
+	}
+
+	// guest pool answers have no author
+	author := foundpa.User_id != "" && foundpa.User_id == pool_answer.User_id
+	if !author {
+		err = pauc.authorizer.AuthorizeForm(ctx, foundpa.Form_id, models.ActionResponseView)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	foundversion, err := pauc.versionRepo.GetById(ctx, foundpa.Version_id)
+	if err != nil {
+		return nil, err
+	}
+
+	// drawn questions are graded as part of answered version
+	answered := foundversion.WithDrawn(foundpa.Questions)
+
+	// pool answer has at most one answer per shown question
+	answers, err := pauc.answerRepo.GetByPoolAnswerId(ctx, foundpa.Id, types.GetSets{Limit: uint64(len(answered.Questions))})
+	if err != nil {
+		return nil, err
+	}
+
+	result := answered.Grade(answerValues(answers))
+	result.Pool_answer = foundpa
+
+	if author && !foundform.ShowsCorrect(time.Now()) {
+		result.HideCorrect()
+	}
+
+	return result, nil
+}
+
+func (pauc *poolAnswerUseCase) Export(ctx context.Context, form_id string, write func(row []string) error) error {
+	err := pauc.authorizer.AuthorizeForm(ctx, form_id, models.ActionResponseView)
+	if err != nil {
+		return err
+	}
+
+	latest, err := pauc.versionRepo.GetLatestByFormId(ctx, form_id)
+	if err != nil {
+		return err
+	}
+
+	versionids, err := pauc.poolAnswerRepo.GetVersionIdsByFormId(ctx, form_id)
+	if err != nil {
+		return err
+	}
+
+	// columns of latest version go first, then ones of older versions from newer to older
+	versions := map[string]*models.FormVersion{latest.Id: latest}
+	columns := &exportColumns{index: make(map[exportColumn]int)}
+	columns.addVersion(latest)
+
+	for i := len(versionids) - 1; i >= 0; i-- {
+		if _, ok := versions[versionids[i]]; ok {
+			continue
+		}
+
+		foundversion, err := pauc.versionRepo.GetById(ctx, versionids[i])
+		if err != nil {
+			return err
+		}
+
+		versions[foundversion.Id] = foundversion
+		columns.addVersion(foundversion)
+	}
+
+	drawn, err := pauc.poolAnswerRepo.GetDrawnByFormId(ctx, form_id)
+	if err != nil {
+		return err
+	}
+
+	for _, q := range drawn {
+		columns.add(true, q)
+	}
+
+	meta := []string{"id", "user_id", "version_id", "created_at"}
+
+	err = write(append(meta, columns.headers...))
+	if err != nil {
+		return err
+	}
+
+	return pauc.poolAnswerRepo.ForEachByFormId(ctx, form_id, func(pool_answer *models.PoolAnswer, values map[string]string) error {
+		row := make([]string, len(meta)+len(columns.headers))
+		row[0] = pool_answer.Id
+		row[1] = pool_answer.User_id
+		row[2] = pool_answer.Version_id
+		row[3] = pool_answer.Created_at.Format(time.RFC3339)
+
+		fill := func(drawn bool, q *models.Question) {
+			if i, ok := columns.index[newExportColumn(drawn, q)]; ok {
+				row[len(meta)+i] = exportValue(q, values[q.Id])
+			}
+		}
+
+		// version published after export started has no columns
+		if answered, ok := versions[pool_answer.Version_id]; ok {
+			for _, q := range answered.Questions {
+				fill(false, q)
+			}
+		}
+
+		for _, q := range pool_answer.Questions {
+			fill(true, q)
+		}
+
+		return write(row)
+	})
+}
+
+// Column of answers export. Questions of versions and drawn bank questions are told apart,
+// as well as question wordings and types of different versions, so every answer
+// is written under the question as it was shown.
+type exportColumn struct {
+	drawn            bool
+	id, header, kind string
+}
+
+func newExportColumn(drawn bool, q *models.Question) exportColumn {
+	return exportColumn{drawn: drawn, id: q.Id, header: q.Header, kind: q.Type}
+}
+
+type exportColumns struct {
+	headers []string
+	index   map[exportColumn]int
+}
+
+func (c *exportColumns) add(drawn bool, q *models.Question) {
+	column := newExportColumn(drawn, q)
+	if _, ok := c.index[column]; ok {
+		return
+	}
+
+	c.index[column] = len(c.headers)
+	c.headers = append(c.headers, q.Header)
+}
+
+func (c *exportColumns) addVersion(v *models.FormVersion) {
+	for _, q := range v.Questions {
+		c.add(false, q)
+	}
+}
+
+// Returns found pool answer & nil, if it is of pool_answer form and user and form edit policy allows to change it now.
+// Returns nil & ErrInvalidContent, ErrForbidden, ErrNotEditable or repo err else.
+func (pauc *poolAnswerUseCase) getEditable(ctx context.Context, pool_answer *models.PoolAnswer) (*models.PoolAnswer, error) {
+	foundpa, err := pauc.poolAnswerRepo.GetById(ctx, pool_answer.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if foundpa.Form_id != pool_answer.Form_id {
+		return nil, errs.ErrInvalidContent
+	}
+
+	// guest pool answers have no author
+	if foundpa.User_id == "" || foundpa.User_id != pool_answer.User_id {
+		return nil, errs.ErrForbidden
+	}
+
+	foundform, err := pauc.formRepo.GetById(ctx, foundpa.Form_id)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	// answers of attempt are not changed past its deadline, edit window runs from submission
+	if foundpa.Pending || foundpa.Submitted_at == nil || foundpa.IsExpired(now) || !foundform.IsEditable(*foundpa.Submitted_at, now) {
+		return nil, errs.ErrNotEditable
+	}
+
+	return foundpa, nil
+}
+
+// Returns bank questions of form owner drawn by form draws, each question is drawn once,
+// in random order with shuffled choices & nil.
+// Returns nil & repo err else.
+func (pauc *poolAnswerUseCase) drawQuestions(ctx context.Context, foundform *models.Form) ([]*models.Question, error) {
+	if len(foundform.Draws) == 0 {
+		return nil, nil
+	}
+
+	res := make([]*models.Question, 0)
+	drawnids := make([]string, 0)
+
+	for _, d := range foundform.Draws {
+		// small bank gives fewer questions than count
+		found, err := pauc.bankRepo.GetRandom(ctx, foundform.User_id, d.Tag, d.Count, drawnids)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, q := range found {
+			res = append(res, q.ToQuestion())
+			drawnids = append(drawnids, q.Id)
+		}
+	}
+
+	models.ShuffleQuestions(res)
+
+	return res, nil
+}
+
+// Locks form and counts its pool answers, if form takes max responses.
+// Returns number of pool answers & nil, if one more is accepted.
+// Returns 0 & ErrFormNotOpen, if form has max responses, or repo err else.
+func (pauc *poolAnswerUseCase) reserveResponse(ctx context.Context, foundform *models.Form) (int, error) {
+	if foundform.Max_responses == 0 {
+		return 0, nil
+	}
+
+	// form lock serializes submissions, so quota can not be overrun
+	err := pauc.formRepo.LockById(ctx, foundform.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	count, err := pauc.poolAnswerRepo.CountByFormId(ctx, foundform.Id)
+	if err != nil {
+		return 0, err
+	}
+
+	if count >= foundform.Max_responses {
+		return 0, errs.ErrFormNotOpen
+	}
+
+	return count, nil
+}
+
+// Closes form, if pool answer added to count of reserveResponse gets it max responses.
+func (pauc *poolAnswerUseCase) closeIfFull(ctx context.Context, foundform *models.Form, count int) error {
+	if foundform.Max_responses > 0 && count+1 >= foundform.Max_responses {
+		return pauc.formRepo.UpdateStatus(ctx, foundform.Id, models.FormStatusClosed)
+	}
+
+	return nil
+}
+
+// Returns nil, if every answer refers to shown version question once, has valid value following question rules
+// and every shown required question is answered.
+// Returns AnswersErr, listing all rejected answers, else.
+func validateAnswers(shown *models.FormVersion, answers []*models.Answer) error {
+	res := new(errs.AnswersErr)
+
+	if len(answers) == 0 {
+		res.Add("", errs.ReasonNoAnswers)
+		return res
+	}
+
+	formquestions := make(map[string]*models.Question, len(shown.Questions))
+	for _, q := range shown.Questions {
+		formquestions[q.Id] = q
+	}
+
+	answered := make(map[string]bool, len(answers))
+	// valid values drive display conditions
+	values := make(map[string]string, len(answers))
+
+	for _, a := range answers {
+		q, ok := formquestions[a.Question_id]
+		switch {
+		case !ok:
+			res.Add(a.Question_id, errs.ReasonUnknownQuestion)
+		case answered[a.Question_id]:
+			res.Add(a.Question_id, errs.ReasonDuplicateAnswer)
+		case !q.ValidateValue(a.Value):
+			res.Add(a.Question_id, errs.ReasonInvalidValue)
+		default:
+			if rule := q.BrokenRule(a.Value); rule != "" {
+				res.AddRule(a.Question_id, rule)
+			} else {
+				values[a.Question_id] = a.Value
+			}
+		}
+
+		answered[a.Question_id] = true
+	}
+
+	visible := shown.VisibleQuestions(values)
+	for _, a := range answers {
+		if _, ok := values[a.Question_id]; ok && !visible[a.Question_id] {
+			res.Add(a.Question_id, errs.ReasonHiddenQuestion)
+		}
+	}
+
+	// hidden required questions are not demanded
+	for _, q := range shown.Questions {
+		if q.Required && visible[q.Id] && !answered[q.Id] {
+			res.Add(q.Id, errs.ReasonRequired)
+		}
+	}
+
+	return res.OrNil()
+}
+
+// Returns answer values by question id.
+func answerValues(answers []*models.Answer) map[string]string {
+	res := make(map[string]string, len(answers))
+
+	for _, a := range answers {
+		res[a.Question_id] = a.Value
+	}
+
+	return res
+}
+
+// Returns value as shown in export, multiple choice JSON array is joined.
+func exportValue(q *models.Question, value string) string {
+	if q.Type != models.QuestionTypeMultipleChoice || value == "" {
+		return value
+	}
+
+	var choices []string
+	if json.Unmarshal([]byte(value), &choices) != nil {
+		return value
+	}
+
+	return strings.Join(choices, "; ")
+}
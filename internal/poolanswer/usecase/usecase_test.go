@@ -3,15 +3,23 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	"fmt"
 	"quizapp/internal/poolanswer/usecase"
 	"quizapp/models"
+	"quizapp/pkg/errs"
 	"quizapp/pkg/types"
+	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	mocka "quizapp/internal/answer/mock"
+	mockauthz "quizapp/internal/authz/mock"
+	mockb "quizapp/internal/bank/mock"
 	mockf "quizapp/internal/form/mock"
 	mockpa "quizapp/internal/poolanswer/mock"
+	mockv "quizapp/internal/version/mock"
+	mocktx "quizapp/pkg/transactor/mock"
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
@@ -25,8 +33,12 @@ func TestPoolAnswerUseCase_Create(t *testing.T) {
 	mockRepoF := mockf.NewMockRepo(ctrl)
 	mockRepoA := mocka.NewMockRepo(ctrl)
 	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF)
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
 
 	type mockBehavior func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer)
 
@@ -35,9 +47,11 @@ func TestPoolAnswerUseCase_Create(t *testing.T) {
 		ctx             context.Context
 		pool_answer     models.PoolAnswer
 		answers         []*models.Answer
+		token           string
 		mockBehavior    mockBehavior
 		expectedPA      models.PoolAnswer
 		expectedAnswers []*models.Answer
+		expectedErr     error
 	}{
 		{
 			nameTest: "ok",
@@ -57,25 +71,36 @@ func TestPoolAnswerUseCase_Create(t *testing.T) {
 				},
 			},
 			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				expectVersion(ctx, mockRepoV, pool_answer.Form_id, answers)
 				pa := models.PoolAnswer{
-					Id:      "10",
-					Form_id: pool_answer.Form_id,
-					User_id: pool_answer.User_id,
+					Id:         "10",
+					Form_id:    pool_answer.Form_id,
+					Version_id: "2",
+					User_id:    pool_answer.User_id,
 				}
-				mockRepoPA.EXPECT().Create(ctx, pool_answer).Return(&pa, nil)
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Create(ctx, submitted(&models.PoolAnswer{
+					Form_id:    pool_answer.Form_id,
+					Version_id: "2",
+					User_id:    pool_answer.User_id,
+				})).Return(&pa, nil)
+				created := make([]*models.Answer, len(answers))
 				for i, answer := range answers {
-					mockRepoA.EXPECT().Create(ctx, answer).Return(&models.Answer{
+					created[i] = &models.Answer{
 						Id:             strconv.Itoa(i),
 						Pool_answer_id: pa.Id,
 						Question_id:    answer.Question_id,
 						Value:          answer.Value,
-					}, nil)
+					}
 				}
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return(created, nil)
 			},
 			expectedPA: models.PoolAnswer{
-				Id:      "10",
-				Form_id: "3",
-				User_id: "4",
+				Id:         "10",
+				Form_id:    "3",
+				Version_id: "2",
+				User_id:    "4",
 			},
 			expectedAnswers: []*models.Answer{
 				{
@@ -93,68 +118,1683 @@ func TestPoolAnswerUseCase_Create(t *testing.T) {
 			},
 		},
 		{
-			nameTest: "repoPA_create_error",
+			nameTest: "repoPA_create_error",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+				{
+					Question_id: "8",
+					Value:       "ans2",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				expectVersion(ctx, mockRepoV, pool_answer.Form_id, answers)
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Create(ctx, pool_answer).Return(nil, errors.New("repoPA_create_error"))
+			},
+		},
+		{
+			nameTest: "repoA_create_error",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+				{
+					Question_id: "8",
+					Value:       "ans2",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				expectVersion(ctx, mockRepoV, pool_answer.Form_id, answers)
+				pa := models.PoolAnswer{
+					Id:      "10",
+					Form_id: pool_answer.Form_id,
+					User_id: pool_answer.User_id,
+				}
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Create(ctx, pool_answer).Return(&pa, nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return(nil, errors.New("repoA_create_error"))
+			},
+		},
+		{
+			nameTest: "value_invalid_for_type",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "not a number",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				min, max := 1.0, 5.0
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, pool_answer.Form_id).Return(&models.FormVersion{
+					Id:      "2",
+					Form_id: pool_answer.Form_id,
+					Questions: []*models.Question{
+						{
+							Id:      "7",
+							Form_id: pool_answer.Form_id,
+							Header:  "rate",
+							Type:    models.QuestionTypeScale,
+							Options: models.QuestionOptions{
+								Min: &min,
+								Max: &max,
+							},
+						},
+					},
+				}, nil)
+			},
+			expectedErr: &errs.AnswersErr{
+				Errs: []*errs.AnswerErr{
+					{Question_id: "7", Reason: errs.ReasonInvalidValue},
+				},
+			},
+		},
+		{
+			nameTest: "question_of_other_form",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+				{
+					Question_id: "100",
+					Value:       "ans2",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				expectVersion(ctx, mockRepoV, pool_answer.Form_id, answers[:1])
+			},
+			expectedErr: &errs.AnswersErr{
+				Errs: []*errs.AnswerErr{
+					{Question_id: "100", Reason: errs.ReasonUnknownQuestion},
+				},
+			},
+		},
+		{
+			nameTest: "ok_conditional",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "yes",
+				},
+				{
+					Question_id: "8",
+					Value:       "why",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, pool_answer.Form_id).Return(conditionalVersion(pool_answer.Form_id), nil)
+				pa := models.PoolAnswer{
+					Id:         "10",
+					Form_id:    pool_answer.Form_id,
+					Version_id: "2",
+					User_id:    pool_answer.User_id,
+				}
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Create(ctx, submitted(&models.PoolAnswer{
+					Form_id:    pool_answer.Form_id,
+					Version_id: "2",
+					User_id:    pool_answer.User_id,
+				})).Return(&pa, nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return([]*models.Answer{
+					{Id: "0", Pool_answer_id: pa.Id, Question_id: "7", Value: "yes"},
+					{Id: "1", Pool_answer_id: pa.Id, Question_id: "8", Value: "why"},
+				}, nil)
+			},
+			expectedPA: models.PoolAnswer{
+				Id:         "10",
+				Form_id:    "3",
+				Version_id: "2",
+				User_id:    "4",
+			},
+			expectedAnswers: []*models.Answer{
+				{Id: "0", Pool_answer_id: "10", Question_id: "7", Value: "yes"},
+				{Id: "1", Pool_answer_id: "10", Question_id: "8", Value: "why"},
+			},
+		},
+		{
+			nameTest: "ok_quiz",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{Question_id: "7", Value: "Yes"},
+				{Question_id: "8", Value: `["c","b"]`},
+				{Question_id: "9", Value: "because"},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:      pool_answer.Form_id,
+					Status:  models.FormStatusOpen,
+					Is_quiz: true,
+				}, nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, pool_answer.Form_id).Return(quizVersion(pool_answer.Form_id), nil)
+				pa := models.PoolAnswer{
+					Id:         "10",
+					Form_id:    pool_answer.Form_id,
+					Version_id: "2",
+					User_id:    pool_answer.User_id,
+					Score:      intRef(2),
+				}
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Create(ctx, submitted(&models.PoolAnswer{
+					Form_id:    pool_answer.Form_id,
+					Version_id: "2",
+					User_id:    pool_answer.User_id,
+					Score:      intRef(2),
+				})).Return(&pa, nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return([]*models.Answer{}, nil)
+			},
+			expectedPA: models.PoolAnswer{
+				Id:         "10",
+				Form_id:    "3",
+				Version_id: "2",
+				User_id:    "4",
+				Score:      intRef(2),
+			},
+			expectedAnswers: []*models.Answer{},
+		},
+		{
+			nameTest: "answer_to_hidden_question",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "no",
+				},
+				{
+					Question_id: "8",
+					Value:       "why",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, pool_answer.Form_id).Return(conditionalVersion(pool_answer.Form_id), nil)
+			},
+			expectedErr: &errs.AnswersErr{
+				Errs: []*errs.AnswerErr{
+					{Question_id: "8", Reason: errs.ReasonHiddenQuestion},
+				},
+			},
+		},
+		{
+			nameTest: "answer_in_hidden_section",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "9",
+					Value:       "extra",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				// hidden required question is not demanded
+				version := conditionalVersion(pool_answer.Form_id)
+				version.Questions[1].Required = true
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, pool_answer.Form_id).Return(version, nil)
+			},
+			expectedErr: &errs.AnswersErr{
+				Errs: []*errs.AnswerErr{
+					{Question_id: "9", Reason: errs.ReasonHiddenQuestion},
+				},
+			},
+		},
+		{
+			nameTest: "required_not_answered",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "yes",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				version := conditionalVersion(pool_answer.Form_id)
+				version.Questions[1].Required = true
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, pool_answer.Form_id).Return(version, nil)
+			},
+			expectedErr: &errs.AnswersErr{
+				Errs: []*errs.AnswerErr{
+					{Question_id: "8", Reason: errs.ReasonRequired},
+				},
+			},
+		},
+		{
+			nameTest: "rules_broken",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "not an email",
+				},
+				{
+					Question_id: "8",
+					Value:       "toolong",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				maxlength := 5
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, pool_answer.Form_id).Return(&models.FormVersion{
+					Id:      "2",
+					Form_id: pool_answer.Form_id,
+					Questions: []*models.Question{
+						{
+							Id:      "7",
+							Form_id: pool_answer.Form_id,
+							Type:    models.QuestionTypeText,
+							Rules:   models.QuestionRules{Format: models.RuleFormatEmail},
+						},
+						{
+							Id:      "8",
+							Form_id: pool_answer.Form_id,
+							Type:    models.QuestionTypeText,
+							Rules:   models.QuestionRules{Pattern: "[a-z]+", Max_length: &maxlength},
+						},
+					},
+				}, nil)
+			},
+			expectedErr: &errs.AnswersErr{
+				Errs: []*errs.AnswerErr{
+					{Question_id: "7", Reason: errs.ReasonRuleViolation, Rule: models.RuleFormat},
+					{Question_id: "8", Reason: errs.ReasonRuleViolation, Rule: models.RuleMaxLength},
+				},
+			},
+		},
+		{
+			nameTest: "duplicate_answers",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+				{
+					Question_id: "7",
+					Value:       "ans2",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				expectVersion(ctx, mockRepoV, pool_answer.Form_id, answers[:1])
+			},
+			expectedErr: &errs.AnswersErr{
+				Errs: []*errs.AnswerErr{
+					{Question_id: "7", Reason: errs.ReasonDuplicateAnswer},
+				},
+			},
+		},
+		{
+			nameTest: "no_answers",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				expectVersion(ctx, mockRepoV, pool_answer.Form_id, answers)
+			},
+			expectedErr: &errs.AnswersErr{
+				Errs: []*errs.AnswerErr{
+					{Reason: errs.ReasonNoAnswers},
+				},
+			},
+		},
+		{
+			nameTest: "not_published",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, pool_answer.Form_id).Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "last_response_closes_form",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:            pool_answer.Form_id,
+					Status:        models.FormStatusOpen,
+					One_response:  true,
+					Max_responses: 5,
+				}, nil)
+				expectVersion(ctx, mockRepoV, pool_answer.Form_id, answers)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, pool_answer.Form_id).Return(nil)
+				mockRepoPA.EXPECT().CountByFormId(ctx, pool_answer.Form_id).Return(4, nil)
+				mockRepoPA.EXPECT().Create(ctx, submitted(&models.PoolAnswer{
+					Form_id:    pool_answer.Form_id,
+					Version_id: "2",
+					User_id:    pool_answer.User_id,
+					Single:     true,
+				})).Return(&models.PoolAnswer{
+					Id:         "10",
+					Form_id:    pool_answer.Form_id,
+					Version_id: "2",
+					User_id:    pool_answer.User_id,
+					Single:     true,
+				}, nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return([]*models.Answer{
+					{
+						Id:             "0",
+						Pool_answer_id: "10",
+						Question_id:    "7",
+						Value:          "ans1",
+					},
+				}, nil)
+				mockRepoF.EXPECT().UpdateStatus(ctx, pool_answer.Form_id, models.FormStatusClosed).Return(nil)
+			},
+			expectedPA: models.PoolAnswer{
+				Id:         "10",
+				Form_id:    "3",
+				Version_id: "2",
+				User_id:    "4",
+				Single:     true,
+			},
+			expectedAnswers: []*models.Answer{
+				{
+					Id:             "0",
+					Pool_answer_id: "10",
+					Question_id:    "7",
+					Value:          "ans1",
+				},
+			},
+		},
+		{
+			nameTest: "max_responses_reached",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:            pool_answer.Form_id,
+					Status:        models.FormStatusOpen,
+					Max_responses: 5,
+				}, nil)
+				expectVersion(ctx, mockRepoV, pool_answer.Form_id, answers)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, pool_answer.Form_id).Return(nil)
+				mockRepoPA.EXPECT().CountByFormId(ctx, pool_answer.Form_id).Return(5, nil)
+			},
+		},
+		{
+			nameTest: "guest_on_authenticated_form",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:     pool_answer.Form_id,
+					Status: models.FormStatusOpen,
+					Access: models.FormAccessAuthenticated,
+				}, nil)
+			},
+		},
+		{
+			nameTest: "invalid_link_token",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+			},
+			token: "guess",
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:           pool_answer.Form_id,
+					Status:       models.FormStatusOpen,
+					Access:       models.FormAccessLink,
+					Access_token: "secret",
+				}, nil)
+			},
+		},
+		{
+			nameTest: "form_not_open",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:     pool_answer.Form_id,
+					Status: models.FormStatusClosed,
+				}, nil)
+			},
+		},
+		{
+			nameTest: "timed_form",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:         pool_answer.Form_id,
+					Status:     models.FormStatusOpen,
+					Time_limit: 30 * time.Minute,
+				}, nil)
+			},
+		},
+		{
+			nameTest: "drawing_form",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "ans1",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:     pool_answer.Form_id,
+					Status: models.FormStatusOpen,
+					Draws:  []*models.Draw{{Tag: "go", Count: 2}},
+				}, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.pool_answer, testCase.answers)
+
+			gotpa, gota, err := uc.Create(testCase.ctx, &testCase.pool_answer, testCase.answers, testCase.token)
+
+			switch testCase.nameTest {
+			case "ok", "last_response_closes_form", "ok_conditional", "ok_quiz":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedPA, *gotpa)
+				assert.Equal(t, testCase.expectedAnswers, gota)
+			case "repoA_create_error", "repoPA_create_error":
+				assert.NotEqual(t, nil, err)
+			case "not_published":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			case "guest_on_authenticated_form":
+				assert.Equal(t, errs.ErrUnauthorized, err)
+			case "invalid_link_token":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "form_not_open", "max_responses_reached":
+				assert.Equal(t, errs.ErrFormNotOpen, err)
+			case "timed_form", "drawing_form":
+				assert.Equal(t, errs.ErrAttemptRequired, err)
+			case "value_invalid_for_type", "question_of_other_form", "duplicate_answers", "no_answers", "answer_to_hidden_question", "answer_in_hidden_section", "required_not_answered", "rules_broken":
+				assert.Equal(t, testCase.expectedErr, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+// question 8 is shown for "yes" on question 7, section with question 9 is shown once question 7 is answered
+func conditionalVersion(form_id string) *models.FormVersion {
+	return &models.FormVersion{
+		Id:      "2",
+		Form_id: form_id,
+		Questions: []*models.Question{
+			{
+				Id:      "7",
+				Form_id: form_id,
+				Type:    models.QuestionTypeSingleChoice,
+				Options: models.QuestionOptions{
+					Choices: []string{"yes", "no"},
+				},
+			},
+			{
+				Id:        "8",
+				Form_id:   form_id,
+				Type:      models.QuestionTypeText,
+				Condition: &models.Condition{Question_id: "7", Op: models.ConditionOpEquals, Value: "yes"},
+			},
+			{
+				Id:         "9",
+				Form_id:    form_id,
+				Section_id: "1",
+				Type:       models.QuestionTypeText,
+			},
+		},
+		Sections: []*models.Section{
+			{
+				Id:        "1",
+				Form_id:   form_id,
+				Condition: &models.Condition{Question_id: "7", Op: models.ConditionOpAnswered},
+			},
+		},
+	}
+}
+
+// question 7 scores 2 for "yes", question 8 scores 3 for choices "a" and "c", question 9 is not scored
+func quizVersion(form_id string) *models.FormVersion {
+	return &models.FormVersion{
+		Id:      "2",
+		Form_id: form_id,
+		Questions: []*models.Question{
+			{
+				Id:      "7",
+				Form_id: form_id,
+				Type:    models.QuestionTypeText,
+				Correct: "yes",
+				Points:  2,
+			},
+			{
+				Id:      "8",
+				Form_id: form_id,
+				Type:    models.QuestionTypeMultipleChoice,
+				Options: models.QuestionOptions{
+					Choices: []string{"a", "b", "c"},
+				},
+				Correct: `["a","c"]`,
+				Points:  3,
+			},
+			{
+				Id:      "9",
+				Form_id: form_id,
+				Type:    models.QuestionTypeText,
+			},
+		},
+	}
+}
+
+// form is open with no answering period
+func expectOpenForm(ctx context.Context, mockRepoF *mockf.MockRepo, form_id string) {
+	mockRepoF.EXPECT().GetById(ctx, form_id).Return(&models.Form{
+		Id:     form_id,
+		Status: models.FormStatusOpen,
+	}, nil)
+}
+
+// latest version shows one text question per answer
+func expectVersion(ctx context.Context, mockRepoV *mockv.MockRepo, form_id string, answers []*models.Answer) {
+	questions := make([]*models.Question, len(answers))
+	for i, a := range answers {
+		questions[i] = &models.Question{
+			Id:      a.Question_id,
+			Form_id: form_id,
+			Header:  "header",
+			Type:    models.QuestionTypeText,
+		}
+	}
+	mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(&models.FormVersion{
+		Id:        "2",
+		Form_id:   form_id,
+		Questions: questions,
+	}, nil)
+}
+
+// runs transaction body in place
+// Matches pool answer created as submitted, time of submission is not compared
+type submittedMatcher struct {
+	want *models.PoolAnswer
+}
+
+func (m submittedMatcher) Matches(x interface{}) bool {
+	got, ok := x.(*models.PoolAnswer)
+	if !ok || got.Submitted_at == nil {
+		return false
+	}
+
+	stripped := *got
+	stripped.Submitted_at = nil
+
+	return reflect.DeepEqual(m.want, &stripped)
+}
+
+func (m submittedMatcher) String() string {
+	return fmt.Sprintf("is submitted %v", m.want)
+}
+
+func submitted(want *models.PoolAnswer) gomock.Matcher {
+	return submittedMatcher{want: want}
+}
+
+func expectTx(ctx context.Context, mockTx *mocktx.MockTransactor) {
+	mockTx.EXPECT().WithinTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+}
+
+func TestPoolAnswerUseCase_Update(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoA := mocka.NewMockRepo(ctrl)
+	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
+
+	submittedAt := time.Now()
+
+	// edit window runs from submission of pool answer, not from start of its attempt
+	foundpa := &models.PoolAnswer{
+		Id:           "10",
+		Form_id:      "3",
+		Version_id:   "2",
+		User_id:      "4",
+		Submitted_at: &submittedAt,
+		Created_at:   time.Now().Add(-2 * time.Hour),
+	}
+
+	editableform := &models.Form{
+		Id:          "3",
+		Status:      models.FormStatusOpen,
+		Edit_window: time.Hour,
+	}
+
+	type mockBehavior func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer)
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		pool_answer     models.PoolAnswer
+		answers         []*models.Answer
+		mockBehavior    mockBehavior
+		expectedAnswers []*models.Answer
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{
+					Question_id: "7",
+					Value:       "edited",
+				},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(foundpa, nil)
+				mockRepoF.EXPECT().GetById(ctx, foundpa.Form_id).Return(editableform, nil)
+				mockRepoV.EXPECT().GetById(ctx, foundpa.Version_id).Return(&models.FormVersion{
+					Id:      foundpa.Version_id,
+					Form_id: foundpa.Form_id,
+					Questions: []*models.Question{
+						{
+							Id:   "7",
+							Type: models.QuestionTypeText,
+						},
+					},
+				}, nil)
+				expectTx(ctx, mockTx)
+				mockRepoA.EXPECT().DeleteByPoolAnswerId(ctx, foundpa.Id).Return(int64(1), nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return([]*models.Answer{
+					{
+						Id:             "20",
+						Pool_answer_id: "10",
+						Question_id:    "7",
+						Value:          "edited",
+					},
+				}, nil)
+			},
+			expectedAnswers: []*models.Answer{
+				{
+					Id:             "20",
+					Pool_answer_id: "10",
+					Question_id:    "7",
+					Value:          "edited",
+				},
+			},
+		},
+		{
+			nameTest: "ok_quiz",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "11",
+				Form_id: "3",
+				User_id: "4",
+			},
+			answers: []*models.Answer{
+				{Question_id: "7", Value: "yes"},
+				{Question_id: "8", Value: `["a","c"]`},
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(&models.PoolAnswer{
+					Id:           "11",
+					Form_id:      "3",
+					Version_id:   "2",
+					User_id:      "4",
+					Score:        intRef(0),
+					Submitted_at: &submittedAt,
+					Created_at:   time.Now(),
+				}, nil)
+				mockRepoF.EXPECT().GetById(ctx, foundpa.Form_id).Return(editableform, nil)
+				mockRepoV.EXPECT().GetById(ctx, "2").Return(quizVersion("3"), nil)
+				expectTx(ctx, mockTx)
+				mockRepoA.EXPECT().DeleteByPoolAnswerId(ctx, "11").Return(int64(1), nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return([]*models.Answer{}, nil)
+				mockRepoPA.EXPECT().UpdateScore(ctx, "11", intRef(5)).Return(nil)
+			},
+			expectedAnswers: []*models.Answer{},
+		},
+		{
+			nameTest: "user_is_not_an_author",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "5",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(foundpa, nil)
+			},
+		},
+		{
+			nameTest: "other_form",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "6",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(foundpa, nil)
+			},
+		},
+		{
+			nameTest: "edit_window_is_over",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				submittedAt := time.Now().Add(-2 * time.Hour)
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(&models.PoolAnswer{
+					Id:           "10",
+					Form_id:      "3",
+					Version_id:   "2",
+					User_id:      "4",
+					Submitted_at: &submittedAt,
+					Created_at:   submittedAt,
+				}, nil)
+				mockRepoF.EXPECT().GetById(ctx, foundpa.Form_id).Return(editableform, nil)
+			},
+		},
+		{
+			nameTest: "attempt_deadline_is_over",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "12",
+				Form_id: "3",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				deadline := time.Now().Add(-time.Minute)
+				submittedAt := deadline.Add(-5 * time.Minute)
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(&models.PoolAnswer{
+					Id:           "12",
+					Form_id:      "3",
+					Version_id:   "2",
+					User_id:      "4",
+					Deadline:     &deadline,
+					Submitted_at: &submittedAt,
+					Created_at:   deadline.Add(-10 * time.Minute),
+				}, nil)
+				mockRepoF.EXPECT().GetById(ctx, foundpa.Form_id).Return(editableform, nil)
+			},
+		},
+		{
+			nameTest: "attempt_is_pending",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "13",
+				Form_id: "3",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
+				deadline := time.Now().Add(10 * time.Minute)
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(&models.PoolAnswer{
+					Id:         "13",
+					Form_id:    "3",
+					Version_id: "2",
+					User_id:    "4",
+					Pending:    true,
+					Deadline:   &deadline,
+					Created_at: time.Now(),
+				}, nil)
+				mockRepoF.EXPECT().GetById(ctx, foundpa.Form_id).Return(editableform, nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.pool_answer, testCase.answers)
+
+			gotpa, gota, err := uc.Update(testCase.ctx, &testCase.pool_answer, testCase.answers)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, foundpa, gotpa)
+				assert.Equal(t, testCase.expectedAnswers, gota)
+			case "ok_quiz":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, intRef(5), gotpa.Score)
+				assert.Equal(t, testCase.expectedAnswers, gota)
+			case "user_is_not_an_author":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "other_form":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "edit_window_is_over", "attempt_deadline_is_over", "attempt_is_pending":
+				assert.Equal(t, errs.ErrNotEditable, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPoolAnswerUseCase_Start(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoA := mocka.NewMockRepo(ctrl)
+	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
+
+	closesAt := time.Now().Add(10 * time.Minute)
+	pendingDeadline := time.Now().Add(20 * time.Minute)
+	expiredDeadline := time.Now().Add(-time.Minute)
+
+	timedform := &models.Form{
+		Id:           "3",
+		Status:       models.FormStatusOpen,
+		One_response: true,
+		Time_limit:   30 * time.Minute,
+	}
+
+	pending := &models.PoolAnswer{
+		Id:         "10",
+		Form_id:    "3",
+		Version_id: "1",
+		User_id:    "4",
+		Single:     true,
+		Pending:    true,
+		Deadline:   &pendingDeadline,
+	}
+
+	expired := &models.PoolAnswer{
+		Id:       "9",
+		Form_id:  "3",
+		User_id:  "4",
+		Single:   true,
+		Pending:  true,
+		Deadline: &expiredDeadline,
+	}
+
+	drawingform := &models.Form{
+		Id:      "3",
+		User_id: "1",
+		Status:  models.FormStatusOpen,
+		Draws:   []*models.Draw{{Tag: "go", Count: 2}, {Count: 1}},
+	}
+
+	// created attempt is returned as passed to repo
+	expectCreate := func(ctx context.Context) {
+		mockRepoV.EXPECT().GetLatestByFormId(ctx, "3").Return(&models.FormVersion{Id: "2", Form_id: "3"}, nil)
+		expectTx(ctx, mockTx)
+		mockRepoPA.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, pool_answer *models.PoolAnswer) (*models.PoolAnswer, error) {
+			return pool_answer, nil
+		})
+	}
+
+	type mockBehavior func(ctx context.Context, pool_answer *models.PoolAnswer)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		pool_answer  models.PoolAnswer
+		token        string
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest:    "ok",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(timedform, nil)
+				mockRepoPA.EXPECT().GetPending(ctx, "3", "4").Return(nil, errs.ErrContentNotFound)
+				expectCreate(ctx)
+			},
+		},
+		{
+			nameTest:    "ok_deadline_at_close",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:         "3",
+					Status:     models.FormStatusOpen,
+					Closes_at:  &closesAt,
+					Time_limit: 30 * time.Minute,
+				}, nil)
+				mockRepoPA.EXPECT().GetPending(ctx, "3", "4").Return(nil, errs.ErrContentNotFound)
+				expectCreate(ctx)
+			},
+		},
+		{
+			nameTest:    "ok_drawn",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(drawingform, nil)
+				mockRepoPA.EXPECT().GetPending(ctx, "3", "4").Return(nil, errs.ErrContentNotFound)
+				mockRepoB.EXPECT().GetRandom(ctx, "1", "go", 2, []string{}).Return([]*models.BankQuestion{
+					{Id: "901", User_id: "1", Header: "q1", Type: models.QuestionTypeText, Tags: []string{"go"}},
+					{Id: "902", User_id: "1", Header: "q2", Type: models.QuestionTypeText, Tags: []string{"go"}},
+				}, nil)
+				mockRepoB.EXPECT().GetRandom(ctx, "1", "", 1, []string{"901", "902"}).Return([]*models.BankQuestion{
+					{Id: "903", User_id: "1", Header: "q3", Type: models.QuestionTypeText},
+				}, nil)
+				expectCreate(ctx)
+			},
+		},
+		{
+			nameTest:    "bank_error",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(drawingform, nil)
+				mockRepoPA.EXPECT().GetPending(ctx, "3", "4").Return(nil, errs.ErrContentNotFound)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, "3").Return(&models.FormVersion{Id: "2", Form_id: "3"}, nil)
+				mockRepoB.EXPECT().GetRandom(ctx, "1", "go", 2, []string{}).Return(nil, errors.New("bank_error"))
+			},
+		},
+		{
+			nameTest:    "ok_resumed",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(timedform, nil)
+				mockRepoPA.EXPECT().GetPending(ctx, "3", "4").Return(pending, nil)
+			},
+		},
+		{
+			nameTest:    "ok_restart_after_expiry",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(timedform, nil)
+				mockRepoPA.EXPECT().GetPending(ctx, "3", "4").Return(expired, nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, "3").Return(&models.FormVersion{Id: "2", Form_id: "3"}, nil)
+				expectTx(ctx, mockTx)
+				gomock.InOrder(
+					mockRepoPA.EXPECT().Delete(ctx, "9").Return(nil),
+					mockRepoPA.EXPECT().Create(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, pool_answer *models.PoolAnswer) (*models.PoolAnswer, error) {
+						return pool_answer, nil
+					}),
+				)
+			},
+		},
+		{
+			nameTest:    "restart_delete_error",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(timedform, nil)
+				mockRepoPA.EXPECT().GetPending(ctx, "3", "4").Return(expired, nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, "3").Return(&models.FormVersion{Id: "2", Form_id: "3"}, nil)
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Delete(ctx, "9").Return(errors.New("repo_error"))
+			},
+		},
+		{
+			nameTest:    "guest",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(timedform, nil)
+			},
+		},
+		{
+			nameTest:    "invalid_link_token",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			token:       "wrong",
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:           "3",
+					Status:       models.FormStatusOpen,
+					Access:       models.FormAccessLink,
+					Access_token: "secret",
+					Time_limit:   30 * time.Minute,
+				}, nil)
+			},
+		},
+		{
+			nameTest:    "form_not_timed",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				expectOpenForm(ctx, mockRepoF, pool_answer.Form_id)
+			},
+		},
+		{
+			nameTest:    "form_not_open",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(&models.Form{
+					Id:         "3",
+					Status:     models.FormStatusClosed,
+					Time_limit: 30 * time.Minute,
+				}, nil)
+			},
+		},
+		{
+			nameTest:    "repo_error",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoF.EXPECT().GetById(ctx, pool_answer.Form_id).Return(timedform, nil)
+				mockRepoPA.EXPECT().GetPending(ctx, "3", "4").Return(nil, errors.New("repo_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.pool_answer)
+
+			got, err := uc.Start(testCase.ctx, &testCase.pool_answer, testCase.token)
+
+			switch testCase.nameTest {
+			case "ok", "ok_restart_after_expiry":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, "2", got.Version_id)
+				assert.True(t, got.Pending)
+				assert.True(t, got.Single)
+				assert.WithinDuration(t, time.Now().Add(30*time.Minute), *got.Deadline, time.Second)
+			case "ok_deadline_at_close":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, closesAt, *got.Deadline)
+			case "ok_drawn":
+				assert.Equal(t, nil, err)
+				assert.Nil(t, got.Deadline)
+				ids := make([]string, 0, len(got.Questions))
+				for _, q := range got.Questions {
+					ids = append(ids, q.Id)
+				}
+				assert.ElementsMatch(t, []string{"901", "902", "903"}, ids)
+			case "ok_resumed":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, pending, got)
+			case "guest":
+				assert.Equal(t, errs.ErrUnauthorized, err)
+			case "invalid_link_token":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "form_not_timed":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "form_not_open":
+				assert.Equal(t, errs.ErrFormNotOpen, err)
+			case "repo_error", "bank_error", "restart_delete_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPoolAnswerUseCase_Submit(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoA := mocka.NewMockRepo(ctrl)
+	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
+
+	// pool answer started as attempt of form 3
+	attempt := func(deadline time.Time, pending bool) *models.PoolAnswer {
+		return &models.PoolAnswer{
+			Id:         "10",
+			Form_id:    "3",
+			Version_id: "2",
+			User_id:    "4",
+			Pending:    pending,
+			Deadline:   &deadline,
+		}
+	}
+
+	quizform := &models.Form{
+		Id:         "3",
+		Status:     models.FormStatusOpen,
+		Time_limit: 30 * time.Minute,
+		Is_quiz:    true,
+	}
+
+	answers := []*models.Answer{
+		{Question_id: "7", Value: "yes"},
+		{Question_id: "8", Value: `["a","b"]`},
+	}
+
+	// bank question 901 is drawn into attempt along version questions
+	drawnattempt := func(required bool) *models.PoolAnswer {
+		res := attempt(time.Now().Add(time.Minute), true)
+		res.Questions = []*models.Question{
+			{Id: "901", Type: models.QuestionTypeText, Required: required},
+		}
+		return res
+	}
+
+	type mockBehavior func(ctx context.Context, pool_answer *models.PoolAnswer)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		pool_answer  models.PoolAnswer
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest:    "ok",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(attempt(time.Now().Add(time.Minute), true), nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(quizform, nil)
+				mockRepoV.EXPECT().GetById(ctx, "2").Return(quizVersion("3"), nil)
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Submit(ctx, "10", intRef(2), gomock.Any()).Return(nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return(answers, nil)
+			},
+		},
+		{
+			nameTest:    "ok_within_grace",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(attempt(time.Now().Add(-time.Second), true), nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(quizform, nil)
+				mockRepoV.EXPECT().GetById(ctx, "2").Return(quizVersion("3"), nil)
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Submit(ctx, "10", intRef(2), gomock.Any()).Return(nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return(answers, nil)
+			},
+		},
+		{
+			nameTest:    "last_response_closes_form",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(attempt(time.Now().Add(time.Minute), true), nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(&models.Form{
+					Id:            "3",
+					Status:        models.FormStatusOpen,
+					Max_responses: 5,
+					Time_limit:    30 * time.Minute,
+				}, nil)
+				mockRepoV.EXPECT().GetById(ctx, "2").Return(quizVersion("3"), nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, "3").Return(nil)
+				mockRepoPA.EXPECT().CountByFormId(ctx, "3").Return(4, nil)
+				mockRepoPA.EXPECT().Submit(ctx, "10", (*int)(nil), gomock.Any()).Return(nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return(answers, nil)
+				mockRepoF.EXPECT().UpdateStatus(ctx, "3", models.FormStatusClosed).Return(nil)
+			},
+		},
+		{
+			nameTest:    "deadline_exceeded",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(attempt(time.Now().Add(-time.Minute), true), nil)
+			},
+		},
+		{
+			nameTest:    "already_submitted",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(attempt(time.Now().Add(time.Minute), false), nil)
+			},
+		},
+		{
+			nameTest:    "submitted_concurrently",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(attempt(time.Now().Add(time.Minute), true), nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(quizform, nil)
+				mockRepoV.EXPECT().GetById(ctx, "2").Return(quizVersion("3"), nil)
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Submit(ctx, "10", intRef(2), gomock.Any()).Return(errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest:    "user_is_not_an_author",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "5"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(attempt(time.Now().Add(time.Minute), true), nil)
+			},
+		},
+		{
+			nameTest:    "other_form",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "6", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(attempt(time.Now().Add(time.Minute), true), nil)
+			},
+		},
+		{
+			nameTest:    "form_not_open",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(attempt(time.Now().Add(time.Minute), true), nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(&models.Form{
+					Id:         "3",
+					Status:     models.FormStatusClosed,
+					Time_limit: 30 * time.Minute,
+				}, nil)
+			},
+		},
+		{
+			nameTest:    "ok_drawn",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(drawnattempt(false), nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(quizform, nil)
+				mockRepoV.EXPECT().GetById(ctx, "2").Return(quizVersion("3"), nil)
+				expectTx(ctx, mockTx)
+				mockRepoPA.EXPECT().Submit(ctx, "10", intRef(2), gomock.Any()).Return(nil)
+				mockRepoA.EXPECT().CreateBatch(ctx, answers).Return(answers, nil)
+			},
+		},
+		{
+			nameTest:    "drawn_required_not_answered",
+			ctx:         context.Background(),
+			pool_answer: models.PoolAnswer{Id: "10", Form_id: "3", User_id: "4"},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(drawnattempt(true), nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(quizform, nil)
+				mockRepoV.EXPECT().GetById(ctx, "2").Return(quizVersion("3"), nil)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.pool_answer)
+
+			gotpa, gota, err := uc.Submit(testCase.ctx, &testCase.pool_answer, answers)
+
+			switch testCase.nameTest {
+			case "ok", "ok_within_grace", "ok_drawn":
+				assert.Equal(t, nil, err)
+				assert.False(t, gotpa.Pending)
+				assert.NotNil(t, gotpa.Submitted_at)
+				assert.Equal(t, intRef(2), gotpa.Score)
+				assert.Equal(t, answers, gota)
+			case "last_response_closes_form":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, (*int)(nil), gotpa.Score)
+			case "deadline_exceeded":
+				assert.Equal(t, errs.ErrDeadlineExceeded, err)
+			case "already_submitted", "submitted_concurrently":
+				assert.Equal(t, errs.ErrAlreadyAnswered, err)
+			case "user_is_not_an_author":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "other_form":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "form_not_open":
+				assert.Equal(t, errs.ErrFormNotOpen, err)
+			case "drawn_required_not_answered":
+				assert.Equal(t, &errs.AnswersErr{
+					Errs: []*errs.AnswerErr{
+						{Question_id: "901", Reason: errs.ReasonRequired},
+					},
+				}, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPoolAnswerUseCase_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoA := mocka.NewMockRepo(ctrl)
+	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
+
+	submittedAt := time.Now()
+
+	foundpa := &models.PoolAnswer{
+		Id:           "10",
+		Form_id:      "3",
+		Version_id:   "2",
+		User_id:      "4",
+		Submitted_at: &submittedAt,
+		Created_at:   submittedAt,
+	}
+
+	type mockBehavior func(ctx context.Context, pool_answer *models.PoolAnswer)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		pool_answer  models.PoolAnswer
+		mockBehavior mockBehavior
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(foundpa, nil)
+				mockRepoF.EXPECT().GetById(ctx, foundpa.Form_id).Return(&models.Form{
+					Id:          "3",
+					Status:      models.FormStatusOpen,
+					Edit_window: time.Hour,
+				}, nil)
+				mockRepoPA.EXPECT().Delete(ctx, foundpa.Id).Return(nil)
+			},
+		},
+		{
+			nameTest: "user_is_not_an_author",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "5",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(foundpa, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, pool_answer.Form_id, models.ActionResponseModerate).Return(errs.ErrForbidden)
+			},
+		},
+		{
+			nameTest: "moderated",
 			ctx:      context.Background(),
 			pool_answer: models.PoolAnswer{
+				Id:      "10",
 				Form_id: "3",
-				User_id: "4",
+				User_id: "7",
 			},
-			answers: []*models.Answer{
-				{
-					Question_id: "7",
-					Value:       "ans1",
-				},
-				{
-					Question_id: "8",
-					Value:       "ans2",
-				},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(foundpa, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, pool_answer.Form_id, models.ActionResponseModerate).Return(nil)
+				mockRepoPA.EXPECT().Delete(ctx, pool_answer.Id).Return(nil)
 			},
-			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
-				mockRepoPA.EXPECT().Create(ctx, pool_answer).Return(nil, errors.New("repoPA_create_error"))
+		},
+		{
+			nameTest: "editing_disabled",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(foundpa, nil)
+				mockRepoF.EXPECT().GetById(ctx, foundpa.Form_id).Return(&models.Form{
+					Id:     "3",
+					Status: models.FormStatusOpen,
+				}, nil)
 			},
 		},
 		{
-			nameTest: "repoA_create_error",
+			nameTest: "no_pool_answer",
 			ctx:      context.Background(),
 			pool_answer: models.PoolAnswer{
+				Id:      "10",
 				Form_id: "3",
 				User_id: "4",
 			},
-			answers: []*models.Answer{
-				{
-					Question_id: "7",
-					Value:       "ans1",
-				},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(nil, errs.ErrContentNotFound)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.pool_answer)
+
+			err := uc.Delete(testCase.ctx, &testCase.pool_answer)
+
+			switch testCase.nameTest {
+			case "ok", "moderated":
+				assert.Equal(t, nil, err)
+			case "user_is_not_an_author":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "editing_disabled":
+				assert.Equal(t, errs.ErrNotEditable, err)
+			case "no_pool_answer":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPoolAnswerUseCase_GetByUserId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoA := mocka.NewMockRepo(ctrl)
+	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
+
+	type mockBehavior func(ctx context.Context, user_id string, sets types.GetSets)
+
+	testTable := []struct {
+		nameTest            string
+		ctx                 context.Context
+		user_id             string
+		sets                types.GetSets
+		mockBehavior        mockBehavior
+		expectedPoolAnswers []*models.PoolAnswer
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			user_id:  "4",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
+				mockRepoPA.EXPECT().GetByUserId(ctx, user_id, sets).Return([]*models.PoolAnswer{
+					{
+						Id:         "10",
+						Form_id:    "3",
+						Version_id: "2",
+						User_id:    user_id,
+					},
+				}, nil)
+			},
+			expectedPoolAnswers: []*models.PoolAnswer{
 				{
-					Question_id: "8",
-					Value:       "ans2",
+					Id:         "10",
+					Form_id:    "3",
+					Version_id: "2",
+					User_id:    "4",
 				},
 			},
-			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer, answers []*models.Answer) {
-				pa := models.PoolAnswer{
-					Id:      "10",
-					Form_id: pool_answer.Form_id,
-					User_id: pool_answer.User_id,
-				}
-				mockRepoPA.EXPECT().Create(ctx, pool_answer).Return(&pa, nil)
-				mockRepoA.EXPECT().Create(ctx, answers[0]).Return(nil, errors.New("repoA_create_error"))
-				mockRepoPA.EXPECT().Delete(ctx, pa.Id)
+		},
+		{
+			nameTest: "repo_error",
+			ctx:      context.Background(),
+			user_id:  "4",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
+				mockRepoPA.EXPECT().GetByUserId(ctx, user_id, sets).Return(nil, errors.New("repo_error"))
 			},
 		},
 	}
 
 	for _, testCase := range testTable {
 		t.Run(testCase.nameTest, func(t *testing.T) {
-			testCase.mockBehavior(testCase.ctx, &testCase.pool_answer, testCase.answers)
+			testCase.mockBehavior(testCase.ctx, testCase.user_id, testCase.sets)
 
-			gotpa, gota, err := uc.Create(testCase.ctx, &testCase.pool_answer, testCase.answers)
+			got, err := uc.GetByUserId(testCase.ctx, testCase.user_id, testCase.sets)
 
 			switch testCase.nameTest {
 			case "ok":
 				assert.Equal(t, nil, err)
-				assert.Equal(t, testCase.expectedPA, *gotpa)
-				assert.Equal(t, testCase.expectedAnswers, gota)
-			case "repoA_create_error", "repoPA_create_error":
+				assert.Equal(t, testCase.expectedPoolAnswers, got)
+			case "repo_error":
 				assert.NotEqual(t, nil, err)
 			default:
 				assert.Error(t, errors.New("No case"), "No case")
@@ -171,8 +1811,12 @@ func TestPoolAnswerUseCase_GetByFormId(t *testing.T) {
 	mockRepoF := mockf.NewMockRepo(ctrl)
 	mockRepoA := mocka.NewMockRepo(ctrl)
 	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF)
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
 
 	type mockBehavior func(ctx context.Context, form_id string, sets types.GetSets)
 
@@ -190,7 +1834,7 @@ func TestPoolAnswerUseCase_GetByFormId(t *testing.T) {
 			form_id:  "5",
 			sets:     types.GetSets{},
 			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, form_id).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
 				pas := []*models.PoolAnswer{
 					{
 						Id:      "10",
@@ -224,7 +1868,7 @@ func TestPoolAnswerUseCase_GetByFormId(t *testing.T) {
 			form_id:  "5",
 			sets:     types.GetSets{},
 			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, form_id).Return(errors.New("user_is_not_an_owner"))
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(errors.New("user_is_not_an_owner"))
 			},
 		},
 	}
@@ -256,8 +1900,12 @@ func TestPoolAnswerUseCase_GetId(t *testing.T) {
 	mockRepoF := mockf.NewMockRepo(ctrl)
 	mockRepoA := mocka.NewMockRepo(ctrl)
 	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF)
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
 
 	type mockBehavior func(ctx context.Context, id string)
 
@@ -306,3 +1954,366 @@ func TestPoolAnswerUseCase_GetId(t *testing.T) {
 		})
 	}
 }
+
+func TestPoolAnswerUseCase_GetResult(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoA := mocka.NewMockRepo(ctrl)
+	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
+
+	scoredpa := &models.PoolAnswer{
+		Id:         "10",
+		Form_id:    "3",
+		Version_id: "2",
+		User_id:    "4",
+		Score:      intRef(2),
+	}
+
+	newForm := func(show_correct string) *models.Form {
+		return &models.Form{
+			Id:           "3",
+			Status:       models.FormStatusOpen,
+			Is_quiz:      true,
+			Show_correct: show_correct,
+		}
+	}
+
+	expectGrading := func(ctx context.Context) {
+		mockRepoV.EXPECT().GetById(ctx, "2").Return(quizVersion("3"), nil)
+		mockRepoA.EXPECT().GetByPoolAnswerId(ctx, "10", types.GetSets{Limit: 3}).Return([]*models.Answer{
+			{Id: "20", Pool_answer_id: "10", Question_id: "7", Value: "yes"},
+			{Id: "21", Pool_answer_id: "10", Question_id: "8", Value: `["a"]`},
+		}, nil)
+	}
+
+	type mockBehavior func(ctx context.Context, pool_answer *models.PoolAnswer)
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		pool_answer     models.PoolAnswer
+		mockBehavior    mockBehavior
+		expectedCorrect []string
+		expectedErr     error
+	}{
+		{
+			nameTest: "ok_author_correct_hidden",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(scoredpa, nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(newForm(models.FormShowCorrectNever), nil)
+				expectGrading(ctx)
+			},
+			expectedCorrect: []string{"", "", ""},
+		},
+		{
+			nameTest: "ok_author_correct_shown",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(scoredpa, nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(newForm(models.FormShowCorrectAfterSubmission), nil)
+				expectGrading(ctx)
+			},
+			expectedCorrect: []string{"yes", `["a","c"]`, ""},
+		},
+		{
+			nameTest: "ok_owner",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "1",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(scoredpa, nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(newForm(models.FormShowCorrectNever), nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, "3", models.ActionResponseView).Return(nil)
+				expectGrading(ctx)
+			},
+			expectedCorrect: []string{"yes", `["a","c"]`, ""},
+		},
+		{
+			nameTest: "not_scored",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(&models.PoolAnswer{
+					Id:         "10",
+					Form_id:    "3",
+					Version_id: "2",
+					User_id:    "4",
+				}, nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+		{
+			nameTest: "other_form",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "6",
+				User_id: "4",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(scoredpa, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "user_is_neither_author_nor_owner",
+			ctx:      context.Background(),
+			pool_answer: models.PoolAnswer{
+				Id:      "10",
+				Form_id: "3",
+				User_id: "5",
+			},
+			mockBehavior: func(ctx context.Context, pool_answer *models.PoolAnswer) {
+				mockRepoPA.EXPECT().GetById(ctx, pool_answer.Id).Return(scoredpa, nil)
+				mockRepoF.EXPECT().GetById(ctx, "3").Return(newForm(models.FormShowCorrectAfterSubmission), nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, "3", models.ActionResponseView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.pool_answer)
+
+			got, err := uc.GetResult(testCase.ctx, &testCase.pool_answer)
+
+			switch testCase.nameTest {
+			case "ok_author_correct_hidden", "ok_author_correct_shown", "ok_owner":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, scoredpa, got.Pool_answer)
+				assert.Equal(t, 2, got.Score)
+				assert.Equal(t, 5, got.Max_score)
+				assert.Len(t, got.Answers, 3)
+				for i, a := range got.Answers {
+					assert.Equal(t, testCase.expectedCorrect[i], a.Question.Correct)
+				}
+				assert.True(t, got.Answers[0].Is_correct)
+				assert.False(t, got.Answers[1].Is_correct)
+				assert.Equal(t, "", got.Answers[2].Value)
+			case "not_scored", "other_form", "user_is_neither_author_nor_owner":
+				assert.Equal(t, testCase.expectedErr, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestPoolAnswerUseCase_Export(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockRepoA := mocka.NewMockRepo(ctrl)
+	mockRepoPA := mockpa.NewMockRepo(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoB := mockb.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewPoolAnswerUseCase(mockRepoPA, mockRepoA, mockRepoF, mockRepoV, mockRepoB, mockAuthz, mockTx)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	createdat := time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+
+	pets := &models.Question{Id: "8", Header: "pets", Type: models.QuestionTypeMultipleChoice, Options: models.QuestionOptions{Choices: []string{"cat", "dog"}}}
+
+	// name is reworded and age is removed in latest version
+	older := &models.FormVersion{
+		Id:      "2",
+		Form_id: "5",
+		Questions: []*models.Question{
+			{Id: "7", Header: "name", Type: models.QuestionTypeText},
+			{Id: "9", Header: "age", Type: models.QuestionTypeNumber},
+			pets,
+		},
+	}
+
+	latest := &models.FormVersion{
+		Id:      "3",
+		Form_id: "5",
+		Questions: []*models.Question{
+			{Id: "7", Header: "full name", Type: models.QuestionTypeText},
+			pets,
+		},
+	}
+
+	drawn := []*models.Question{
+		{Id: "90", Header: "2+2", Type: models.QuestionTypeNumber, Correct: "4", Points: 1},
+	}
+
+	forEach := func(pas []*models.PoolAnswer, values []map[string]string) func(context.Context, string, func(*models.PoolAnswer, map[string]string) error) error {
+		return func(_ context.Context, _ string, fn func(*models.PoolAnswer, map[string]string) error) error {
+			for i, pa := range pas {
+				err := fn(pa, values[i])
+				if err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		writeErr     error
+		mockBehavior mockBehavior
+		expectedRows [][]string
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(latest, nil)
+				mockRepoPA.EXPECT().GetVersionIdsByFormId(ctx, form_id).Return([]string{"2", "3"}, nil)
+				mockRepoV.EXPECT().GetById(ctx, "2").Return(older, nil)
+				mockRepoPA.EXPECT().GetDrawnByFormId(ctx, form_id).Return(drawn, nil)
+				mockRepoPA.EXPECT().ForEachByFormId(ctx, form_id, gomock.Any()).DoAndReturn(forEach(
+					[]*models.PoolAnswer{
+						{Id: "10", User_id: "32", Form_id: form_id, Version_id: "3", Created_at: createdat, Questions: drawn},
+						{Id: "11", Form_id: form_id, Version_id: "2", Created_at: createdat},
+					},
+					[]map[string]string{
+						{"7": "Tom", "8": `["cat","dog"]`, "90": "4"},
+						{"7": "Ann", "9": "30", "8": `["dog"]`},
+					},
+				))
+			},
+			// every answer is written under the question as shown to respondent
+			expectedRows: [][]string{
+				{"id", "user_id", "version_id", "created_at", "full name", "pets", "name", "age", "2+2"},
+				{"10", "32", "3", "2023-05-01T12:00:00Z", "Tom", "cat; dog", "", "", "4"},
+				{"11", "", "2", "2023-05-01T12:00:00Z", "", "dog", "Ann", "30", ""},
+			},
+		},
+		{
+			nameTest: "ok_no_answers",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(latest, nil)
+				mockRepoPA.EXPECT().GetVersionIdsByFormId(ctx, form_id).Return([]string{}, nil)
+				mockRepoPA.EXPECT().GetDrawnByFormId(ctx, form_id).Return([]*models.Question{}, nil)
+				mockRepoPA.EXPECT().ForEachByFormId(ctx, form_id, gomock.Any()).Return(nil)
+			},
+			expectedRows: [][]string{
+				{"id", "user_id", "version_id", "created_at", "full name", "pets"},
+			},
+		},
+		{
+			nameTest: "version_error",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(latest, nil)
+				mockRepoPA.EXPECT().GetVersionIdsByFormId(ctx, form_id).Return([]string{"2", "3"}, nil)
+				mockRepoV.EXPECT().GetById(ctx, "2").Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+		{
+			nameTest: "drawn_error",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(latest, nil)
+				mockRepoPA.EXPECT().GetVersionIdsByFormId(ctx, form_id).Return([]string{"3"}, nil)
+				mockRepoPA.EXPECT().GetDrawnByFormId(ctx, form_id).Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+		{
+			nameTest: "write_error",
+			ctx:      context.Background(),
+			form_id:  "5",
+			writeErr: errors.New("write_error"),
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(latest, nil)
+				mockRepoPA.EXPECT().GetVersionIdsByFormId(ctx, form_id).Return([]string{"3"}, nil)
+				mockRepoPA.EXPECT().GetDrawnByFormId(ctx, form_id).Return(drawn, nil)
+			},
+			expectedRows: [][]string{
+				{"id", "user_id", "version_id", "created_at", "full name", "pets", "2+2"},
+			},
+			expectedErr: errors.New("write_error"),
+		},
+		{
+			nameTest: "not_published",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(nil, errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+		{
+			nameTest: "user_is_not_an_owner",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			var gotRows [][]string
+
+			err := uc.Export(testCase.ctx, testCase.form_id, func(row []string) error {
+				gotRows = append(gotRows, row)
+				return testCase.writeErr
+			})
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedRows, gotRows)
+		})
+	}
+}
+
+func intRef(i int) *int {
+	return &i
+}
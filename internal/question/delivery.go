@@ -8,4 +8,5 @@ type Handlers interface {
 	Delete() gin.HandlerFunc
 	Update() gin.HandlerFunc
 	GetByFormId() gin.HandlerFunc
+	Reorder() gin.HandlerFunc
 }
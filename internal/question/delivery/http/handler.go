@@ -5,19 +5,56 @@ import (
 	"quizapp/internal/question"
 	"quizapp/models"
 	"quizapp/pkg/errs"
+	"quizapp/pkg/questiondto"
 	"quizapp/pkg/types"
 
 	"github.com/gin-gonic/gin"
 )
 
+type conditionDTO struct {
+	Question_id string `json:"question_id" binding:"required"`
+	Op          string `json:"op" enums:"equals,not_equals,answered,not_answered"`
+	// Value to compare answer with, has to be empty for answered ops
+	Value string `json:"value,omitempty"`
+}
+
 type questionCreatRequest struct {
-	Header string `json:"header" binding:"required"`
+	Header  string              `json:"header" binding:"required"`
+	Type    string              `json:"type" enums:"text,single_choice,multiple_choice,scale,date,number"`
+	Options questiondto.Options `json:"options"`
+	// Section of form to put question in, question is out of sections, if not set
+	Section_id string `json:"section_id"`
+	// Place to insert question at, following questions are moved down. Question is appended, if not set. Not changed by update
+	Position int `json:"position" minimum:"0"`
+	// Question is shown only when condition on earlier answer holds, always shown, if not set
+	Condition *conditionDTO `json:"condition"`
+	// Shown required question has to be answered
+	Required bool `json:"required"`
+	// Answer rules of text questions
+	Rules questiondto.Rules `json:"rules"`
+	// Correct answer value in quiz, question is not scored, if not set
+	Correct string `json:"correct"`
+	// Points for correct answer, has to be 0 for not scored question
+	Points int `json:"points" minimum:"0"`
 }
 
 type questionResponse struct {
-	Id      string `json:"id"`
-	Form_id string `json:"form_id"`
-	Header  string `json:"header"`
+	Id         string              `json:"id"`
+	Form_id    string              `json:"form_id"`
+	Header     string              `json:"header"`
+	Type       string              `json:"type"`
+	Options    questiondto.Options `json:"options"`
+	Section_id string              `json:"section_id,omitempty"`
+	Position   int                 `json:"position,omitempty"`
+	Condition  *conditionDTO       `json:"condition,omitempty"`
+	Required   bool                `json:"required"`
+	Rules      questiondto.Rules   `json:"rules"`
+	Correct    string              `json:"correct,omitempty"`
+	Points     int                 `json:"points"`
+}
+
+type questionReorderRequest struct {
+	Question_ids []string `json:"question_ids" binding:"required"`
 }
 
 type questionGetByFormIdResponse struct {
@@ -38,14 +75,14 @@ func NewQuestionHandlers(questionUC question.UseCase, ctxUserKey string) questio
 
 // Create godoc
 // @Summary Create question
-// @Description Create new question with header for current form
+// @Description Create new question with header, type and type options for current form
 // @Tags Questions
 // @Security JWTToken
-// @Param data body questionCreatRequest true "question header"
+// @Param data body questionCreatRequest true "question header, type and options"
 // @Param id path string true "current form id"
 // @Success 201 {object} questionResponse
 // @Failure 204   "No such form"
-// @Failure 400   "Invalid json"
+// @Failure 400   "Invalid json, unknown type, options or rules inconsistent with type, section is not of form or invalid condition"
 // @Failure 401   "Unauthorized"
 // @Failure 403   "Permission denied"
 // @Failure 500   "Other err"
@@ -60,10 +97,8 @@ func (h *questionHandlers) Create() gin.HandlerFunc {
 			return
 		}
 
-		modelBL := &models.Question{
-			Form_id: c.Param("formid"),
-			Header:  request.Header,
-		}
+		modelBL := questionRequestToBL(request)
+		modelBL.Form_id = c.Param("formid")
 
 		createdquestion, err := h.questionUC.Create(c, modelBL)
 		if err != nil {
@@ -85,7 +120,7 @@ func (h *questionHandlers) Create() gin.HandlerFunc {
 // @Failure 204   "No such question"
 // @Failure 400   "Invalid question id"
 // @Failure 401   "Unauthorized"
-// @Failure 403   "User is not the form owner or permission denied"
+// @Failure 403   "User is neither the form owner nor editor or permission denied"
 // @Failure 500   "Other err"
 // @Router /forms/{formid}/questions/{questionid} [delete]
 func (h *questionHandlers) Delete() gin.HandlerFunc {
@@ -112,6 +147,7 @@ func (h *questionHandlers) Delete() gin.HandlerFunc {
 // @Failure 204 {object} questionGetByFormIdResponse "No questions by form"
 // @Failure 400   "Invalid params"
 // @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner or member nor moderator or admin"
 // @Failure 500   "Other err"
 // @Router /forms/{formid}/questions [get]
 func (h *questionHandlers) GetByFormId() gin.HandlerFunc {
@@ -127,7 +163,7 @@ func (h *questionHandlers) GetByFormId() gin.HandlerFunc {
 			Offset: offset,
 		})
 		if err != nil {
-			c.AbortWithStatus(http.StatusInternalServerError)
+			c.AbortWithStatus(errs.MatchHttpErr(err))
 			return
 		}
 
@@ -145,15 +181,15 @@ func (h *questionHandlers) GetByFormId() gin.HandlerFunc {
 
 // Update godoc
 // @Summary Update question
-// @Description Update question with header
+// @Description Update question with header, type and type options, type is required
 // @Tags Questions
 // @Security JWTToken
 // @Param formid path string true "form id"
 // @Param questionid path string true "question id"
-// @Param new body questionCreatRequest true "new header"
+// @Param new body questionCreatRequest true "new header, type and options"
 // @Success 200 {object} questionResponse "Updated"
 // @Failure 204   "No such question"
-// @Failure 400   "Invalid question id"
+// @Failure 400   "Invalid question id, unknown type, options or rules inconsistent with type, section is not of form or invalid condition"
 // @Failure 401   "Unauthorized"
 // @Failure 403   "Permission denied"
 // @Failure 500   "Other err"
@@ -168,11 +204,11 @@ func (h *questionHandlers) Update() gin.HandlerFunc {
 			return
 		}
 
-		updatedquestion, err := h.questionUC.Update(c, &models.Question{
-			Id:      c.Param("questionid"),
-			Form_id: c.Param("formid"),
-			Header:  request.Header,
-		})
+		modelBL := questionRequestToBL(request)
+		modelBL.Id = c.Param("questionid")
+		modelBL.Form_id = c.Param("formid")
+
+		updatedquestion, err := h.questionUC.Update(c, modelBL)
 		if err != nil {
 			c.AbortWithStatus(errs.MatchHttpErr(err))
 			return
@@ -182,11 +218,93 @@ func (h *questionHandlers) Update() gin.HandlerFunc {
 	}
 }
 
+// Reorder godoc
+// @Summary Reorder questions
+// @Description Set order of all form questions at once
+// @Tags Questions
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param data body questionReorderRequest true "ids of all form questions in new order"
+// @Success 200   "Reordered"
+// @Failure 204   "No such form or form has no questions"
+// @Failure 400   "Invalid json or ids are not exactly form questions"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner nor editor or permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/questions/order [put]
+func (h *questionHandlers) Reorder() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(questionReorderRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		err = h.questionUC.Reorder(c, c.Param("formid"), request.Question_ids)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+func questionRequestToBL(dto *questionCreatRequest) *models.Question {
+	return &models.Question{
+		Header:     dto.Header,
+		Type:       dto.Type,
+		Options:    questiondto.OptionsToBL(&dto.Options),
+		Section_id: dto.Section_id,
+		Position:   dto.Position,
+		Condition:  conditionRequestToBL(dto.Condition),
+		Required:   dto.Required,
+		Rules:      questiondto.RulesToBL(&dto.Rules),
+		Correct:    dto.Correct,
+		Points:     dto.Points,
+	}
+}
+
 func questionBLToResponse(modelBL *models.Question) *questionResponse {
 	return &questionResponse{
-		Id:      modelBL.Id,
-		Form_id: modelBL.Form_id,
-		Header:  modelBL.Header,
+		Id:         modelBL.Id,
+		Form_id:    modelBL.Form_id,
+		Header:     modelBL.Header,
+		Type:       modelBL.Type,
+		Options:    questiondto.OptionsBLToDTO(&modelBL.Options),
+		Section_id: modelBL.Section_id,
+		Position:   modelBL.Position,
+		Condition:  conditionBLToResponse(modelBL.Condition),
+		Required:   modelBL.Required,
+		Rules:      questiondto.RulesBLToDTO(&modelBL.Rules),
+		Correct:    modelBL.Correct,
+		Points:     modelBL.Points,
+	}
+}
+
+func conditionRequestToBL(dto *conditionDTO) *models.Condition {
+	if dto == nil {
+		return nil
+	}
+
+	return &models.Condition{
+		Question_id: dto.Question_id,
+		Op:          dto.Op,
+		Value:       dto.Value,
+	}
+}
+
+func conditionBLToResponse(modelBL *models.Condition) *conditionDTO {
+	if modelBL == nil {
+		return nil
+	}
+
+	return &conditionDTO{
+		Question_id: modelBL.Question_id,
+		Op:          modelBL.Op,
+		Value:       modelBL.Value,
 	}
 }
 
@@ -10,6 +10,7 @@ import (
 func MapQuestionRoutes(questionGroup *gin.RouterGroup, h question.Handlers) {
 	questionGroup.POST("", h.Create())
 	questionGroup.GET("", h.GetByFormId())
+	questionGroup.PUT("/order", h.Reorder())
 	questionGroup.PUT("/:questionid", h.Update())
 	questionGroup.DELETE("/:questionid", h.Delete())
 }
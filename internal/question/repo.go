@@ -19,6 +19,12 @@ type Repo interface {
 	// Returns nil & other err else.
 	GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.Question, error)
 
+	// Returns all form questions & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetAllByFormId(ctx context.Context, form_id string) ([]*models.Question, error)
+
 	// Returns source model & nil, if updated.
 	// Returns nil & ErrContentNotFound, if nothing to update.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
@@ -33,6 +39,21 @@ type Repo interface {
 	// Returns other errors else.
 	Delete(ctx context.Context, id string) error
 
+	// Moves questions of form at position from and after one place down.
+	// Returns nil, if moved or nothing to move.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	ShiftPositions(ctx context.Context, form_id string, from int) error
+
+	// Sets positions of form questions by their place in question_ids.
+	// Returns nil, if reordered.
+	// Returns ErrContentNotFound, if form has no questions.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	Reorder(ctx context.Context, form_id string, question_ids []string) error
+
 	// Returns found model, if get.
 	// Returns nil & ErrContentNotFound, if get nothing.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
@@ -7,6 +7,7 @@ import (
 	"quizapp/models"
 	"quizapp/pkg/errs"
 	"quizapp/pkg/postgres"
+	"quizapp/pkg/snapshot"
 	"quizapp/pkg/types"
 	"strconv"
 
@@ -16,8 +17,15 @@ import (
 )
 
 type QuestionDB struct {
-	Id, FormId int
-	Header     string
+	Id, FormId, Position int
+	SectionId            *int
+	Header, Type         string
+	Options              []byte
+	Condition            *string
+	Required             bool
+	Rules                []byte
+	Correct              string
+	Points               int
 }
 
 type questionRepo struct {
@@ -34,17 +42,24 @@ func (qr *questionRepo) Create(ctx context.Context, modelBL *models.Question) (*
 		return nil, errs.ErrInvalidContent
 	}
 
+	// unset position appends question after the last one
+	var position interface{} = questionDB.Position
+	if questionDB.Position <= 0 {
+		position = squirrel.Expr("(SELECT COALESCE(MAX(position_), 0) + 1 FROM question_ WHERE form_id_ = ?)", questionDB.FormId)
+	}
+
 	sql, args, err := qr.Builder.
 		Insert("question_").
-		Columns("form_id_, header_").
-		Values(questionDB.FormId, questionDB.Header).
-		Suffix("RETURNING \"id_\"").
+		Columns("form_id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_").
+		Values(questionDB.FormId, questionDB.SectionId, questionDB.Header, questionDB.Type, string(questionDB.Options), position, questionDB.Condition,
+			questionDB.Required, string(questionDB.Rules), questionDB.Correct, questionDB.Points).
+		Suffix("RETURNING \"id_\", \"position_\"").
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	err = qr.Pool.QueryRow(ctx, sql, args...).Scan(&questionDB.Id)
+	err = qr.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&questionDB.Id, &questionDB.Position)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
@@ -63,18 +78,35 @@ func (q *questionRepo) GetByFormId(ctx context.Context, form_id string, sets typ
 		return nil, errs.ErrInvalidContent
 	}
 
-	sql, args, err := q.Builder.
-		Select("id_, header_").
+	return q.getByFormId(ctx, intformid, q.Builder.
+		Select("id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_").
 		From("question_").
 		Where(squirrel.Eq{"form_id_": intformid}).
+		OrderBy("position_, id_").
 		Limit(sets.Limit).
-		Offset(sets.Offset).
-		ToSql()
+		Offset(sets.Offset))
+}
+
+func (q *questionRepo) GetAllByFormId(ctx context.Context, form_id string) ([]*models.Question, error) {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	return q.getByFormId(ctx, intformid, q.Builder.
+		Select("id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_").
+		From("question_").
+		Where(squirrel.Eq{"form_id_": intformid}).
+		OrderBy("position_, id_"))
+}
+
+func (q *questionRepo) getByFormId(ctx context.Context, intformid int, builder squirrel.SelectBuilder) ([]*models.Question, error) {
+	sql, args, err := builder.ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	rows, err := q.Pool.Query(ctx, sql, args...)
+	rows, err := q.Conn(ctx).Query(ctx, sql, args...)
 	if err != nil {
 		return nil, err
 	}
@@ -85,7 +117,7 @@ func (q *questionRepo) GetByFormId(ctx context.Context, form_id string, sets typ
 	for rows.Next() {
 		modelDB := QuestionDB{FormId: intformid}
 
-		err = rows.Scan(&modelDB.Id, &modelDB.Header)
+		err = rows.Scan(&modelDB.Id, &modelDB.SectionId, &modelDB.Header, &modelDB.Type, &modelDB.Options, &modelDB.Position, &modelDB.Condition, &modelDB.Required, &modelDB.Rules, &modelDB.Correct, &modelDB.Points)
 		if err != nil {
 			return nil, err
 		}
@@ -109,14 +141,22 @@ func (q *questionRepo) Update(ctx context.Context, modelBL *models.Question) (*m
 
 	sql, args, err := q.Builder.
 		Update("question_").
+		Set("section_id_", modelDB.SectionId).
 		Set("header_", modelDB.Header).
+		Set("type_", modelDB.Type).
+		Set("options_", string(modelDB.Options)).
+		Set("condition_", modelDB.Condition).
+		Set("required_", modelDB.Required).
+		Set("rules_", string(modelDB.Rules)).
+		Set("correct_", modelDB.Correct).
+		Set("points_", modelDB.Points).
 		Where(squirrel.Eq{"id_": modelDB.Id}).
 		ToSql()
 	if err != nil {
 		return nil, err
 	}
 
-	res, err := q.Pool.Exec(ctx, sql, args...)
+	res, err := q.Conn(ctx).Exec(ctx, sql, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
@@ -147,7 +187,77 @@ func (q *questionRepo) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	res, err := q.Pool.Exec(ctx, sql, args...)
+	res, err := q.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (q *questionRepo) ShiftPositions(ctx context.Context, form_id string, from int) error {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := q.Builder.
+		Update("question_").
+		Set("position_", squirrel.Expr("position_ + 1")).
+		Where(squirrel.Eq{"form_id_": intformid}).
+		Where(squirrel.GtOrEq{"position_": from}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = q.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (q *questionRepo) Reorder(ctx context.Context, form_id string, question_ids []string) error {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	intids := make([]int, len(question_ids))
+	for i, id := range question_ids {
+		intids[i], err = strconv.Atoi(id)
+		if err != nil {
+			return errs.ErrInvalidContent
+		}
+	}
+
+	// single statement, so positions stay unique when checked at commit
+	sql, args, err := q.Builder.
+		Update("question_").
+		Set("position_", squirrel.Expr("array_position(?::int[], id_)", intids)).
+		Where(squirrel.Eq{"form_id_": intformid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := q.Conn(ctx).Exec(ctx, sql, args...)
 	if err != nil {
 		var pgErr *pgconn.PgError
 		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
@@ -171,7 +281,7 @@ func (q *questionRepo) GetById(ctx context.Context, id string) (*models.Question
 	}
 
 	sql, args, err := q.Builder.
-		Select("form_id_, header_").
+		Select("form_id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_").
 		From("question_").
 		Where(squirrel.Eq{"id_": intid}).
 		ToSql()
@@ -180,7 +290,7 @@ func (q *questionRepo) GetById(ctx context.Context, id string) (*models.Question
 	}
 
 	modelDB := QuestionDB{Id: intid}
-	err = q.Pool.QueryRow(ctx, sql, args...).Scan(&modelDB.FormId, &modelDB.Header)
+	err = q.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.FormId, &modelDB.SectionId, &modelDB.Header, &modelDB.Type, &modelDB.Options, &modelDB.Position, &modelDB.Condition, &modelDB.Required, &modelDB.Rules, &modelDB.Correct, &modelDB.Points)
 	if err != nil {
 		if err == pgx.ErrNoRows {
 			return nil, errs.ErrContentNotFound
@@ -193,10 +303,39 @@ func (q *questionRepo) GetById(ctx context.Context, id string) (*models.Question
 }
 
 func questionDBToBL(questionDB *QuestionDB) (*models.Question, error) {
+	options, err := snapshot.UnmarshalOptions(questionDB.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	var sid string
+	if questionDB.SectionId != nil {
+		sid = strconv.Itoa(*questionDB.SectionId)
+	}
+
+	condition, err := snapshot.UnmarshalCondition(questionDB.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := snapshot.UnmarshalRules(questionDB.Rules)
+	if err != nil {
+		return nil, err
+	}
+
 	return &models.Question{
-		Id:      strconv.Itoa(questionDB.Id),
-		Form_id: strconv.Itoa(questionDB.FormId),
-		Header:  questionDB.Header,
+		Id:         strconv.Itoa(questionDB.Id),
+		Form_id:    strconv.Itoa(questionDB.FormId),
+		Section_id: sid,
+		Header:     questionDB.Header,
+		Type:       questionDB.Type,
+		Options:    options,
+		Position:   questionDB.Position,
+		Condition:  condition,
+		Required:   questionDB.Required,
+		Rules:      rules,
+		Correct:    questionDB.Correct,
+		Points:     questionDB.Points,
 	}, nil
 }
 
@@ -221,9 +360,43 @@ func questionBLToDB(questionBL *models.Question) (*QuestionDB, error) {
 		}
 	}
 
+	// question out of sections has no section
+	var sid *int
+	if questionBL.Section_id != "" {
+		intsid, err := strconv.Atoi(questionBL.Section_id)
+		if err != nil {
+			return nil, err
+		}
+		sid = &intsid
+	}
+
+	condition, err := snapshot.MarshalCondition(questionBL.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	options, err := snapshot.MarshalOptions(questionBL.Options)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := snapshot.MarshalRules(questionBL.Rules)
+	if err != nil {
+		return nil, err
+	}
+
 	return &QuestionDB{
-		Id:     id,
-		FormId: fid,
-		Header: questionBL.Header,
+		Id:        id,
+		FormId:    fid,
+		SectionId: sid,
+		Header:    questionBL.Header,
+		Type:      questionBL.Type,
+		Options:   options,
+		Position:  questionBL.Position,
+		Condition: condition,
+		Required:  questionBL.Required,
+		Rules:     rules,
+		Correct:   questionBL.Correct,
+		Points:    questionBL.Points,
 	}, nil
 }
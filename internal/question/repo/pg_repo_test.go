@@ -52,17 +52,43 @@ func TestFormRepo_Create(t *testing.T) {
 			question: models.Question{
 				Form_id: "12",
 				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
 			},
 			mockBehavior: func(ctx context.Context, question *models.Question) {
-				pgxRows := pgxpoolmock.NewRows([]string{"id_"}).AddRow(345).ToPgxRows()
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "position_"}).AddRow(345, 3).ToPgxRows()
 				pgxRows.Next()
 				formidint, _ := strconv.Atoi(question.Form_id)
-				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO question_ (form_id_, header_) VALUES ($1,$2) RETURNING \"id_\"", formidint, question.Header).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO question_ (form_id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_) VALUES ($1,$2,$3,$4,$5,(SELECT COALESCE(MAX(position_), 0) + 1 FROM question_ WHERE form_id_ = $6),$7,$8,$9,$10,$11) RETURNING \"id_\", \"position_\"", formidint, (*int)(nil), question.Header, question.Type, "{}", formidint, (*string)(nil), false, "{}", "", 0).Return(pgxRows)
 			},
 			expectedQuestion: models.Question{
-				Id:      "345",
-				Form_id: "12",
-				Header:  "sdcsd",
+				Id:       "345",
+				Form_id:  "12",
+				Header:   "sdcsd",
+				Type:     models.QuestionTypeText,
+				Position: 3,
+			},
+		},
+		{
+			nameTest: "ok_at_position",
+			ctx:      context.Background(),
+			question: models.Question{
+				Form_id:  "12",
+				Header:   "sdcsd",
+				Type:     models.QuestionTypeText,
+				Position: 2,
+			},
+			mockBehavior: func(ctx context.Context, question *models.Question) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "position_"}).AddRow(345, 2).ToPgxRows()
+				pgxRows.Next()
+				formidint, _ := strconv.Atoi(question.Form_id)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO question_ (form_id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_) VALUES ($1,$2,$3,$4,$5,$6,$7,$8,$9,$10,$11) RETURNING \"id_\", \"position_\"", formidint, (*int)(nil), question.Header, question.Type, "{}", question.Position, (*string)(nil), false, "{}", "", 0).Return(pgxRows)
+			},
+			expectedQuestion: models.Question{
+				Id:       "345",
+				Form_id:  "12",
+				Header:   "sdcsd",
+				Type:     models.QuestionTypeText,
+				Position: 2,
 			},
 		},
 		{
@@ -80,11 +106,12 @@ func TestFormRepo_Create(t *testing.T) {
 			question: models.Question{
 				Form_id: "12",
 				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
 			},
 			mockBehavior: func(ctx context.Context, question *models.Question) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 				formidint, _ := strconv.Atoi(question.Form_id)
-				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO question_ (form_id_, header_) VALUES ($1,$2) RETURNING \"id_\"", formidint, question.Header).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO question_ (form_id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_) VALUES ($1,$2,$3,$4,$5,(SELECT COALESCE(MAX(position_), 0) + 1 FROM question_ WHERE form_id_ = $6),$7,$8,$9,$10,$11) RETURNING \"id_\", \"position_\"", formidint, (*int)(nil), question.Header, question.Type, "{}", formidint, (*string)(nil), false, "{}", "", 0).Return(pgxRows)
 			},
 		},
 	}
@@ -96,7 +123,7 @@ func TestFormRepo_Create(t *testing.T) {
 			got, err := r.Create(testCase.ctx, &testCase.question)
 
 			switch testCase.nameTest {
-			case "ok":
+			case "ok", "ok_at_position":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedQuestion, *got)
 			case "invalid_inputs":
@@ -138,15 +165,17 @@ func TestQuestionRepo_GetById(t *testing.T) {
 			ctx:      context.Background(),
 			id:       "345",
 			mockBehavior: func(ctx context.Context, id string) {
-				pgxRows := pgxpoolmock.NewRows([]string{"form_id_", "header_"}).AddRow(12, "sdcsd").ToPgxRows()
+				pgxRows := pgxpoolmock.NewRows([]string{"form_id_", "section_id_", "header_", "type_", "options_", "position_", "condition_", "required_", "rules_", "correct_", "points_"}).AddRow(12, nil, "sdcsd", "text", []byte("{}"), 1, nil, false, []byte("{}"), "", 0).ToPgxRows()
 				pgxRows.Next()
 				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT form_id_, header_ FROM question_ WHERE id_ = $1", idint).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT form_id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_ FROM question_ WHERE id_ = $1", idint).Return(pgxRows)
 			},
 			expectedQuestion: models.Question{
-				Id:      "345",
-				Form_id: "12",
-				Header:  "sdcsd",
+				Id:       "345",
+				Form_id:  "12",
+				Header:   "sdcsd",
+				Type:     models.QuestionTypeText,
+				Position: 1,
 			},
 		},
 		{
@@ -162,7 +191,7 @@ func TestQuestionRepo_GetById(t *testing.T) {
 			mockBehavior: func(ctx context.Context, id string) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 				idint, _ := strconv.Atoi(id)
-				mockPool.EXPECT().QueryRow(ctx, "SELECT form_id_, header_ FROM question_ WHERE id_ = $1", idint).Return(pgxRows)
+				mockPool.EXPECT().QueryRow(ctx, "SELECT form_id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_ FROM question_ WHERE id_ = $1", idint).Return(pgxRows)
 			},
 		},
 	}
@@ -218,20 +247,38 @@ func TestQuestionRepo_GetByFormId(t *testing.T) {
 			form_id:  "12",
 			sets:     types.GetSets{},
 			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
-				pgxRows := pgxpoolmock.NewRows([]string{"id_", "header_"}).AddRow(345, "ecefvc").AddRow(346, "ty").ToPgxRows()
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "section_id_", "header_", "type_", "options_", "position_", "condition_", "required_", "rules_", "correct_", "points_"}).AddRow(345, nil, "ecefvc", "text", []byte("{}"), 1, nil, false, []byte(`{"max_length":10}`), "", 0).AddRow(346, intRef(7), "ty", "single_choice", []byte(`{"choices":["a","b"]}`), 2, strRef(`{"question_id":345,"op":"answered"}`), true, []byte("{}"), "b", 2).ToPgxRows()
 				formidint, _ := strconv.Atoi(form_id)
-				mockPool.EXPECT().Query(ctx, "SELECT id_, header_ FROM question_ WHERE form_id_ = $1 LIMIT 0 OFFSET 0", formidint).Return(pgxRows, nil)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_ FROM question_ WHERE form_id_ = $1 ORDER BY position_, id_ LIMIT 0 OFFSET 0", formidint).Return(pgxRows, nil)
 			},
 			expectedQuestions: []*models.Question{
 				{
-					Id:      "345",
-					Form_id: "12",
-					Header:  "ecefvc",
+					Id:       "345",
+					Form_id:  "12",
+					Header:   "ecefvc",
+					Type:     models.QuestionTypeText,
+					Position: 1,
+					Rules: models.QuestionRules{
+						Max_length: intRef(10),
+					},
 				},
 				{
 					Id:      "346",
 					Form_id: "12",
 					Header:  "ty",
+					Type:    models.QuestionTypeSingleChoice,
+					Options: models.QuestionOptions{
+						Choices: []string{"a", "b"},
+					},
+					Section_id: "7",
+					Position:   2,
+					Condition: &models.Condition{
+						Question_id: "345",
+						Op:          models.ConditionOpAnswered,
+					},
+					Required: true,
+					Correct:  "b",
+					Points:   2,
 				},
 			},
 		},
@@ -249,7 +296,7 @@ func TestQuestionRepo_GetByFormId(t *testing.T) {
 			sets:     types.GetSets{},
 			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
 				formidint, _ := strconv.Atoi(form_id)
-				mockPool.EXPECT().Query(ctx, "SELECT id_, header_ FROM question_ WHERE form_id_ = $1 LIMIT 0 OFFSET 0", formidint).Return(nil, errors.New("query_error"))
+				mockPool.EXPECT().Query(ctx, "SELECT id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_ FROM question_ WHERE form_id_ = $1 ORDER BY position_, id_ LIMIT 0 OFFSET 0", formidint).Return(nil, errors.New("query_error"))
 			},
 		},
 		{
@@ -261,7 +308,7 @@ func TestQuestionRepo_GetByFormId(t *testing.T) {
 				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
 				pgxRows.Next()
 				formidint, _ := strconv.Atoi(form_id)
-				mockPool.EXPECT().Query(ctx, "SELECT id_, header_ FROM question_ WHERE form_id_ = $1 LIMIT 0 OFFSET 0", formidint).Return(pgxRows, nil)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_ FROM question_ WHERE form_id_ = $1 ORDER BY position_, id_ LIMIT 0 OFFSET 0", formidint).Return(pgxRows, nil)
 			},
 			expectedQuestions: []*models.Question{},
 		},
@@ -291,6 +338,86 @@ func TestQuestionRepo_GetByFormId(t *testing.T) {
 	}
 }
 
+func TestQuestionRepo_GetAllByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewQuestionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	testTable := []struct {
+		nameTest          string
+		ctx               context.Context
+		form_id           string
+		mockBehavior      mockBehavior
+		expectedQuestions []*models.Question
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "section_id_", "header_", "type_", "options_", "position_", "condition_", "required_", "rules_", "correct_", "points_"}).AddRow(345, nil, "ecefvc", "text", []byte("{}"), 1, nil, false, []byte("{}"), "", 0).ToPgxRows()
+				formidint, _ := strconv.Atoi(form_id)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_ FROM question_ WHERE form_id_ = $1 ORDER BY position_, id_", formidint).Return(pgxRows, nil)
+			},
+			expectedQuestions: []*models.Question{
+				{
+					Id:       "345",
+					Form_id:  "12",
+					Header:   "ecefvc",
+					Type:     models.QuestionTypeText,
+					Position: 1,
+				},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			mockBehavior: func(ctx context.Context, form_id string) {},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				formidint, _ := strconv.Atoi(form_id)
+				mockPool.EXPECT().Query(ctx, "SELECT id_, section_id_, header_, type_, options_, position_, condition_, required_, rules_, correct_, points_ FROM question_ WHERE form_id_ = $1 ORDER BY position_, id_", formidint).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			got, err := r.GetAllByFormId(testCase.ctx, testCase.form_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedQuestions, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
 func TestQuestionRepo_Update(t *testing.T) {
 	t.Parallel()
 	ctrl := gomock.NewController(t)
@@ -321,15 +448,17 @@ func TestQuestionRepo_Update(t *testing.T) {
 				Id:      "345",
 				Form_id: "12",
 				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
 			},
 			mockBehavior: func(ctx context.Context, question *models.Question) {
 				idint, _ := strconv.Atoi(question.Id)
-				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET header_ = $1 WHERE id_ = $2", question.Header, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
+				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET section_id_ = $1, header_ = $2, type_ = $3, options_ = $4, condition_ = $5, required_ = $6, rules_ = $7, correct_ = $8, points_ = $9 WHERE id_ = $10", (*int)(nil), question.Header, question.Type, "{}", (*string)(nil), false, "{}", "", 0, idint).Return(pgxmock.NewResult("UPDATE", 1), nil)
 			},
 			expectedQuestion: models.Question{
 				Id:      "345",
 				Form_id: "12",
 				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
 			},
 		},
 		{
@@ -359,10 +488,11 @@ func TestQuestionRepo_Update(t *testing.T) {
 				Id:      "345",
 				Form_id: "12",
 				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
 			},
 			mockBehavior: func(ctx context.Context, question *models.Question) {
 				idint, _ := strconv.Atoi(question.Id)
-				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET header_ = $1 WHERE id_ = $2", question.Header, idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
+				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET section_id_ = $1, header_ = $2, type_ = $3, options_ = $4, condition_ = $5, required_ = $6, rules_ = $7, correct_ = $8, points_ = $9 WHERE id_ = $10", (*int)(nil), question.Header, question.Type, "{}", (*string)(nil), false, "{}", "", 0, idint).Return(pgxmock.NewResult("UPDATE", 0), nil)
 			},
 		},
 		{
@@ -372,10 +502,11 @@ func TestQuestionRepo_Update(t *testing.T) {
 				Id:      "345",
 				Form_id: "12",
 				Header:  "sdcsd",
+				Type:    models.QuestionTypeText,
 			},
 			mockBehavior: func(ctx context.Context, question *models.Question) {
 				idint, _ := strconv.Atoi(question.Id)
-				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET header_ = $1 WHERE id_ = $2", question.Header, idint).Return(nil, errors.New("exec_error"))
+				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET section_id_ = $1, header_ = $2, type_ = $3, options_ = $4, condition_ = $5, required_ = $6, rules_ = $7, correct_ = $8, points_ = $9 WHERE id_ = $10", (*int)(nil), question.Header, question.Type, "{}", (*string)(nil), false, "{}", "", 0, idint).Return(nil, errors.New("exec_error"))
 			},
 		},
 	}
@@ -481,3 +612,166 @@ func TestQuestionRepo_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestQuestionRepo_ShiftPositions(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewQuestionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string, from int)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		from         int
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			from:     2,
+			mockBehavior: func(ctx context.Context, form_id string, from int) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET position_ = position_ + 1 WHERE form_id_ = $1 AND position_ >= $2", 12, from).Return(pgxmock.NewResult("UPDATE", 3), nil)
+			},
+		},
+		{
+			nameTest: "nothing_to_shift",
+			ctx:      context.Background(),
+			form_id:  "12",
+			from:     7,
+			mockBehavior: func(ctx context.Context, form_id string, from int) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET position_ = position_ + 1 WHERE form_id_ = $1 AND position_ >= $2", 12, from).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			from:         1,
+			mockBehavior: func(ctx context.Context, form_id string, from int) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "exec_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			from:     1,
+			mockBehavior: func(ctx context.Context, form_id string, from int) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET position_ = position_ + 1 WHERE form_id_ = $1 AND position_ >= $2", 12, from).Return(nil, errors.New("exec_error"))
+			},
+			expectedErr: errors.New("exec_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.from)
+
+			err := r.ShiftPositions(testCase.ctx, testCase.form_id, testCase.from)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestQuestionRepo_Reorder(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewQuestionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string, question_ids []string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		question_ids []string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest:     "ok",
+			ctx:          context.Background(),
+			form_id:      "12",
+			question_ids: []string{"346", "345"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET position_ = array_position($1::int[], id_) WHERE form_id_ = $2", []int{346, 345}, 12).Return(pgxmock.NewResult("UPDATE", 2), nil)
+			},
+		},
+		{
+			nameTest:     "no_questions",
+			ctx:          context.Background(),
+			form_id:      "12",
+			question_ids: []string{},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET position_ = array_position($1::int[], id_) WHERE form_id_ = $2", []int{}, 12).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+		{
+			nameTest:     "invalid_form_id",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			question_ids: []string{"345"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "invalid_question_id",
+			ctx:          context.Background(),
+			form_id:      "12",
+			question_ids: []string{"345", "x"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "exec_error",
+			ctx:          context.Background(),
+			form_id:      "12",
+			question_ids: []string{"345"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE question_ SET position_ = array_position($1::int[], id_) WHERE form_id_ = $2", []int{345}, 12).Return(nil, errors.New("exec_error"))
+			},
+			expectedErr: errors.New("exec_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.question_ids)
+
+			err := r.Reorder(testCase.ctx, testCase.form_id, testCase.question_ids)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func intRef(i int) *int {
+	return &i
+}
+
+func strRef(s string) *string {
+	return &s
+}
@@ -9,23 +9,25 @@ import (
 type UseCase interface {
 	// Returns created model & nil, if created.
 	// Returns nil & ErrContentNotFound, if no such form.
-	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrInvalidContent, if invalid inputs, section is not of form or condition is invalid.
 	// Returns nil & ErrUnauthorized, if user unauthorized.
-	// Returns nil & ErrForbidden, if user is not form owner or permission denied.
+	// Returns nil & ErrForbidden, if user is neither form owner nor editor or permission denied.
 	// Returns nil & other err else.
 	Create(ctx context.Context, model *models.Question) (*models.Question, error)
 
 	// Returns slice & nil, if get smth.
 	// Returns empty slice & nil, if get nothing.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither form owner or member nor moderator or admin.
 	// Returns nil & other err else.
 	GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.Question, error)
 
 	// Returns source model & nil, if updated.
 	// Returns nil & ErrContentNotFound, if no such model.
-	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrInvalidContent, if invalid inputs, section is not of form or condition is invalid.
 	// Returns nil & ErrUnauthorized, if user unauthorized.
-	// Returns nil & ErrForbidden, if user is not form owner or permission denied.
+	// Returns nil & ErrForbidden, if user is neither form owner nor editor or permission denied.
 	// Returns nil & other err else.
 	Update(ctx context.Context, model *models.Question) (*models.Question, error)
 
@@ -33,7 +35,16 @@ type UseCase interface {
 	// Returns nil & ErrContentNotFound, if no such form or question.
 	// Returns nil & ErrInvalidContent, if invalid inputs.
 	// Returns nil & ErrUnauthorized, if user unauthorized.
-	// Returns nil & ErrForbidden, if user is not form owner or permission denied.
+	// Returns nil & ErrForbidden, if user is neither form owner nor editor or permission denied.
 	// Returns nil & other err else.
 	Delete(ctx context.Context, id string) error
+
+	// Sets order of form questions, question_ids has to list all form questions.
+	// Returns nil, if reordered.
+	// Returns ErrContentNotFound, if no such form or form has no questions.
+	// Returns ErrInvalidContent, if invalid inputs or ids are not exactly form questions.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if user is neither form owner nor editor or permission denied.
+	// Returns other err else.
+	Reorder(ctx context.Context, form_id string, question_ids []string) error
 }
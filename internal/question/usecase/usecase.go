@@ -2,39 +2,121 @@ package usecase
 
 import (
 	"context"
+	"quizapp/internal/authz"
 	"quizapp/internal/form"
 	"quizapp/internal/question"
+	"quizapp/internal/section"
 	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/transactor"
 	"quizapp/pkg/types"
 )
 
 type questionUseCase struct {
-	qRepo question.Repo
-	fRepo form.Repo
+	qRepo      question.Repo
+	fRepo      form.Repo
+	authorizer authz.Authorizer
+	sRepo      section.Repo
+	transactor transactor.Transactor
 }
 
-func NewQuestionUseCase(qRepo question.Repo, fRepo form.Repo) question.UseCase {
+func NewQuestionUseCase(qRepo question.Repo, fRepo form.Repo, authorizer authz.Authorizer, sRepo section.Repo, transactor transactor.Transactor) question.UseCase {
 	return &questionUseCase{
-		qRepo: qRepo,
-		fRepo: fRepo,
+		qRepo:      qRepo,
+		fRepo:      fRepo,
+		authorizer: authorizer,
+		sRepo:      sRepo,
+		transactor: transactor,
 	}
 }
 
 func (q *questionUseCase) Create(ctx context.Context, model *models.Question) (*models.Question, error) {
-	err := q.fRepo.ValidateIsOwner(ctx, model.Form_id)
+	// untyped questions are free text
+	if model.Type == "" {
+		model.Type = models.QuestionTypeText
+	}
+
+	err := validateQuestion(model)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.authorizer.AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit)
 	if err != nil {
 		return nil, err
 	}
 
-	return q.qRepo.Create(ctx, model)
+	err = q.validateSection(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.validateCondition(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdquestion *models.Question
+
+	err = q.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		// form lock serializes position changes of its questions
+		err = q.fRepo.LockById(ctx, model.Form_id)
+		if err != nil {
+			return err
+		}
+
+		if model.Position > 0 {
+			err = q.qRepo.ShiftPositions(ctx, model.Form_id, model.Position)
+			if err != nil {
+				return err
+			}
+		}
+
+		createdquestion, err = q.qRepo.Create(ctx, model)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdquestion, nil
 }
 
 func (q *questionUseCase) GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.Question, error) {
+	err := q.authorizer.AuthorizeForm(ctx, form_id, models.ActionFormView)
+	if err != nil {
+		return nil, err
+	}
+
 	return q.qRepo.GetByFormId(ctx, form_id, sets)
 }
 
 func (q *questionUseCase) Update(ctx context.Context, model *models.Question) (*models.Question, error) {
-	err := q.fRepo.ValidateIsOwner(ctx, model.Form_id)
+	err := validateQuestion(model)
+	if err != nil {
+		return nil, err
+	}
+
+	foundquestion, err := q.qRepo.GetById(ctx, model.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if foundquestion.Form_id != model.Form_id {
+		return nil, errs.ErrContentNotFound
+	}
+
+	err = q.authorizer.AuthorizeForm(ctx, foundquestion.Form_id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.validateSection(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	err = q.validateCondition(ctx, model)
 	if err != nil {
 		return nil, err
 	}
@@ -44,6 +126,8 @@ func (q *questionUseCase) Update(ctx context.Context, model *models.Question) (*
 		return nil, err
 	}
 
+	model.Position = foundquestion.Position
+
 	return model, nil
 }
 
@@ -53,7 +137,7 @@ func (q *questionUseCase) Delete(ctx context.Context, id string) error {
 		return err
 	}
 
-	err = q.fRepo.ValidateIsOwner(ctx, foundquestion.Form_id)
+	err = q.authorizer.AuthorizeForm(ctx, foundquestion.Form_id, models.ActionFormEdit)
 	if err != nil {
 		return err
 	}
@@ -65,3 +149,105 @@ func (q *questionUseCase) Delete(ctx context.Context, id string) error {
 
 	return nil
 }
+
+func (q *questionUseCase) Reorder(ctx context.Context, form_id string, question_ids []string) error {
+	err := q.authorizer.AuthorizeForm(ctx, form_id, models.ActionFormEdit)
+	if err != nil {
+		return err
+	}
+
+	return q.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err = q.fRepo.LockById(ctx, form_id)
+		if err != nil {
+			return err
+		}
+
+		foundquestions, err := q.qRepo.GetAllByFormId(ctx, form_id)
+		if err != nil {
+			return err
+		}
+
+		if !isPermutation(foundquestions, question_ids) {
+			return errs.ErrInvalidContent
+		}
+
+		return q.qRepo.Reorder(ctx, form_id, question_ids)
+	})
+}
+
+// order has to list every question of form exactly once
+func isPermutation(questions []*models.Question, question_ids []string) bool {
+	if len(questions) != len(question_ids) {
+		return false
+	}
+
+	left := make(map[string]bool, len(questions))
+	for _, question := range questions {
+		left[question.Id] = true
+	}
+
+	for _, id := range question_ids {
+		if !left[id] {
+			return false
+		}
+
+		delete(left, id)
+	}
+
+	return true
+}
+
+// question can be put in section of its form only
+func (q *questionUseCase) validateSection(ctx context.Context, model *models.Question) error {
+	if model.Section_id == "" {
+		return nil
+	}
+
+	foundsection, err := q.sRepo.GetById(ctx, model.Section_id)
+	if err != nil {
+		if err == errs.ErrContentNotFound {
+			return errs.ErrInvalidContent
+		}
+
+		return err
+	}
+
+	if foundsection.Form_id != model.Form_id {
+		return errs.ErrInvalidContent
+	}
+
+	return nil
+}
+
+// condition has to refer to other question of form,
+// order of questions is checked on publish
+func (q *questionUseCase) validateCondition(ctx context.Context, model *models.Question) error {
+	if model.Condition == nil {
+		return nil
+	}
+
+	referred, err := q.qRepo.GetById(ctx, model.Condition.Question_id)
+	if err != nil {
+		if err == errs.ErrContentNotFound {
+			return errs.ErrInvalidContent
+		}
+
+		return err
+	}
+
+	if referred.Form_id != model.Form_id || referred.Id == model.Id || !model.Condition.Validate(referred) {
+		return errs.ErrInvalidContent
+	}
+
+	return nil
+}
+
+// type is required on update, so options of typed question
+// are never silently replaced with free text
+func validateQuestion(model *models.Question) error {
+	if !model.ValidateOptions() || !model.ValidateRules() || !model.ValidateScoring() {
+		return errs.ErrInvalidContent
+	}
+
+	return nil
+}
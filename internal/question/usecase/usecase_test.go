@@ -3,10 +3,14 @@ package usecase_test
 import (
 	"context"
 	"errors"
+	mockauthz "quizapp/internal/authz/mock"
 	mockf "quizapp/internal/form/mock"
 	mockq "quizapp/internal/question/mock"
 	"quizapp/internal/question/usecase"
+	mocksec "quizapp/internal/section/mock"
 	"quizapp/models"
+	"quizapp/pkg/errs"
+	mocktx "quizapp/pkg/transactor/mock"
 	"quizapp/pkg/types"
 	"testing"
 
@@ -20,9 +24,12 @@ func TestQuestionUseCase_Create(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
 	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewQuestionUseCase(mockRepoQ, mockRepoF)
+	uc := usecase.NewQuestionUseCase(mockRepoQ, mockRepoF, mockAuthz, mockRepoS, mockTx)
 
 	type mockBehavior func(ctx context.Context, model *models.Question)
 
@@ -41,7 +48,9 @@ func TestQuestionUseCase_Create(t *testing.T) {
 				Header:  "header",
 			},
 			mockBehavior: func(ctx context.Context, model *models.Question) {
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, model.Form_id).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, model.Form_id).Return(nil)
 				mockRepoQ.EXPECT().Create(ctx, model).Return(&models.Question{
 					Id:      "1",
 					Form_id: model.Form_id,
@@ -54,6 +63,255 @@ func TestQuestionUseCase_Create(t *testing.T) {
 				Header:  "header",
 			},
 		},
+		{
+			nameTest: "ok_typed",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id: "5",
+				Header:  "header",
+				Type:    models.QuestionTypeMultipleChoice,
+				Options: models.QuestionOptions{
+					Choices: []string{"a", "b"},
+				},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, model.Form_id).Return(nil)
+				mockRepoQ.EXPECT().Create(ctx, model).Return(&models.Question{
+					Id:      "1",
+					Form_id: model.Form_id,
+					Header:  model.Header,
+					Type:    model.Type,
+					Options: model.Options,
+				}, nil)
+			},
+			expectedModel: models.Question{
+				Id:      "1",
+				Form_id: "5",
+				Header:  "header",
+				Type:    models.QuestionTypeMultipleChoice,
+				Options: models.QuestionOptions{
+					Choices: []string{"a", "b"},
+				},
+			},
+		},
+		{
+			nameTest: "ok_at_position",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id:  "5",
+				Header:   "header",
+				Position: 2,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, model.Form_id).Return(nil)
+				mockRepoQ.EXPECT().ShiftPositions(ctx, model.Form_id, 2).Return(nil)
+				mockRepoQ.EXPECT().Create(ctx, model).Return(&models.Question{
+					Id:       "1",
+					Form_id:  model.Form_id,
+					Header:   model.Header,
+					Position: 2,
+				}, nil)
+			},
+			expectedModel: models.Question{
+				Id:       "1",
+				Form_id:  "5",
+				Header:   "header",
+				Position: 2,
+			},
+		},
+		{
+			nameTest: "shift_error",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id:  "5",
+				Header:   "header",
+				Position: 2,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, model.Form_id).Return(nil)
+				mockRepoQ.EXPECT().ShiftPositions(ctx, model.Form_id, 2).Return(errors.New("exec_error"))
+			},
+		},
+		{
+			nameTest: "section_of_other_form",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id:    "5",
+				Section_id: "7",
+				Header:     "header",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoS.EXPECT().GetById(ctx, model.Section_id).Return(&models.Section{Id: "7", Form_id: "6"}, nil)
+			},
+		},
+		{
+			nameTest: "ok_conditional",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id: "5",
+				Header:  "header",
+				Condition: &models.Condition{
+					Question_id: "3",
+					Op:          models.ConditionOpEquals,
+					Value:       "a",
+				},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, "3").Return(&models.Question{
+					Id:      "3",
+					Form_id: "5",
+					Type:    models.QuestionTypeSingleChoice,
+					Options: models.QuestionOptions{
+						Choices: []string{"a", "b"},
+					},
+				}, nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, model.Form_id).Return(nil)
+				mockRepoQ.EXPECT().Create(ctx, model).Return(&models.Question{
+					Id:        "4",
+					Form_id:   model.Form_id,
+					Header:    model.Header,
+					Condition: model.Condition,
+				}, nil)
+			},
+			expectedModel: models.Question{
+				Id:      "4",
+				Form_id: "5",
+				Header:  "header",
+				Condition: &models.Condition{
+					Question_id: "3",
+					Op:          models.ConditionOpEquals,
+					Value:       "a",
+				},
+			},
+		},
+		{
+			nameTest: "condition_value_not_a_choice",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id: "5",
+				Header:  "header",
+				Condition: &models.Condition{
+					Question_id: "3",
+					Op:          models.ConditionOpEquals,
+					Value:       "c",
+				},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, "3").Return(&models.Question{
+					Id:      "3",
+					Form_id: "5",
+					Type:    models.QuestionTypeSingleChoice,
+					Options: models.QuestionOptions{
+						Choices: []string{"a", "b"},
+					},
+				}, nil)
+			},
+		},
+		{
+			nameTest: "condition_on_question_of_other_form",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id: "5",
+				Header:  "header",
+				Condition: &models.Condition{
+					Question_id: "3",
+					Op:          models.ConditionOpAnswered,
+				},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, "3").Return(&models.Question{Id: "3", Form_id: "6"}, nil)
+			},
+		},
+		{
+			nameTest: "condition_on_missing_question",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id: "5",
+				Header:  "header",
+				Condition: &models.Condition{
+					Question_id: "3",
+					Op:          models.ConditionOpAnswered,
+				},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, "3").Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "unknown_type",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id: "5",
+				Header:  "header",
+				Type:    "color",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {},
+		},
+		{
+			nameTest: "invalid_options",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id: "5",
+				Header:  "header",
+				Type:    models.QuestionTypeScale,
+				Options: models.QuestionOptions{
+					Choices: []string{"a"},
+				},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {},
+		},
+		{
+			nameTest: "rules_on_choice_question",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id: "5",
+				Header:  "header",
+				Type:    models.QuestionTypeSingleChoice,
+				Options: models.QuestionOptions{
+					Choices: []string{"a"},
+				},
+				Rules: models.QuestionRules{Format: models.RuleFormatEmail},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {},
+		},
+		{
+			nameTest: "invalid_pattern",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id:  "5",
+				Header:   "header",
+				Required: true,
+				Rules:    models.QuestionRules{Pattern: "[a-"},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {},
+		},
+		{
+			nameTest: "correct_answer_not_a_choice",
+			ctx:      context.Background(),
+			model: models.Question{
+				Form_id: "5",
+				Header:  "header",
+				Type:    models.QuestionTypeSingleChoice,
+				Options: models.QuestionOptions{
+					Choices: []string{"a", "b"},
+				},
+				Correct: "c",
+				Points:  1,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {},
+		},
 		{
 			nameTest: "user_is_not_an_owner",
 			ctx:      context.Background(),
@@ -62,7 +320,7 @@ func TestQuestionUseCase_Create(t *testing.T) {
 				Header:  "header",
 			},
 			mockBehavior: func(ctx context.Context, model *models.Question) {
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, model.Form_id).Return(errors.New("user_is_not_an_owner"))
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(errors.New("user_is_not_an_owner"))
 			},
 		},
 	}
@@ -74,10 +332,12 @@ func TestQuestionUseCase_Create(t *testing.T) {
 			got, err := uc.Create(testCase.ctx, &testCase.model)
 
 			switch testCase.nameTest {
-			case "ok":
+			case "ok", "ok_typed", "ok_at_position", "ok_conditional":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedModel, *got)
-			case "user_is_not_an_owner":
+			case "unknown_type", "invalid_options", "section_of_other_form", "condition_value_not_a_choice", "condition_on_question_of_other_form", "condition_on_missing_question", "rules_on_choice_question", "invalid_pattern", "correct_answer_not_a_choice":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "user_is_not_an_owner", "shift_error":
 				assert.NotEqual(t, nil, err)
 			default:
 				assert.Error(t, errors.New("No case"), "No case")
@@ -92,9 +352,12 @@ func TestQuestionUseCase_GetByFormId(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
 	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewQuestionUseCase(mockRepoQ, mockRepoF)
+	uc := usecase.NewQuestionUseCase(mockRepoQ, mockRepoF, mockAuthz, mockRepoS, mockTx)
 
 	type mockBehavior func(ctx context.Context, form_id string, sets types.GetSets)
 
@@ -112,11 +375,13 @@ func TestQuestionUseCase_GetByFormId(t *testing.T) {
 			form_id:  "5",
 			sets:     types.GetSets{},
 			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(nil)
 				mockRepoQ.EXPECT().GetByFormId(ctx, form_id, sets).Return([]*models.Question{
 					{
 						Id:      "1",
 						Form_id: form_id,
 						Header:  "header1",
+						Correct: "answer",
 					},
 					{
 						Id:      "2",
@@ -130,6 +395,7 @@ func TestQuestionUseCase_GetByFormId(t *testing.T) {
 					Id:      "1",
 					Form_id: "5",
 					Header:  "header1",
+					Correct: "answer",
 				},
 				{
 					Id:      "2",
@@ -138,6 +404,16 @@ func TestQuestionUseCase_GetByFormId(t *testing.T) {
 				},
 			},
 		},
+		{
+			// correct answers of quiz are not shown to respondents
+			nameTest: "user_is_not_a_member",
+			ctx:      context.Background(),
+			form_id:  "5",
+			sets:     types.GetSets{},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(errs.ErrForbidden)
+			},
+		},
 	}
 
 	for _, testCase := range testTable {
@@ -150,6 +426,9 @@ func TestQuestionUseCase_GetByFormId(t *testing.T) {
 			case "ok":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedModels, got)
+			case "user_is_not_a_member":
+				assert.Equal(t, errs.ErrForbidden, err)
+				assert.Equal(t, []*models.Question(nil), got)
 			default:
 				assert.Error(t, errors.New("No case"), "No case")
 			}
@@ -163,12 +442,17 @@ func TestQuestionUseCase_Update(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
 	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewQuestionUseCase(mockRepoQ, mockRepoF)
+	uc := usecase.NewQuestionUseCase(mockRepoQ, mockRepoF, mockAuthz, mockRepoS, mockTx)
 
 	type mockBehavior func(ctx context.Context, model *models.Question)
 
+	stored := &models.Question{Id: "1", Form_id: "5", Header: "old", Type: models.QuestionTypeText, Position: 3}
+
 	testTable := []struct {
 		nameTest      string
 		ctx           context.Context
@@ -183,16 +467,98 @@ func TestQuestionUseCase_Update(t *testing.T) {
 				Id:      "1",
 				Form_id: "5",
 				Header:  "header",
+				Type:    models.QuestionTypeText,
 			},
 			mockBehavior: func(ctx context.Context, model *models.Question) {
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, model.Form_id).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, model.Id).Return(stored, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, stored.Form_id, models.ActionFormEdit).Return(nil)
 				mockRepoQ.EXPECT().Update(ctx, model).Return(nil, nil)
 			},
 			expectedModel: models.Question{
+				Id:       "1",
+				Form_id:  "5",
+				Header:   "header",
+				Type:     models.QuestionTypeText,
+				Position: 3,
+			},
+		},
+		{
+			nameTest: "ok_in_section",
+			ctx:      context.Background(),
+			model: models.Question{
+				Id:         "1",
+				Form_id:    "5",
+				Section_id: "7",
+				Header:     "header",
+				Type:       models.QuestionTypeText,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockRepoQ.EXPECT().GetById(ctx, model.Id).Return(stored, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, stored.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoS.EXPECT().GetById(ctx, model.Section_id).Return(&models.Section{Id: "7", Form_id: "5"}, nil)
+				mockRepoQ.EXPECT().Update(ctx, model).Return(nil, nil)
+			},
+			expectedModel: models.Question{
+				Id:         "1",
+				Form_id:    "5",
+				Section_id: "7",
+				Header:     "header",
+				Type:       models.QuestionTypeText,
+				Position:   3,
+			},
+		},
+		{
+			nameTest: "section_of_other_form",
+			ctx:      context.Background(),
+			model: models.Question{
+				Id:         "1",
+				Form_id:    "5",
+				Section_id: "7",
+				Header:     "header",
+				Type:       models.QuestionTypeText,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockRepoQ.EXPECT().GetById(ctx, model.Id).Return(stored, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, stored.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoS.EXPECT().GetById(ctx, model.Section_id).Return(&models.Section{Id: "7", Form_id: "6"}, nil)
+			},
+		},
+		{
+			nameTest: "no_such_section",
+			ctx:      context.Background(),
+			model: models.Question{
+				Id:         "1",
+				Form_id:    "5",
+				Section_id: "7",
+				Header:     "header",
+				Type:       models.QuestionTypeText,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockRepoQ.EXPECT().GetById(ctx, model.Id).Return(stored, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, stored.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoS.EXPECT().GetById(ctx, model.Section_id).Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "no_type",
+			ctx:      context.Background(),
+			model: models.Question{
+				Id:      "1",
+				Form_id: "5",
+				Header:  "header",
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {},
+		},
+		{
+			nameTest: "invalid_options",
+			ctx:      context.Background(),
+			model: models.Question{
 				Id:      "1",
 				Form_id: "5",
 				Header:  "header",
+				Type:    models.QuestionTypeSingleChoice,
 			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {},
 		},
 		{
 			nameTest: "user_is_not_an_owner",
@@ -201,9 +567,57 @@ func TestQuestionUseCase_Update(t *testing.T) {
 				Id:      "1",
 				Form_id: "5",
 				Header:  "header",
+				Type:    models.QuestionTypeText,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockRepoQ.EXPECT().GetById(ctx, model.Id).Return(stored, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, stored.Form_id, models.ActionFormEdit).Return(errors.New("user_is_not_an_owner"))
+			},
+		},
+		{
+			// form of path has to be form of question
+			nameTest: "question_of_other_form",
+			ctx:      context.Background(),
+			model: models.Question{
+				Id:      "1",
+				Form_id: "6",
+				Header:  "header",
+				Type:    models.QuestionTypeText,
 			},
 			mockBehavior: func(ctx context.Context, model *models.Question) {
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, model.Form_id).Return(errors.New("user_is_not_an_owner"))
+				mockRepoQ.EXPECT().GetById(ctx, model.Id).Return(stored, nil)
+			},
+		},
+		{
+			nameTest: "no_such_question",
+			ctx:      context.Background(),
+			model: models.Question{
+				Id:      "1",
+				Form_id: "5",
+				Header:  "header",
+				Type:    models.QuestionTypeText,
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockRepoQ.EXPECT().GetById(ctx, model.Id).Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "condition_on_itself",
+			ctx:      context.Background(),
+			model: models.Question{
+				Id:      "1",
+				Form_id: "5",
+				Header:  "header",
+				Type:    models.QuestionTypeText,
+				Condition: &models.Condition{
+					Question_id: "1",
+					Op:          models.ConditionOpAnswered,
+				},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Question) {
+				mockRepoQ.EXPECT().GetById(ctx, model.Id).Return(stored, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, stored.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, "1").Return(&models.Question{Id: "1", Form_id: "5"}, nil)
 			},
 		},
 		{
@@ -213,9 +627,11 @@ func TestQuestionUseCase_Update(t *testing.T) {
 				Id:      "1",
 				Form_id: "5",
 				Header:  "header",
+				Type:    models.QuestionTypeText,
 			},
 			mockBehavior: func(ctx context.Context, model *models.Question) {
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, model.Form_id).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, model.Id).Return(stored, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, stored.Form_id, models.ActionFormEdit).Return(nil)
 				mockRepoQ.EXPECT().Update(ctx, model).Return(nil, errors.New("repo_update_error"))
 			},
 		},
@@ -228,9 +644,13 @@ func TestQuestionUseCase_Update(t *testing.T) {
 			got, err := uc.Update(testCase.ctx, &testCase.model)
 
 			switch testCase.nameTest {
-			case "ok":
+			case "ok", "ok_in_section":
 				assert.Equal(t, nil, err)
 				assert.Equal(t, testCase.expectedModel, *got)
+			case "no_type", "invalid_options", "section_of_other_form", "no_such_section", "condition_on_itself":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "question_of_other_form", "no_such_question":
+				assert.Equal(t, errs.ErrContentNotFound, err)
 			case "user_is_not_an_owner", "repo_update_error":
 				assert.NotEqual(t, nil, err)
 			default:
@@ -246,9 +666,12 @@ func TestQuestionUseCase_Delete(t *testing.T) {
 	defer ctrl.Finish()
 
 	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
 	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
 
-	uc := usecase.NewQuestionUseCase(mockRepoQ, mockRepoF)
+	uc := usecase.NewQuestionUseCase(mockRepoQ, mockRepoF, mockAuthz, mockRepoS, mockTx)
 
 	type mockBehavior func(ctx context.Context, id string)
 
@@ -269,7 +692,7 @@ func TestQuestionUseCase_Delete(t *testing.T) {
 					Form_id: formid,
 					Header:  "header",
 				}, nil)
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, formid).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, formid, models.ActionFormEdit).Return(nil)
 				mockRepoQ.EXPECT().Delete(ctx, id).Return(nil)
 			},
 		},
@@ -284,7 +707,7 @@ func TestQuestionUseCase_Delete(t *testing.T) {
 					Form_id: formid,
 					Header:  "header",
 				}, nil)
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, formid).Return(errors.New("user_is_not_an_owner"))
+				mockAuthz.EXPECT().AuthorizeForm(ctx, formid, models.ActionFormEdit).Return(errors.New("user_is_not_an_owner"))
 			},
 		},
 		{
@@ -298,7 +721,7 @@ func TestQuestionUseCase_Delete(t *testing.T) {
 					Form_id: formid,
 					Header:  "header",
 				}, nil)
-				mockRepoF.EXPECT().ValidateIsOwner(ctx, formid).Return(nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, formid, models.ActionFormEdit).Return(nil)
 				mockRepoQ.EXPECT().Delete(ctx, id).Return(errors.New("repo_delete_error"))
 			},
 		},
@@ -329,3 +752,127 @@ func TestQuestionUseCase_Delete(t *testing.T) {
 		})
 	}
 }
+
+func TestQuestionUseCase_Reorder(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewQuestionUseCase(mockRepoQ, mockRepoF, mockAuthz, mockRepoS, mockTx)
+
+	type mockBehavior func(ctx context.Context, form_id string, question_ids []string)
+
+	foundquestions := []*models.Question{
+		{Id: "1", Form_id: "5", Header: "a", Position: 1},
+		{Id: "2", Form_id: "5", Header: "b", Position: 2},
+		{Id: "3", Form_id: "5", Header: "c", Position: 3},
+	}
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		question_ids []string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest:     "ok",
+			ctx:          context.Background(),
+			form_id:      "5",
+			question_ids: []string{"3", "1", "2"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(foundquestions, nil)
+				mockRepoQ.EXPECT().Reorder(ctx, form_id, question_ids).Return(nil)
+			},
+		},
+		{
+			nameTest:     "missing_question",
+			ctx:          context.Background(),
+			form_id:      "5",
+			question_ids: []string{"3", "1"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(foundquestions, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "duplicate_question",
+			ctx:          context.Background(),
+			form_id:      "5",
+			question_ids: []string{"3", "1", "3"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(foundquestions, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "question_of_other_form",
+			ctx:          context.Background(),
+			form_id:      "5",
+			question_ids: []string{"3", "1", "4"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(foundquestions, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "user_is_not_an_owner",
+			ctx:          context.Background(),
+			form_id:      "5",
+			question_ids: []string{"3", "1", "2"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest:     "reorder_error",
+			ctx:          context.Background(),
+			form_id:      "5",
+			question_ids: []string{"3", "1", "2"},
+			mockBehavior: func(ctx context.Context, form_id string, question_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(foundquestions, nil)
+				mockRepoQ.EXPECT().Reorder(ctx, form_id, question_ids).Return(errors.New("exec_error"))
+			},
+			expectedErr: errors.New("exec_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.question_ids)
+
+			err := uc.Reorder(testCase.ctx, testCase.form_id, testCase.question_ids)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func expectTx(ctx context.Context, mockTx *mocktx.MockTransactor) {
+	mockTx.EXPECT().WithinTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+}
@@ -0,0 +1,12 @@
+package section
+
+import "github.com/gin-gonic/gin"
+
+// Section HTTP Handlers interface
+type Handlers interface {
+	Create() gin.HandlerFunc
+	GetByFormId() gin.HandlerFunc
+	Update() gin.HandlerFunc
+	Delete() gin.HandlerFunc
+	Reorder() gin.HandlerFunc
+}
@@ -0,0 +1,318 @@
+package http
+
+import (
+	"net/http"
+	"quizapp/internal/section"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/questiondto"
+
+	"github.com/gin-gonic/gin"
+)
+
+type sectionCreateRequest struct {
+	Title       string `json:"title" binding:"required"`
+	Description string `json:"description"`
+	// Place to insert section at, following sections are moved down. Section is appended, if not set. Not changed by update
+	Position int `json:"position" minimum:"0"`
+	// Section is shown only when condition on earlier answer holds, always shown, if not set
+	Condition *conditionDTO `json:"condition"`
+}
+
+type conditionDTO struct {
+	Question_id string `json:"question_id" binding:"required"`
+	Op          string `json:"op" enums:"equals,not_equals,answered,not_answered"`
+	// Value to compare answer with, has to be empty for answered ops
+	Value string `json:"value,omitempty"`
+}
+
+type questionResponse struct {
+	Id        string              `json:"id"`
+	Header    string              `json:"header"`
+	Type      string              `json:"type"`
+	Options   questiondto.Options `json:"options"`
+	Position  int                 `json:"position"`
+	Condition *conditionDTO       `json:"condition,omitempty"`
+	Required  bool                `json:"required"`
+	Rules     questiondto.Rules   `json:"rules"`
+	Correct   string              `json:"correct,omitempty"`
+	Points    int                 `json:"points"`
+}
+
+type sectionResponse struct {
+	Id          string              `json:"id"`
+	Form_id     string              `json:"form_id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Position    int                 `json:"position"`
+	Condition   *conditionDTO       `json:"condition,omitempty"`
+	Questions   []*questionResponse `json:"questions,omitempty"`
+}
+
+type sectionsResponse struct {
+	Sections []*sectionResponse `json:"sections"`
+	// Questions out of sections
+	Questions []*questionResponse `json:"questions"`
+}
+
+type sectionReorderRequest struct {
+	Section_ids []string `json:"section_ids" binding:"required"`
+}
+
+type sectionHandlers struct {
+	sectionUC  section.UseCase
+	ctxUserKey string
+}
+
+func NewSectionHandlers(sectionUC section.UseCase, ctxUserKey string) section.Handlers {
+	return &sectionHandlers{
+		sectionUC:  sectionUC,
+		ctxUserKey: ctxUserKey,
+	}
+}
+
+// Create godoc
+// @Summary Create section
+// @Description Create new section (page) with title and description for form
+// @Tags Sections
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param data body sectionCreateRequest true "section title, description and position"
+// @Success 201 {object} sectionResponse
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid json or condition"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner nor editor or permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/sections [post]
+func (h *sectionHandlers) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(sectionCreateRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		modelBL := sectionRequestToBL(request)
+		modelBL.Form_id = c.Param("formid")
+
+		createdsection, err := h.sectionUC.Create(c, modelBL)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, sectionBLToResponse(createdsection))
+	}
+}
+
+// GetByFormId godoc
+// @Summary Get sections
+// @Description Get form sections in order with their questions, and questions out of sections
+// @Tags Sections
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Success 200 {object} sectionsResponse "Found"
+// @Failure 204 {object} sectionsResponse "No sections and questions by form"
+// @Failure 400   "Invalid form id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner or member nor moderator or admin"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/sections [get]
+func (h *sectionHandlers) GetByFormId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		foundsections, unsectioned, err := h.sectionUC.GetByFormId(c, c.Param("formid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		status := http.StatusOK
+
+		if len(foundsections) == 0 && len(unsectioned) == 0 {
+			status = http.StatusNoContent
+		}
+
+		res := &sectionsResponse{
+			Sections:  make([]*sectionResponse, len(foundsections)),
+			Questions: questionsBLToResponse(unsectioned),
+		}
+
+		for i, s := range foundsections {
+			res.Sections[i] = sectionBLToResponse(s)
+		}
+
+		c.JSON(status, res)
+	}
+}
+
+// Update godoc
+// @Summary Update section
+// @Description Update section title and description
+// @Tags Sections
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param sectionid path string true "section id"
+// @Param data body sectionCreateRequest true "new title and description"
+// @Success 200 {object} sectionResponse "Updated"
+// @Failure 204   "No such section in form"
+// @Failure 400   "Invalid json, section id or condition"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner nor editor or permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/sections/{sectionid} [put]
+func (h *sectionHandlers) Update() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(sectionCreateRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		modelBL := sectionRequestToBL(request)
+		modelBL.Id = c.Param("sectionid")
+		modelBL.Form_id = c.Param("formid")
+
+		updatedsection, err := h.sectionUC.Update(c, modelBL)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, sectionBLToResponse(updatedsection))
+	}
+}
+
+// Delete godoc
+// @Summary Delete section
+// @Description Delete section by id, its questions stay in form out of sections
+// @Tags Sections
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param sectionid path string true "section id"
+// @Success 200   "Deleted"
+// @Failure 204   "No such section"
+// @Failure 400   "Invalid section id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner nor editor or permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/sections/{sectionid} [delete]
+func (h *sectionHandlers) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.sectionUC.Delete(c, c.Param("sectionid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+// Reorder godoc
+// @Summary Reorder sections
+// @Description Set order of all form sections at once
+// @Tags Sections
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param data body sectionReorderRequest true "ids of all form sections in new order"
+// @Success 200   "Reordered"
+// @Failure 204   "No such form or form has no sections"
+// @Failure 400   "Invalid json or ids are not exactly form sections"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner nor editor or permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/sections/order [put]
+func (h *sectionHandlers) Reorder() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(sectionReorderRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		err = h.sectionUC.Reorder(c, c.Param("formid"), request.Section_ids)
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+func sectionRequestToBL(dto *sectionCreateRequest) *models.Section {
+	return &models.Section{
+		Title:       dto.Title,
+		Description: dto.Description,
+		Position:    dto.Position,
+		Condition:   conditionRequestToBL(dto.Condition),
+	}
+}
+
+func sectionBLToResponse(modelBL *models.Section) *sectionResponse {
+	return &sectionResponse{
+		Id:          modelBL.Id,
+		Form_id:     modelBL.Form_id,
+		Title:       modelBL.Title,
+		Description: modelBL.Description,
+		Position:    modelBL.Position,
+		Condition:   conditionBLToResponse(modelBL.Condition),
+		Questions:   questionsBLToResponse(modelBL.Questions),
+	}
+}
+
+func questionsBLToResponse(questions []*models.Question) []*questionResponse {
+	if questions == nil {
+		return nil
+	}
+
+	res := make([]*questionResponse, len(questions))
+
+	for i, q := range questions {
+		res[i] = &questionResponse{
+			Id:        q.Id,
+			Header:    q.Header,
+			Type:      q.Type,
+			Options:   questiondto.OptionsBLToDTO(&q.Options),
+			Position:  q.Position,
+			Condition: conditionBLToResponse(q.Condition),
+			Required:  q.Required,
+			Rules:     questiondto.RulesBLToDTO(&q.Rules),
+			Correct:   q.Correct,
+			Points:    q.Points,
+		}
+	}
+
+	return res
+}
+
+func conditionRequestToBL(dto *conditionDTO) *models.Condition {
+	if dto == nil {
+		return nil
+	}
+
+	return &models.Condition{
+		Question_id: dto.Question_id,
+		Op:          dto.Op,
+		Value:       dto.Value,
+	}
+}
+
+func conditionBLToResponse(modelBL *models.Condition) *conditionDTO {
+	if modelBL == nil {
+		return nil
+	}
+
+	return &conditionDTO{
+		Question_id: modelBL.Question_id,
+		Op:          modelBL.Op,
+		Value:       modelBL.Value,
+	}
+}
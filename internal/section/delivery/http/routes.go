@@ -0,0 +1,16 @@
+package http
+
+import (
+	"quizapp/internal/section"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Map section routes
+func MapSectionRoutes(sectionGroup *gin.RouterGroup, h section.Handlers) {
+	sectionGroup.POST("", h.Create())
+	sectionGroup.GET("", h.GetByFormId())
+	sectionGroup.PUT("/order", h.Reorder())
+	sectionGroup.PUT("/:sectionid", h.Update())
+	sectionGroup.DELETE("/:sectionid", h.Delete())
+}
@@ -0,0 +1,56 @@
+package section
+
+import (
+	"context"
+	"quizapp/models"
+)
+
+type Repo interface {
+	// Returns created model & nil, if created.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Create(ctx context.Context, modelBL *models.Section) (*models.Section, error)
+
+	// Returns found model & nil, if get.
+	// Returns nil & ErrContentNotFound, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetById(ctx context.Context, id string) (*models.Section, error)
+
+	// Returns all form sections ordered by position & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetByFormId(ctx context.Context, form_id string) ([]*models.Section, error)
+
+	// Updates title and description.
+	// Returns source model & nil, if updated.
+	// Returns nil & ErrContentNotFound, if nothing to update.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Update(ctx context.Context, modelBL *models.Section) (*models.Section, error)
+
+	// Returns nil, if deleted.
+	// Returns ErrContentNotFound, if nothing to delete.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	Delete(ctx context.Context, id string) error
+
+	// Moves sections of form at position from and after one place down.
+	// Returns nil, if moved or nothing to move.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	ShiftPositions(ctx context.Context, form_id string, from int) error
+
+	// Sets positions of form sections by their place in section_ids.
+	// Returns nil, if reordered.
+	// Returns ErrContentNotFound, if form has no sections.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	Reorder(ctx context.Context, form_id string, section_ids []string) error
+}
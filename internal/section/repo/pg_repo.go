@@ -0,0 +1,323 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/section"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"quizapp/pkg/snapshot"
+	"strconv"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type SectionDB struct {
+	Id, FormId, Position int
+	Title, Description   string
+	Condition            *string
+}
+
+type sectionRepo struct {
+	*postgres.Postgres
+}
+
+func NewSectionRepo(db *postgres.Postgres) section.Repo {
+	return &sectionRepo{db}
+}
+
+func (s *sectionRepo) Create(ctx context.Context, modelBL *models.Section) (*models.Section, error) {
+	sectionDB, err := sectionBLToDB(modelBL)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	// unset position appends section after the last one
+	var position interface{} = sectionDB.Position
+	if sectionDB.Position <= 0 {
+		position = squirrel.Expr("(SELECT COALESCE(MAX(position_), 0) + 1 FROM section_ WHERE form_id_ = ?)", sectionDB.FormId)
+	}
+
+	sql, args, err := s.Builder.
+		Insert("section_").
+		Columns("form_id_, title_, description_, position_, condition_").
+		Values(sectionDB.FormId, sectionDB.Title, sectionDB.Description, position, sectionDB.Condition).
+		Suffix("RETURNING \"id_\", \"position_\"").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&sectionDB.Id, &sectionDB.Position)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return nil, errs.ErrForbidden
+		}
+
+		return nil, err
+	}
+
+	return sectionDBToBL(sectionDB)
+}
+
+func (s *sectionRepo) GetById(ctx context.Context, id string) (*models.Section, error) {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := s.Builder.
+		Select("form_id_, title_, description_, position_, condition_").
+		From("section_").
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	modelDB := SectionDB{Id: intid}
+	err = s.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.FormId, &modelDB.Title, &modelDB.Description, &modelDB.Position, &modelDB.Condition)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return sectionDBToBL(&modelDB)
+}
+
+func (s *sectionRepo) GetByFormId(ctx context.Context, form_id string) ([]*models.Section, error) {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := s.Builder.
+		Select("id_, title_, description_, position_, condition_").
+		From("section_").
+		Where(squirrel.Eq{"form_id_": intformid}).
+		OrderBy("position_, id_").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.Section, 0)
+
+	for rows.Next() {
+		modelDB := SectionDB{FormId: intformid}
+
+		err = rows.Scan(&modelDB.Id, &modelDB.Title, &modelDB.Description, &modelDB.Position, &modelDB.Condition)
+		if err != nil {
+			return nil, err
+		}
+
+		sectionBL, err := sectionDBToBL(&modelDB)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, sectionBL)
+	}
+
+	return res, nil
+}
+
+func (s *sectionRepo) Update(ctx context.Context, modelBL *models.Section) (*models.Section, error) {
+	modelDB, err := sectionBLToDB(modelBL)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := s.Builder.
+		Update("section_").
+		Set("title_", modelDB.Title).
+		Set("description_", modelDB.Description).
+		Set("condition_", modelDB.Condition).
+		Where(squirrel.Eq{"id_": modelDB.Id}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	res, err := s.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return nil, errs.ErrForbidden
+		}
+
+		return nil, err
+	}
+
+	if res.RowsAffected() == 0 {
+		return nil, errs.ErrContentNotFound
+	}
+
+	return modelBL, nil
+}
+
+func (s *sectionRepo) Delete(ctx context.Context, id string) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := s.Builder.
+		Delete("section_").
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := s.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (s *sectionRepo) ShiftPositions(ctx context.Context, form_id string, from int) error {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := s.Builder.
+		Update("section_").
+		Set("position_", squirrel.Expr("position_ + 1")).
+		Where(squirrel.Eq{"form_id_": intformid}).
+		Where(squirrel.GtOrEq{"position_": from}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	_, err = s.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	return nil
+}
+
+func (s *sectionRepo) Reorder(ctx context.Context, form_id string, section_ids []string) error {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	intids := make([]int, len(section_ids))
+	for i, id := range section_ids {
+		intids[i], err = strconv.Atoi(id)
+		if err != nil {
+			return errs.ErrInvalidContent
+		}
+	}
+
+	// single statement, so positions stay unique when checked at commit
+	sql, args, err := s.Builder.
+		Update("section_").
+		Set("position_", squirrel.Expr("array_position(?::int[], id_)", intids)).
+		Where(squirrel.Eq{"form_id_": intformid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := s.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func sectionDBToBL(sectionDB *SectionDB) (*models.Section, error) {
+	condition, err := snapshot.UnmarshalCondition(sectionDB.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.Section{
+		Id:          strconv.Itoa(sectionDB.Id),
+		Form_id:     strconv.Itoa(sectionDB.FormId),
+		Title:       sectionDB.Title,
+		Description: sectionDB.Description,
+		Position:    sectionDB.Position,
+		Condition:   condition,
+	}, nil
+}
+
+func sectionBLToDB(sectionBL *models.Section) (*SectionDB, error) {
+	var (
+		err error
+		id  int
+	)
+
+	if sectionBL.Id != "" {
+		id, err = strconv.Atoi(sectionBL.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	var fid int
+	if sectionBL.Form_id != "" {
+		fid, err = strconv.Atoi(sectionBL.Form_id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	condition, err := snapshot.MarshalCondition(sectionBL.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	return &SectionDB{
+		Id:          id,
+		FormId:      fid,
+		Title:       sectionBL.Title,
+		Description: sectionBL.Description,
+		Position:    sectionBL.Position,
+		Condition:   condition,
+	}, nil
+}
@@ -0,0 +1,571 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/section/repo"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"testing"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+)
+
+func TestSectionRepo_Create(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewSectionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, section *models.Section)
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		section         models.Section
+		mockBehavior    mockBehavior
+		expectedSection *models.Section
+		expectedErr     error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			section: models.Section{
+				Form_id:     "12",
+				Title:       "page",
+				Description: "desc",
+			},
+			mockBehavior: func(ctx context.Context, section *models.Section) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "position_"}).AddRow(345, 3).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO section_ (form_id_, title_, description_, position_, condition_) VALUES ($1,$2,$3,(SELECT COALESCE(MAX(position_), 0) + 1 FROM section_ WHERE form_id_ = $4),$5) RETURNING \"id_\", \"position_\"", 12, section.Title, section.Description, 12, (*string)(nil)).Return(pgxRows)
+			},
+			expectedSection: &models.Section{
+				Id:          "345",
+				Form_id:     "12",
+				Title:       "page",
+				Description: "desc",
+				Position:    3,
+			},
+		},
+		{
+			nameTest: "ok_at_position",
+			ctx:      context.Background(),
+			section: models.Section{
+				Form_id:  "12",
+				Title:    "page",
+				Position: 1,
+			},
+			mockBehavior: func(ctx context.Context, section *models.Section) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "position_"}).AddRow(345, 1).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO section_ (form_id_, title_, description_, position_, condition_) VALUES ($1,$2,$3,$4,$5) RETURNING \"id_\", \"position_\"", 12, section.Title, section.Description, 1, (*string)(nil)).Return(pgxRows)
+			},
+			expectedSection: &models.Section{
+				Id:       "345",
+				Form_id:  "12",
+				Title:    "page",
+				Position: 1,
+			},
+		},
+		{
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			section: models.Section{
+				Form_id: "5r4",
+				Title:   "page",
+			},
+			mockBehavior: func(ctx context.Context, section *models.Section) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "permission_denied",
+			ctx:      context.Background(),
+			section: models.Section{
+				Form_id:  "12",
+				Title:    "page",
+				Position: 1,
+			},
+			mockBehavior: func(ctx context.Context, section *models.Section) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "position_"}).AddRow(nil, nil).RowError(0, &pgconn.PgError{Code: postgres.PermDenied}).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO section_ (form_id_, title_, description_, position_, condition_) VALUES ($1,$2,$3,$4,$5) RETURNING \"id_\", \"position_\"", 12, section.Title, section.Description, 1, (*string)(nil)).Return(pgxRows)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.section)
+
+			got, err := r.Create(testCase.ctx, &testCase.section)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedSection, got)
+		})
+	}
+}
+
+func TestSectionRepo_GetById(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewSectionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		id              string
+		mockBehavior    mockBehavior
+		expectedSection *models.Section
+		expectedErr     error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"form_id_", "title_", "description_", "position_", "condition_"}).AddRow(12, "page", "desc", 2, nil).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT form_id_, title_, description_, position_, condition_ FROM section_ WHERE id_ = $1", 345).Return(pgxRows)
+			},
+			expectedSection: &models.Section{
+				Id:          "345",
+				Form_id:     "12",
+				Title:       "page",
+				Description: "desc",
+				Position:    2,
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"form_id_", "title_", "description_", "position_", "condition_"}).AddRow(nil, nil, nil, nil, nil).RowError(0, pgx.ErrNoRows).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT form_id_, title_, description_, position_, condition_ FROM section_ WHERE id_ = $1", 345).Return(pgxRows)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := r.GetById(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedSection, got)
+		})
+	}
+}
+
+func TestSectionRepo_GetByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewSectionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		form_id          string
+		mockBehavior     mockBehavior
+		expectedSections []*models.Section
+		expectedErr      error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "title_", "description_", "position_", "condition_"}).AddRow(345, "first", "", 1, nil).AddRow(346, "second", "desc", 2, strRef(`{"question_id":7,"op":"equals","value":"yes"}`)).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, "SELECT id_, title_, description_, position_, condition_ FROM section_ WHERE form_id_ = $1 ORDER BY position_, id_", 12).Return(pgxRows, nil)
+			},
+			expectedSections: []*models.Section{
+				{Id: "345", Form_id: "12", Title: "first", Position: 1},
+				{
+					Id:          "346",
+					Form_id:     "12",
+					Title:       "second",
+					Description: "desc",
+					Position:    2,
+					Condition: &models.Condition{
+						Question_id: "7",
+						Op:          models.ConditionOpEquals,
+						Value:       "yes",
+					},
+				},
+			},
+		},
+		{
+			nameTest: "empty",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "title_", "description_", "position_", "condition_"}).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, "SELECT id_, title_, description_, position_, condition_ FROM section_ WHERE form_id_ = $1 ORDER BY position_, id_", 12).Return(pgxRows, nil)
+			},
+			expectedSections: []*models.Section{},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			mockBehavior: func(ctx context.Context, form_id string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockPool.EXPECT().Query(ctx, "SELECT id_, title_, description_, position_, condition_ FROM section_ WHERE form_id_ = $1 ORDER BY position_, id_", 12).Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			got, err := r.GetByFormId(testCase.ctx, testCase.form_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedSections, got)
+		})
+	}
+}
+
+func TestSectionRepo_Update(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewSectionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, section *models.Section)
+
+	section := models.Section{
+		Id:          "345",
+		Form_id:     "12",
+		Title:       "page",
+		Description: "desc",
+	}
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		section         models.Section
+		mockBehavior    mockBehavior
+		expectedSection *models.Section
+		expectedErr     error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			section:  section,
+			mockBehavior: func(ctx context.Context, section *models.Section) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE section_ SET title_ = $1, description_ = $2, condition_ = $3 WHERE id_ = $4", section.Title, section.Description, (*string)(nil), 345).Return(pgxmock.NewResult("UPDATE", 1), nil)
+			},
+			expectedSection: &section,
+		},
+		{
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			section: models.Section{
+				Id:    "5r4",
+				Title: "page",
+			},
+			mockBehavior: func(ctx context.Context, section *models.Section) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "no_section_to_update",
+			ctx:      context.Background(),
+			section:  section,
+			mockBehavior: func(ctx context.Context, section *models.Section) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE section_ SET title_ = $1, description_ = $2, condition_ = $3 WHERE id_ = $4", section.Title, section.Description, (*string)(nil), 345).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+		{
+			nameTest: "permission_denied",
+			ctx:      context.Background(),
+			section:  section,
+			mockBehavior: func(ctx context.Context, section *models.Section) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE section_ SET title_ = $1, description_ = $2, condition_ = $3 WHERE id_ = $4", section.Title, section.Description, (*string)(nil), 345).Return(nil, &pgconn.PgError{Code: postgres.PermDenied})
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.section)
+
+			got, err := r.Update(testCase.ctx, &testCase.section)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedSection, got)
+		})
+	}
+}
+
+func TestSectionRepo_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewSectionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM section_ WHERE id_ = $1", 345).Return(pgxmock.NewResult("DELETE", 1), nil)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "no_section_to_delete",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM section_ WHERE id_ = $1", 345).Return(pgxmock.NewResult("DELETE", 0), nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+		{
+			nameTest: "exec_error",
+			ctx:      context.Background(),
+			id:       "345",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM section_ WHERE id_ = $1", 345).Return(nil, errors.New("exec_error"))
+			},
+			expectedErr: errors.New("exec_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			err := r.Delete(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestSectionRepo_ShiftPositions(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewSectionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string, from int)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		from         int
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			from:     2,
+			mockBehavior: func(ctx context.Context, form_id string, from int) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE section_ SET position_ = position_ + 1 WHERE form_id_ = $1 AND position_ >= $2", 12, from).Return(pgxmock.NewResult("UPDATE", 2), nil)
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			from:         1,
+			mockBehavior: func(ctx context.Context, form_id string, from int) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "exec_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			from:     1,
+			mockBehavior: func(ctx context.Context, form_id string, from int) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE section_ SET position_ = position_ + 1 WHERE form_id_ = $1 AND position_ >= $2", 12, from).Return(nil, errors.New("exec_error"))
+			},
+			expectedErr: errors.New("exec_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.from)
+
+			err := r.ShiftPositions(testCase.ctx, testCase.form_id, testCase.from)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestSectionRepo_Reorder(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewSectionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string, section_ids []string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		section_ids  []string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest:    "ok",
+			ctx:         context.Background(),
+			form_id:     "12",
+			section_ids: []string{"346", "345"},
+			mockBehavior: func(ctx context.Context, form_id string, section_ids []string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE section_ SET position_ = array_position($1::int[], id_) WHERE form_id_ = $2", []int{346, 345}, 12).Return(pgxmock.NewResult("UPDATE", 2), nil)
+			},
+		},
+		{
+			nameTest:    "no_sections",
+			ctx:         context.Background(),
+			form_id:     "12",
+			section_ids: []string{},
+			mockBehavior: func(ctx context.Context, form_id string, section_ids []string) {
+				mockPool.EXPECT().Exec(ctx, "UPDATE section_ SET position_ = array_position($1::int[], id_) WHERE form_id_ = $2", []int{}, 12).Return(pgxmock.NewResult("UPDATE", 0), nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+		{
+			nameTest:     "invalid_section_id",
+			ctx:          context.Background(),
+			form_id:      "12",
+			section_ids:  []string{"x"},
+			mockBehavior: func(ctx context.Context, form_id string, section_ids []string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.section_ids)
+
+			err := r.Reorder(testCase.ctx, testCase.form_id, testCase.section_ids)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func strRef(s string) *string {
+	return &s
+}
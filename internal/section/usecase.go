@@ -0,0 +1,50 @@
+package section
+
+import (
+	"context"
+	"quizapp/models"
+)
+
+type UseCase interface {
+	// Returns created model & nil, if created.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs or condition.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither form owner nor editor or permission denied.
+	// Returns nil & other err else.
+	Create(ctx context.Context, model *models.Section) (*models.Section, error)
+
+	// Returns form sections with their questions and questions out of sections & nil, if get.
+	// Returns empty slices & nil, if get nothing.
+	// Returns nil, nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil, nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil, nil & ErrForbidden, if user is neither form owner or member nor moderator or admin.
+	// Returns nil, nil & other err else.
+	GetByFormId(ctx context.Context, form_id string) ([]*models.Section, []*models.Question, error)
+
+	// Returns source model & nil, if updated.
+	// Returns nil & ErrContentNotFound, if no such section in form.
+	// Returns nil & ErrInvalidContent, if invalid inputs or condition.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither form owner nor editor or permission denied.
+	// Returns nil & other err else.
+	Update(ctx context.Context, model *models.Section) (*models.Section, error)
+
+	// Questions of deleted section stay in form out of sections.
+	// Returns nil, if deleted.
+	// Returns ErrContentNotFound, if no such section.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if user is neither form owner nor editor or permission denied.
+	// Returns other err else.
+	Delete(ctx context.Context, id string) error
+
+	// Sets order of form sections, section_ids has to list all form sections.
+	// Returns nil, if reordered.
+	// Returns ErrContentNotFound, if no such form or form has no sections.
+	// Returns ErrInvalidContent, if invalid inputs or ids are not exactly form sections.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if user is neither form owner nor editor or permission denied.
+	// Returns other err else.
+	Reorder(ctx context.Context, form_id string, section_ids []string) error
+}
@@ -0,0 +1,218 @@
+package usecase
+
+import (
+	"context"
+	"quizapp/internal/authz"
+	"quizapp/internal/form"
+	"quizapp/internal/question"
+	"quizapp/internal/section"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/transactor"
+)
+
+type sectionUseCase struct {
+	sRepo      section.Repo
+	qRepo      question.Repo
+	fRepo      form.Repo
+	authorizer authz.Authorizer
+	transactor transactor.Transactor
+}
+
+func NewSectionUseCase(sRepo section.Repo, qRepo question.Repo, fRepo form.Repo, authorizer authz.Authorizer, transactor transactor.Transactor) section.UseCase {
+	return &sectionUseCase{
+		sRepo:      sRepo,
+		qRepo:      qRepo,
+		fRepo:      fRepo,
+		authorizer: authorizer,
+		transactor: transactor,
+	}
+}
+
+func (s *sectionUseCase) Create(ctx context.Context, model *models.Section) (*models.Section, error) {
+	err := s.authorizer.AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validateCondition(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdsection *models.Section
+
+	err = s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		// form lock serializes position changes of its sections
+		err = s.fRepo.LockById(ctx, model.Form_id)
+		if err != nil {
+			return err
+		}
+
+		if model.Position > 0 {
+			err = s.sRepo.ShiftPositions(ctx, model.Form_id, model.Position)
+			if err != nil {
+				return err
+			}
+		}
+
+		createdsection, err = s.sRepo.Create(ctx, model)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdsection, nil
+}
+
+func (s *sectionUseCase) GetByFormId(ctx context.Context, form_id string) ([]*models.Section, []*models.Question, error) {
+	err := s.authorizer.AuthorizeForm(ctx, form_id, models.ActionFormView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	foundsections, err := s.sRepo.GetByFormId(ctx, form_id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	foundquestions, err := s.qRepo.GetAllByFormId(ctx, form_id)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	byid := make(map[string]*models.Section, len(foundsections))
+	for _, sec := range foundsections {
+		sec.Questions = make([]*models.Question, 0)
+		byid[sec.Id] = sec
+	}
+
+	// questions keep form order inside of section
+	unsectioned := make([]*models.Question, 0)
+	for _, q := range foundquestions {
+		sec, ok := byid[q.Section_id]
+		if !ok {
+			unsectioned = append(unsectioned, q)
+			continue
+		}
+
+		sec.Questions = append(sec.Questions, q)
+	}
+
+	return foundsections, unsectioned, nil
+}
+
+func (s *sectionUseCase) Update(ctx context.Context, model *models.Section) (*models.Section, error) {
+	foundsection, err := s.sRepo.GetById(ctx, model.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	if foundsection.Form_id != model.Form_id {
+		return nil, errs.ErrContentNotFound
+	}
+
+	err = s.authorizer.AuthorizeForm(ctx, foundsection.Form_id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.validateCondition(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.sRepo.Update(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+
+	model.Position = foundsection.Position
+
+	return model, nil
+}
+
+func (s *sectionUseCase) Delete(ctx context.Context, id string) error {
+	foundsection, err := s.sRepo.GetById(ctx, id)
+	if err != nil {
+		return err
+	}
+
+	err = s.authorizer.AuthorizeForm(ctx, foundsection.Form_id, models.ActionFormEdit)
+	if err != nil {
+		return err
+	}
+
+	return s.sRepo.Delete(ctx, id)
+}
+
+func (s *sectionUseCase) Reorder(ctx context.Context, form_id string, section_ids []string) error {
+	err := s.authorizer.AuthorizeForm(ctx, form_id, models.ActionFormEdit)
+	if err != nil {
+		return err
+	}
+
+	return s.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		err = s.fRepo.LockById(ctx, form_id)
+		if err != nil {
+			return err
+		}
+
+		foundsections, err := s.sRepo.GetByFormId(ctx, form_id)
+		if err != nil {
+			return err
+		}
+
+		if !isPermutation(foundsections, section_ids) {
+			return errs.ErrInvalidContent
+		}
+
+		return s.sRepo.Reorder(ctx, form_id, section_ids)
+	})
+}
+
+// condition has to refer to question of form,
+// order of questions is checked on publish
+func (s *sectionUseCase) validateCondition(ctx context.Context, model *models.Section) error {
+	if model.Condition == nil {
+		return nil
+	}
+
+	referred, err := s.qRepo.GetById(ctx, model.Condition.Question_id)
+	if err != nil {
+		if err == errs.ErrContentNotFound {
+			return errs.ErrInvalidContent
+		}
+
+		return err
+	}
+
+	if referred.Form_id != model.Form_id || !model.Condition.Validate(referred) {
+		return errs.ErrInvalidContent
+	}
+
+	return nil
+}
+
+// order has to list every section of form exactly once
+func isPermutation(sections []*models.Section, section_ids []string) bool {
+	if len(sections) != len(section_ids) {
+		return false
+	}
+
+	left := make(map[string]bool, len(sections))
+	for _, sec := range sections {
+		left[sec.Id] = true
+	}
+
+	for _, id := range section_ids {
+		if !left[id] {
+			return false
+		}
+
+		delete(left, id)
+	}
+
+	return true
+}
@@ -0,0 +1,498 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	mockauthz "quizapp/internal/authz/mock"
+	mockf "quizapp/internal/form/mock"
+	mockq "quizapp/internal/question/mock"
+	mocks "quizapp/internal/section/mock"
+	"quizapp/internal/section/usecase"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	mocktx "quizapp/pkg/transactor/mock"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSectionUseCase_Create(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoS := mocks.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewSectionUseCase(mockRepoS, mockRepoQ, mockRepoF, mockAuthz, mockTx)
+
+	type mockBehavior func(ctx context.Context, model *models.Section)
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		model           models.Section
+		mockBehavior    mockBehavior
+		expectedSection *models.Section
+		expectedErr     error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			model:    models.Section{Form_id: "5", Title: "page"},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, model.Form_id).Return(nil)
+				mockRepoS.EXPECT().Create(ctx, model).Return(&models.Section{Id: "1", Form_id: "5", Title: "page", Position: 3}, nil)
+			},
+			expectedSection: &models.Section{Id: "1", Form_id: "5", Title: "page", Position: 3},
+		},
+		{
+			nameTest: "ok_at_position",
+			ctx:      context.Background(),
+			model:    models.Section{Form_id: "5", Title: "page", Position: 1},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, model.Form_id).Return(nil)
+				mockRepoS.EXPECT().ShiftPositions(ctx, model.Form_id, 1).Return(nil)
+				mockRepoS.EXPECT().Create(ctx, model).Return(&models.Section{Id: "1", Form_id: "5", Title: "page", Position: 1}, nil)
+			},
+			expectedSection: &models.Section{Id: "1", Form_id: "5", Title: "page", Position: 1},
+		},
+		{
+			nameTest: "ok_conditional",
+			ctx:      context.Background(),
+			model: models.Section{
+				Form_id:   "5",
+				Title:     "page",
+				Condition: &models.Condition{Question_id: "3", Op: models.ConditionOpNotAnswered},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, "3").Return(&models.Question{Id: "3", Form_id: "5"}, nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, model.Form_id).Return(nil)
+				mockRepoS.EXPECT().Create(ctx, model).Return(&models.Section{Id: "1", Form_id: "5", Title: "page", Position: 3, Condition: model.Condition}, nil)
+			},
+			expectedSection: &models.Section{
+				Id:        "1",
+				Form_id:   "5",
+				Title:     "page",
+				Position:  3,
+				Condition: &models.Condition{Question_id: "3", Op: models.ConditionOpNotAnswered},
+			},
+		},
+		{
+			nameTest: "condition_with_value_for_answered",
+			ctx:      context.Background(),
+			model: models.Section{
+				Form_id:   "5",
+				Title:     "page",
+				Condition: &models.Condition{Question_id: "3", Op: models.ConditionOpAnswered, Value: "a"},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, "3").Return(&models.Question{Id: "3", Form_id: "5"}, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "condition_on_question_of_other_form",
+			ctx:      context.Background(),
+			model: models.Section{
+				Form_id:   "5",
+				Title:     "page",
+				Condition: &models.Condition{Question_id: "3", Op: models.ConditionOpAnswered},
+			},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				mockRepoQ.EXPECT().GetById(ctx, "3").Return(&models.Question{Id: "3", Form_id: "6"}, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "user_is_not_an_owner",
+			ctx:      context.Background(),
+			model:    models.Section{Form_id: "5", Title: "page"},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "shift_error",
+			ctx:      context.Background(),
+			model:    models.Section{Form_id: "5", Title: "page", Position: 1},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, model.Form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, model.Form_id).Return(nil)
+				mockRepoS.EXPECT().ShiftPositions(ctx, model.Form_id, 1).Return(errors.New("exec_error"))
+			},
+			expectedErr: errors.New("exec_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.Create(testCase.ctx, &testCase.model)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedSection, got)
+		})
+	}
+}
+
+func TestSectionUseCase_GetByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoS := mocks.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewSectionUseCase(mockRepoS, mockRepoQ, mockRepoF, mockAuthz, mockTx)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	questions := []*models.Question{
+		{Id: "1", Form_id: "5", Header: "intro", Position: 1},
+		{Id: "2", Form_id: "5", Section_id: "8", Header: "b", Position: 2},
+		{Id: "3", Form_id: "5", Section_id: "7", Header: "a", Position: 3},
+		{Id: "4", Form_id: "5", Section_id: "8", Header: "c", Position: 4},
+	}
+
+	testTable := []struct {
+		nameTest            string
+		ctx                 context.Context
+		form_id             string
+		mockBehavior        mockBehavior
+		expectedSections    []*models.Section
+		expectedUnsectioned []*models.Question
+		expectedErr         error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return([]*models.Section{
+					{Id: "7", Form_id: "5", Title: "first", Position: 1},
+					{Id: "8", Form_id: "5", Title: "second", Position: 2},
+					{Id: "9", Form_id: "5", Title: "empty", Position: 3},
+				}, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(questions, nil)
+			},
+			expectedSections: []*models.Section{
+				{Id: "7", Form_id: "5", Title: "first", Position: 1, Questions: []*models.Question{questions[2]}},
+				{Id: "8", Form_id: "5", Title: "second", Position: 2, Questions: []*models.Question{questions[1], questions[3]}},
+				{Id: "9", Form_id: "5", Title: "empty", Position: 3, Questions: []*models.Question{}},
+			},
+			expectedUnsectioned: []*models.Question{questions[0]},
+		},
+		{
+			nameTest: "no_sections",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return([]*models.Section{}, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(questions[:1], nil)
+			},
+			expectedSections:    []*models.Section{},
+			expectedUnsectioned: []*models.Question{questions[0]},
+		},
+		{
+			nameTest: "invalid_form_id",
+			ctx:      context.Background(),
+			form_id:  "5r4",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(errs.ErrInvalidContent)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			// questions with correct answers of quiz are not shown to respondents
+			nameTest: "user_is_not_a_member",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "questions_error",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return([]*models.Section{}, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			gotSections, gotUnsectioned, err := uc.GetByFormId(testCase.ctx, testCase.form_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedSections, gotSections)
+			assert.Equal(t, testCase.expectedUnsectioned, gotUnsectioned)
+		})
+	}
+}
+
+func TestSectionUseCase_Update(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoS := mocks.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewSectionUseCase(mockRepoS, mockRepoQ, mockRepoF, mockAuthz, mockTx)
+
+	type mockBehavior func(ctx context.Context, model *models.Section)
+
+	foundsection := &models.Section{Id: "7", Form_id: "5", Title: "old", Position: 2}
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		model           models.Section
+		mockBehavior    mockBehavior
+		expectedSection *models.Section
+		expectedErr     error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			model:    models.Section{Id: "7", Form_id: "5", Title: "new", Description: "desc"},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockRepoS.EXPECT().GetById(ctx, model.Id).Return(foundsection, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, "5", models.ActionFormEdit).Return(nil)
+				mockRepoS.EXPECT().Update(ctx, model).Return(model, nil)
+			},
+			expectedSection: &models.Section{Id: "7", Form_id: "5", Title: "new", Description: "desc", Position: 2},
+		},
+		{
+			nameTest: "section_of_other_form",
+			ctx:      context.Background(),
+			model:    models.Section{Id: "7", Form_id: "6", Title: "new"},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockRepoS.EXPECT().GetById(ctx, model.Id).Return(foundsection, nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+		{
+			nameTest: "user_is_not_an_owner",
+			ctx:      context.Background(),
+			model:    models.Section{Id: "7", Form_id: "5", Title: "new"},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockRepoS.EXPECT().GetById(ctx, model.Id).Return(foundsection, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, "5", models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "no_such_section",
+			ctx:      context.Background(),
+			model:    models.Section{Id: "7", Form_id: "5", Title: "new"},
+			mockBehavior: func(ctx context.Context, model *models.Section) {
+				mockRepoS.EXPECT().GetById(ctx, model.Id).Return(nil, errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.Update(testCase.ctx, &testCase.model)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedSection, got)
+		})
+	}
+}
+
+func TestSectionUseCase_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoS := mocks.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewSectionUseCase(mockRepoS, mockRepoQ, mockRepoF, mockAuthz, mockTx)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "7",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepoS.EXPECT().GetById(ctx, id).Return(&models.Section{Id: "7", Form_id: "5"}, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, "5", models.ActionFormEdit).Return(nil)
+				mockRepoS.EXPECT().Delete(ctx, id).Return(nil)
+			},
+		},
+		{
+			nameTest: "user_is_not_an_owner",
+			ctx:      context.Background(),
+			id:       "7",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepoS.EXPECT().GetById(ctx, id).Return(&models.Section{Id: "7", Form_id: "5"}, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, "5", models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "no_such_section",
+			ctx:      context.Background(),
+			id:       "7",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepoS.EXPECT().GetById(ctx, id).Return(nil, errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			err := uc.Delete(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestSectionUseCase_Reorder(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoS := mocks.NewMockRepo(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewSectionUseCase(mockRepoS, mockRepoQ, mockRepoF, mockAuthz, mockTx)
+
+	type mockBehavior func(ctx context.Context, form_id string, section_ids []string)
+
+	foundsections := []*models.Section{
+		{Id: "7", Form_id: "5", Position: 1},
+		{Id: "8", Form_id: "5", Position: 2},
+	}
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		form_id      string
+		section_ids  []string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest:    "ok",
+			ctx:         context.Background(),
+			form_id:     "5",
+			section_ids: []string{"8", "7"},
+			mockBehavior: func(ctx context.Context, form_id string, section_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return(foundsections, nil)
+				mockRepoS.EXPECT().Reorder(ctx, form_id, section_ids).Return(nil)
+			},
+		},
+		{
+			nameTest:    "not_all_sections",
+			ctx:         context.Background(),
+			form_id:     "5",
+			section_ids: []string{"8"},
+			mockBehavior: func(ctx context.Context, form_id string, section_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return(foundsections, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest:    "duplicate_section",
+			ctx:         context.Background(),
+			form_id:     "5",
+			section_ids: []string{"8", "8"},
+			mockBehavior: func(ctx context.Context, form_id string, section_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return(foundsections, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest:    "user_is_not_an_owner",
+			ctx:         context.Background(),
+			form_id:     "5",
+			section_ids: []string{"8", "7"},
+			mockBehavior: func(ctx context.Context, form_id string, section_ids []string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.section_ids)
+
+			err := uc.Reorder(testCase.ctx, testCase.form_id, testCase.section_ids)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func expectTx(ctx context.Context, mockTx *mocktx.MockTransactor) {
+	mockTx.EXPECT().WithinTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+}
@@ -7,16 +7,36 @@ import (
 	authh "quizapp/internal/auth/delivery/http"
 	authrepo "quizapp/internal/auth/repo"
 	authuc "quizapp/internal/auth/usecase"
+	authzpolicy "quizapp/internal/authz/policy"
+	bh "quizapp/internal/bank/delivery/http"
+	brepo "quizapp/internal/bank/repo"
+	buc "quizapp/internal/bank/usecase"
 	fh "quizapp/internal/form/delivery/http"
 	frepo "quizapp/internal/form/repo"
 	fuc "quizapp/internal/form/usecase"
+	mh "quizapp/internal/member/delivery/http"
+	mrepo "quizapp/internal/member/repo"
+	muc "quizapp/internal/member/usecase"
 	pah "quizapp/internal/poolanswer/delivery/http"
 	parepo "quizapp/internal/poolanswer/repo"
 	pauc "quizapp/internal/poolanswer/usecase"
 	qh "quizapp/internal/question/delivery/http"
 	qrepo "quizapp/internal/question/repo"
 	quc "quizapp/internal/question/usecase"
+	sech "quizapp/internal/section/delivery/http"
+	secrepo "quizapp/internal/section/repo"
+	secuc "quizapp/internal/section/usecase"
+	sh "quizapp/internal/stats/delivery/http"
+	srepo "quizapp/internal/stats/repo"
+	suc "quizapp/internal/stats/usecase"
+	vh "quizapp/internal/version/delivery/http"
+	vrepo "quizapp/internal/version/repo"
+	vuc "quizapp/internal/version/usecase"
+	wh "quizapp/internal/workspace/delivery/http"
+	wrepo "quizapp/internal/workspace/repo"
+	wuc "quizapp/internal/workspace/usecase"
 	jwtgo "quizapp/pkg/jwter/impl"
+	"time"
 
 	_ "quizapp/docs"
 
@@ -26,37 +46,68 @@ import (
 )
 
 func (s *Server) MapHandlers() error {
-	jwter := jwtgo.NewJWTGO(s.cfg.Server.JwtSecretKey)
+	jwter, err := jwtgo.NewJWTGO(s.cfg)
+	if err != nil {
+		return err
+	}
 
 	aRepo := arepo.NewAnswerRepo(s.db)
 	authRepo := authrepo.NewAuthRepo(s.db)
-	fRepo := frepo.NewFormRepo(s.cfg.Server.CtxUserKey, s.db)
+	fRepo := frepo.NewFormRepo(s.db)
 	qRepo := qrepo.NewQuestionRepo(s.db)
 	paRepo := parepo.NewPoolAnswerRepo(s.db)
-
-	paUC := pauc.NewPoolAnswerUseCase(paRepo, aRepo, fRepo)
-	aUC := auc.NewAnswerUseCase(aRepo, fRepo, paRepo)
-	qUC := quc.NewQuestionUseCase(qRepo, fRepo)
-	authUC := authuc.NewAuthUseCase(authRepo, jwter)
-	fUC := fuc.NewFormUseCase(fRepo, s.cfg.Server.CtxUserKey)
+	vRepo := vrepo.NewVersionRepo(s.db)
+	sRepo := srepo.NewStatsRepo(s.db)
+	secRepo := secrepo.NewSectionRepo(s.db)
+	bRepo := brepo.NewBankRepo(s.db)
+	mRepo := mrepo.NewMemberRepo(s.db)
+	wRepo := wrepo.NewWorkspaceRepo(s.db)
+
+	authorizer := authzpolicy.NewPolicy(fRepo, mRepo, wRepo, s.cfg.Server.CtxUserKey)
+
+	paUC := pauc.NewPoolAnswerUseCase(paRepo, aRepo, fRepo, vRepo, bRepo, authorizer, s.db)
+	aUC := auc.NewAnswerUseCase(aRepo, authorizer, paRepo, vRepo, s.cfg.Server.CtxUserKey)
+	qUC := quc.NewQuestionUseCase(qRepo, fRepo, authorizer, secRepo, s.db)
+	vUC := vuc.NewVersionUseCase(vRepo, fRepo, authorizer, qRepo, secRepo, s.db)
+	authUC := authuc.NewAuthUseCase(authRepo, jwter, authorizer, s.db, time.Second*s.cfg.Server.AccessTokenTTL, time.Second*s.cfg.Server.RefreshTokenTTL)
+	fUC := fuc.NewFormUseCase(fRepo, vRepo, qRepo, secRepo, authorizer, s.db, s.cfg.Server.CtxUserKey)
+	sUC := suc.NewStatsUseCase(sRepo, authorizer, vRepo)
+	secUC := secuc.NewSectionUseCase(secRepo, qRepo, fRepo, authorizer, s.db)
+	bUC := buc.NewBankUseCase(bRepo, s.cfg.Server.CtxUserKey)
+	mUC := muc.NewMemberUseCase(mRepo, fRepo, authorizer, s.cfg.Server.CtxUserKey)
+	wUC := wuc.NewWorkspaceUseCase(wRepo, authorizer, s.db, s.cfg.Server.CtxUserKey)
 
 	authH := authh.NewAuthHandlers(authUC)
 	middleware := authh.NewAuthMiddleware(authUC, s.cfg.Server.CtxUserKey)
 	fH := fh.NewFormHandlers(fUC, s.cfg.Server.CtxUserKey)
 	qH := qh.NewQuestionHandlers(qUC, s.cfg.Server.CtxUserKey)
 	aH := pah.NewAnswersHandlers(paUC, aUC, fUC, s.cfg.Server.CtxUserKey)
+	vH := vh.NewVersionHandlers(vUC, s.cfg.Server.CtxUserKey)
+	sH := sh.NewStatsHandlers(sUC, s.cfg.Server.CtxUserKey)
+	secH := sech.NewSectionHandlers(secUC, s.cfg.Server.CtxUserKey)
+	bH := bh.NewBankHandlers(bUC, s.cfg.Server.CtxUserKey)
+	mH := mh.NewMemberHandlers(mUC, s.cfg.Server.CtxUserKey)
+	wH := wh.NewWorkspaceHandlers(wUC, s.cfg.Server.CtxUserKey)
 
 	s.router.GET("api/v1/docs/*any", ginSwagger.WrapHandler(swaggerFiles.Handler))
+	s.router.GET("/.well-known/jwks.json", authH.JWKS())
 	s.router.GET("api/v1/", func(c *gin.Context) { c.Redirect(http.StatusSeeOther, "/api/v1/docs/index.html") })
 
 	auth := s.router.Group("api/v1/auth")
 	authh.MapAuthRoutes(auth, authH)
 
+	public := s.router.Group("api/v1/public/forms")
+	fh.MapPublicFormRoutes(public, fH)
+
+	publicanswers := public.Group("/:formid/poolsanswer")
+	pah.MapPublicPARoutes(publicanswers, aH)
+
 	api := s.router.Group("/api", middleware)
 
 	v1 := api.Group("/v1")
 
 	v1.GET("/users/:id", authH.GetById())
+	v1.PUT("/users/:id/role", authH.SetRole())
 
 	forms := v1.Group("/forms")
 	fh.MapFormRoutes(forms, fH)
@@ -64,8 +115,32 @@ func (s *Server) MapHandlers() error {
 	questions := forms.Group("/:formid/questions")
 	qh.MapQuestionRoutes(questions, qH)
 
+	sections := forms.Group("/:formid/sections")
+	sech.MapSectionRoutes(sections, secH)
+
 	answers := forms.Group("/:formid/poolsanswer")
 	pah.MapPARoutes(answers, aH)
 
+	me := v1.Group("/me")
+	pah.MapMyPARoutes(me, aH)
+
+	versions := forms.Group("/:formid/versions")
+	vh.MapVersionRoutes(versions, vH)
+
+	formstats := forms.Group("/:formid/stats")
+	sh.MapStatsRoutes(formstats, sH)
+
+	members := forms.Group("/:formid/members")
+	mh.MapMemberRoutes(members, mH)
+
+	bank := v1.Group("/bank/questions")
+	bh.MapBankRoutes(bank, bH)
+
+	workspaces := v1.Group("/workspaces")
+	wh.MapWorkspaceRoutes(workspaces, wH)
+
+	workspacemembers := workspaces.Group("/:workspaceid/members")
+	wh.MapWorkspaceMemberRoutes(workspacemembers, wH)
+
 	return nil
 }
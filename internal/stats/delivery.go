@@ -0,0 +1,8 @@
+package stats
+
+import "github.com/gin-gonic/gin"
+
+// Stats HTTP Handlers interface
+type Handlers interface {
+	GetByFormId() gin.HandlerFunc
+}
@@ -0,0 +1,139 @@
+package http
+
+import (
+	"net/http"
+	"quizapp/internal/stats"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type dailyCountResponse struct {
+	Day   time.Time `json:"day"`
+	Count int       `json:"count"`
+}
+
+type choiceCountResponse struct {
+	Value string `json:"value"`
+	Count int    `json:"count"`
+}
+
+type numericStatsResponse struct {
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	P25    float64 `json:"p25"`
+	P75    float64 `json:"p75"`
+	P90    float64 `json:"p90"`
+}
+
+type questionStatsResponse struct {
+	Question_id string                 `json:"question_id"`
+	Header      string                 `json:"header"`
+	Type        string                 `json:"type"`
+	Answered    int                    `json:"answered"`
+	Choices     []*choiceCountResponse `json:"choices,omitempty"`
+	Numeric     *numericStatsResponse  `json:"numeric,omitempty"`
+}
+
+type statsResponse struct {
+	Form_id    string                   `json:"form_id"`
+	Version_id string                   `json:"version_id,omitempty"`
+	Total      int                      `json:"total"`
+	Daily      []*dailyCountResponse    `json:"daily"`
+	Questions  []*questionStatsResponse `json:"questions"`
+}
+
+type statsHandlers struct {
+	statsUC    stats.UseCase
+	ctxUserKey string
+}
+
+func NewStatsHandlers(statsUC stats.UseCase, ctxUserKey string) stats.Handlers {
+	return &statsHandlers{
+		statsUC:    statsUC,
+		ctxUserKey: ctxUserKey,
+	}
+}
+
+// GetByFormId godoc
+// @Summary Get form stats
+// @Description Get number of answers per day and per question, counts per choice and numeric stats of answers. Questions of latest version and answers of all versions are used, if version id is not set
+// @Tags Stats
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param version_id query string false "version id"
+// @Success 200 {object} statsResponse "Found"
+// @Failure 204   "No such form or version, or form is not published"
+// @Failure 400   "Invalid params or version is not of form"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner or member nor moderator or admin"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/stats [get]
+func (h *statsHandlers) GetByFormId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		foundstats, err := h.statsUC.GetByFormId(c, c.Param("formid"), c.Query("version_id"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, statsBLToResponse(foundstats))
+	}
+}
+
+func statsBLToResponse(statsBL *models.FormStats) *statsResponse {
+	daily := make([]*dailyCountResponse, len(statsBL.Daily))
+	for i, d := range statsBL.Daily {
+		daily[i] = &dailyCountResponse{
+			Day:   d.Day,
+			Count: d.Count,
+		}
+	}
+
+	questions := make([]*questionStatsResponse, len(statsBL.Questions))
+	for i, q := range statsBL.Questions {
+		questions[i] = questionStatsBLToResponse(q)
+	}
+
+	return &statsResponse{
+		Form_id:    statsBL.Form_id,
+		Version_id: statsBL.Version_id,
+		Total:      statsBL.Total,
+		Daily:      daily,
+		Questions:  questions,
+	}
+}
+
+func questionStatsBLToResponse(statsBL *models.QuestionStats) *questionStatsResponse {
+	res := &questionStatsResponse{
+		Question_id: statsBL.Question.Id,
+		Header:      statsBL.Question.Header,
+		Type:        statsBL.Question.Type,
+		Answered:    statsBL.Answered,
+	}
+
+	for _, c := range statsBL.Choices {
+		res.Choices = append(res.Choices, &choiceCountResponse{
+			Value: c.Value,
+			Count: c.Count,
+		})
+	}
+
+	if n := statsBL.Numeric; n != nil {
+		res.Numeric = &numericStatsResponse{
+			Min:    n.Min,
+			Max:    n.Max,
+			Mean:   n.Mean,
+			Median: n.Median,
+			P25:    n.P25,
+			P75:    n.P75,
+			P90:    n.P90,
+		}
+	}
+
+	return res
+}
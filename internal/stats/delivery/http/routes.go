@@ -0,0 +1,12 @@
+package http
+
+import (
+	"quizapp/internal/stats"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Map stats routes
+func MapStatsRoutes(statsGroup *gin.RouterGroup, h stats.Handlers) {
+	statsGroup.GET("", h.GetByFormId())
+}
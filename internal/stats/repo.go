@@ -0,0 +1,33 @@
+package stats
+
+import (
+	"context"
+	"quizapp/models"
+)
+
+// Version id filters pool answers of version, all versions are aggregated, if it is empty.
+type Repo interface {
+	// Returns slice ordered by day & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	CountByDay(ctx context.Context, form_id, version_id string) ([]*models.DailyCount, error)
+
+	// Returns numbers of pool answers by question id & nil, if get.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	CountAnswered(ctx context.Context, form_id, version_id string) (map[string]int, error)
+
+	// Counts answers of questions by value, multiple choice values are counted by each choice.
+	// Answers given to version, where question was not of the same choice type, are skipped.
+	// Returns counts by question id & nil, if get.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	CountChoices(ctx context.Context, form_id, version_id string, question_ids []string, multiple bool) (map[string][]*models.ChoiceCount, error)
+
+	// Returns numeric stats by question id & nil, if get.
+	// Answers given to version, where question was not numeric, are skipped.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetNumeric(ctx context.Context, form_id, version_id string, question_ids []string) (map[string]*models.NumericStats, error)
+}
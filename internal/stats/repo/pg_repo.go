@@ -0,0 +1,269 @@
+package repo
+
+import (
+	"context"
+	"quizapp/internal/stats"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"strconv"
+
+	"github.com/Masterminds/squirrel"
+)
+
+const (
+	// Answer values of numeric questions are validated to be numbers on create
+	numericColumns = "a.question_id_, MIN(a.value_::float8), MAX(a.value_::float8), AVG(a.value_::float8), " +
+		"percentile_cont(0.5) WITHIN GROUP (ORDER BY a.value_::float8), " +
+		"percentile_cont(0.25) WITHIN GROUP (ORDER BY a.value_::float8), " +
+		"percentile_cont(0.75) WITHIN GROUP (ORDER BY a.value_::float8), " +
+		"percentile_cont(0.9) WITHIN GROUP (ORDER BY a.value_::float8)"
+
+	poolAnswerJoin = "pool_answer_ p ON p.id_ = a.pool_answer_id_"
+	versionJoin    = "form_version_ v ON v.id_ = p.version_id_"
+
+	// Question type may change between versions, so answers are aggregated
+	// only if question had one of types in version they were given to
+	questionTypeFilter = "EXISTS (SELECT 1 FROM jsonb_array_elements(v.questions_) AS q " +
+		"WHERE (q->>'id')::int = a.question_id_ AND q->>'type' = ANY(?))"
+)
+
+type statsRepo struct {
+	*postgres.Postgres
+}
+
+func NewStatsRepo(db *postgres.Postgres) stats.Repo {
+	return &statsRepo{db}
+}
+
+func (s *statsRepo) CountByDay(ctx context.Context, form_id, version_id string) ([]*models.DailyCount, error) {
+	filter, err := poolAnswerFilter("", form_id, version_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := s.Builder.
+		Select("date_trunc('day', created_at_) AS day_, COUNT(*)").
+		From("pool_answer_").
+		Where(filter).
+		// pending attempts are not answered yet
+		Where(squirrel.Eq{"pending_": false}).
+		GroupBy("day_").
+		OrderBy("day_").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.DailyCount, 0)
+
+	for rows.Next() {
+		var daily models.DailyCount
+
+		err = rows.Scan(&daily.Day, &daily.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, &daily)
+	}
+
+	return res, nil
+}
+
+func (s *statsRepo) CountAnswered(ctx context.Context, form_id, version_id string) (map[string]int, error) {
+	filter, err := poolAnswerFilter("p.", form_id, version_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := s.Builder.
+		Select("a.question_id_, COUNT(DISTINCT a.pool_answer_id_)").
+		From("answer_ a").
+		Join(poolAnswerJoin).
+		Where(filter).
+		GroupBy("a.question_id_").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]int)
+
+	for rows.Next() {
+		var questionid, count int
+
+		err = rows.Scan(&questionid, &count)
+		if err != nil {
+			return nil, err
+		}
+
+		res[strconv.Itoa(questionid)] = count
+	}
+
+	return res, nil
+}
+
+func (s *statsRepo) CountChoices(ctx context.Context, form_id, version_id string, question_ids []string, multiple bool) (map[string][]*models.ChoiceCount, error) {
+	filter, err := poolAnswerFilter("p.", form_id, version_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	questionids, err := atoiSlice(question_ids)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	// Multiple choice values are JSON arrays, each choice is counted
+	value := "a.value_"
+	questiontype := models.QuestionTypeSingleChoice
+	if multiple {
+		value = "c.value_"
+		questiontype = models.QuestionTypeMultipleChoice
+	}
+
+	builder := s.Builder.
+		Select("a.question_id_, " + value + ", COUNT(*)").
+		From("answer_ a").
+		Join(poolAnswerJoin).
+		Join(versionJoin)
+
+	if multiple {
+		builder = builder.CrossJoin("jsonb_array_elements_text(a.value_::jsonb) AS c(value_)")
+	}
+
+	sql, args, err := builder.
+		Where(filter).
+		Where(squirrel.Eq{"a.question_id_": questionids}).
+		Where(questionTypeFilter, []string{questiontype}).
+		GroupBy("a.question_id_, " + value).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string][]*models.ChoiceCount)
+
+	for rows.Next() {
+		var (
+			questionid int
+			choice     models.ChoiceCount
+		)
+
+		err = rows.Scan(&questionid, &choice.Value, &choice.Count)
+		if err != nil {
+			return nil, err
+		}
+
+		id := strconv.Itoa(questionid)
+		res[id] = append(res[id], &choice)
+	}
+
+	return res, nil
+}
+
+func (s *statsRepo) GetNumeric(ctx context.Context, form_id, version_id string, question_ids []string) (map[string]*models.NumericStats, error) {
+	filter, err := poolAnswerFilter("p.", form_id, version_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	questionids, err := atoiSlice(question_ids)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := s.Builder.
+		Select(numericColumns).
+		From("answer_ a").
+		Join(poolAnswerJoin).
+		Join(versionJoin).
+		Where(filter).
+		Where(squirrel.Eq{"a.question_id_": questionids}).
+		Where(questionTypeFilter, []string{models.QuestionTypeScale, models.QuestionTypeNumber}).
+		GroupBy("a.question_id_").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make(map[string]*models.NumericStats)
+
+	for rows.Next() {
+		var (
+			questionid int
+			numeric    models.NumericStats
+		)
+
+		err = rows.Scan(&questionid, &numeric.Min, &numeric.Max, &numeric.Mean,
+			&numeric.Median, &numeric.P25, &numeric.P75, &numeric.P90)
+		if err != nil {
+			return nil, err
+		}
+
+		res[strconv.Itoa(questionid)] = &numeric
+	}
+
+	return res, nil
+}
+
+// Filters pool answers, prefixed by alias, of form and version, if it is set.
+func poolAnswerFilter(alias, form_id, version_id string) (squirrel.Eq, error) {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := squirrel.Eq{alias + "form_id_": intformid}
+
+	if version_id != "" {
+		intversionid, err := strconv.Atoi(version_id)
+		if err != nil {
+			return nil, err
+		}
+
+		filter[alias+"version_id_"] = intversionid
+	}
+
+	return filter, nil
+}
+
+func atoiSlice(ids []string) ([]int, error) {
+	res := make([]int, len(ids))
+
+	for i, id := range ids {
+		intid, err := strconv.Atoi(id)
+		if err != nil {
+			return nil, err
+		}
+
+		res[i] = intid
+	}
+
+	return res, nil
+}
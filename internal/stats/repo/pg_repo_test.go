@@ -0,0 +1,415 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/stats/repo"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_builder = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	_day     = time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+)
+
+func TestStatsRepo_CountByDay(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewStatsRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id, version_id string)
+
+	const (
+		selectSQL        = "SELECT date_trunc('day', created_at_) AS day_, COUNT(*) FROM pool_answer_ WHERE form_id_ = $1 AND pending_ = $2 GROUP BY day_ ORDER BY day_"
+		selectVersionSQL = "SELECT date_trunc('day', created_at_) AS day_, COUNT(*) FROM pool_answer_ WHERE form_id_ = $1 AND version_id_ = $2 AND pending_ = $3 GROUP BY day_ ORDER BY day_"
+	)
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		form_id       string
+		version_id    string
+		mockBehavior  mockBehavior
+		expectedDaily []*models.DailyCount
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"day_", "count"}).
+					AddRow(_day, 3).
+					AddRow(_day.AddDate(0, 0, 1), 1).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(pgxRows, nil)
+			},
+			expectedDaily: []*models.DailyCount{
+				{Day: _day, Count: 3},
+				{Day: _day.AddDate(0, 0, 1), Count: 1},
+			},
+		},
+		{
+			nameTest:   "ok_version",
+			ctx:        context.Background(),
+			form_id:    "12",
+			version_id: "7",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"day_", "count"}).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectVersionSQL, 12, 7, false).Return(pgxRows, nil)
+			},
+			expectedDaily: []*models.DailyCount{},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "12",
+			version_id:   "7f",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, false).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.version_id)
+
+			got, err := r.CountByDay(testCase.ctx, testCase.form_id, testCase.version_id)
+
+			switch testCase.nameTest {
+			case "ok", "ok_version":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedDaily, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestStatsRepo_CountAnswered(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewStatsRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id, version_id string)
+
+	const selectSQL = "SELECT a.question_id_, COUNT(DISTINCT a.pool_answer_id_) FROM answer_ a JOIN pool_answer_ p ON p.id_ = a.pool_answer_id_ WHERE p.form_id_ = $1 AND p.version_id_ = $2 GROUP BY a.question_id_"
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		form_id          string
+		version_id       string
+		mockBehavior     mockBehavior
+		expectedAnswered map[string]int
+	}{
+		{
+			nameTest:   "ok",
+			ctx:        context.Background(),
+			form_id:    "12",
+			version_id: "7",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"question_id_", "count"}).
+					AddRow(34, 5).
+					AddRow(35, 2).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, 7).Return(pgxRows, nil)
+			},
+			expectedAnswered: map[string]int{"34": 5, "35": 2},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "1d2",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {},
+		},
+		{
+			nameTest:   "query_error",
+			ctx:        context.Background(),
+			form_id:    "12",
+			version_id: "7",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, 7).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.version_id)
+
+			got, err := r.CountAnswered(testCase.ctx, testCase.form_id, testCase.version_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedAnswered, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestStatsRepo_CountChoices(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewStatsRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id, version_id string)
+
+	const (
+		singleSQL = "SELECT a.question_id_, a.value_, COUNT(*) FROM answer_ a JOIN pool_answer_ p ON p.id_ = a.pool_answer_id_ " +
+			"JOIN form_version_ v ON v.id_ = p.version_id_ WHERE p.form_id_ = $1 AND a.question_id_ IN ($2,$3) " +
+			"AND EXISTS (SELECT 1 FROM jsonb_array_elements(v.questions_) AS q WHERE (q->>'id')::int = a.question_id_ AND q->>'type' = ANY($4)) " +
+			"GROUP BY a.question_id_, a.value_"
+		multipleSQL = "SELECT a.question_id_, c.value_, COUNT(*) FROM answer_ a JOIN pool_answer_ p ON p.id_ = a.pool_answer_id_ " +
+			"JOIN form_version_ v ON v.id_ = p.version_id_ CROSS JOIN jsonb_array_elements_text(a.value_::jsonb) AS c(value_) " +
+			"WHERE p.form_id_ = $1 AND a.question_id_ IN ($2,$3) " +
+			"AND EXISTS (SELECT 1 FROM jsonb_array_elements(v.questions_) AS q WHERE (q->>'id')::int = a.question_id_ AND q->>'type' = ANY($4)) " +
+			"GROUP BY a.question_id_, c.value_"
+	)
+
+	testTable := []struct {
+		nameTest       string
+		ctx            context.Context
+		form_id        string
+		version_id     string
+		question_ids   []string
+		multiple       bool
+		mockBehavior   mockBehavior
+		expectedCounts map[string][]*models.ChoiceCount
+	}{
+		{
+			nameTest:     "ok_single",
+			ctx:          context.Background(),
+			form_id:      "12",
+			question_ids: []string{"34", "35"},
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"question_id_", "value_", "count"}).
+					AddRow(34, "a", 3).
+					AddRow(34, "b", 1).
+					AddRow(35, "c", 2).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, singleSQL, 12, 34, 35, []string{models.QuestionTypeSingleChoice}).Return(pgxRows, nil)
+			},
+			expectedCounts: map[string][]*models.ChoiceCount{
+				"34": {{Value: "a", Count: 3}, {Value: "b", Count: 1}},
+				"35": {{Value: "c", Count: 2}},
+			},
+		},
+		{
+			nameTest:     "ok_multiple",
+			ctx:          context.Background(),
+			form_id:      "12",
+			question_ids: []string{"34", "35"},
+			multiple:     true,
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"question_id_", "value_", "count"}).
+					AddRow(35, "c", 2).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, multipleSQL, 12, 34, 35, []string{models.QuestionTypeMultipleChoice}).Return(pgxRows, nil)
+			},
+			expectedCounts: map[string][]*models.ChoiceCount{
+				"35": {{Value: "c", Count: 2}},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "12",
+			question_ids: []string{"34", "3g5"},
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {},
+		},
+		{
+			nameTest:     "query_error",
+			ctx:          context.Background(),
+			form_id:      "12",
+			question_ids: []string{"34", "35"},
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockPool.EXPECT().Query(ctx, singleSQL, 12, 34, 35, []string{models.QuestionTypeSingleChoice}).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.version_id)
+
+			got, err := r.CountChoices(testCase.ctx, testCase.form_id, testCase.version_id, testCase.question_ids, testCase.multiple)
+
+			switch testCase.nameTest {
+			case "ok_single", "ok_multiple":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedCounts, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestStatsRepo_GetNumeric(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewStatsRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id, version_id string)
+
+	const (
+		numericColumns = "SELECT a.question_id_, MIN(a.value_::float8), MAX(a.value_::float8), AVG(a.value_::float8), " +
+			"percentile_cont(0.5) WITHIN GROUP (ORDER BY a.value_::float8), " +
+			"percentile_cont(0.25) WITHIN GROUP (ORDER BY a.value_::float8), " +
+			"percentile_cont(0.75) WITHIN GROUP (ORDER BY a.value_::float8), " +
+			"percentile_cont(0.9) WITHIN GROUP (ORDER BY a.value_::float8) " +
+			"FROM answer_ a JOIN pool_answer_ p ON p.id_ = a.pool_answer_id_ JOIN form_version_ v ON v.id_ = p.version_id_ "
+		typeFilter = "EXISTS (SELECT 1 FROM jsonb_array_elements(v.questions_) AS q WHERE (q->>'id')::int = a.question_id_ AND q->>'type' = ANY"
+
+		selectSQL = numericColumns + "WHERE p.form_id_ = $1 AND p.version_id_ = $2 AND a.question_id_ IN ($3) AND " +
+			typeFilter + "($4)) GROUP BY a.question_id_"
+		selectAllVersionsSQL = numericColumns + "WHERE p.form_id_ = $1 AND a.question_id_ IN ($2) AND " +
+			typeFilter + "($3)) GROUP BY a.question_id_"
+	)
+
+	numericTypes := []string{models.QuestionTypeScale, models.QuestionTypeNumber}
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		form_id         string
+		version_id      string
+		question_ids    []string
+		mockBehavior    mockBehavior
+		expectedNumeric map[string]*models.NumericStats
+	}{
+		{
+			nameTest:     "ok",
+			ctx:          context.Background(),
+			form_id:      "12",
+			version_id:   "7",
+			question_ids: []string{"36"},
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"question_id_", "min", "max", "avg", "median", "p25", "p75", "p90"}).
+					AddRow(36, 1.0, 5.0, 3.2, 3.0, 2.0, 4.0, 5.0).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, 7, 36, numericTypes).Return(pgxRows, nil)
+			},
+			expectedNumeric: map[string]*models.NumericStats{
+				"36": {Min: 1, Max: 5, Mean: 3.2, Median: 3, P25: 2, P75: 4, P90: 5},
+			},
+		},
+		{
+			// question was text in earlier version, its text answers are not cast
+			nameTest:     "ok_type_changed_between_versions",
+			ctx:          context.Background(),
+			form_id:      "12",
+			question_ids: []string{"36"},
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"question_id_", "min", "max", "avg", "median", "p25", "p75", "p90"}).
+					AddRow(36, 2.0, 4.0, 3.0, 3.0, 2.5, 3.5, 3.8).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, selectAllVersionsSQL, 12, 36, numericTypes).Return(pgxRows, nil)
+			},
+			expectedNumeric: map[string]*models.NumericStats{
+				"36": {Min: 2, Max: 4, Mean: 3, Median: 3, P25: 2.5, P75: 3.5, P90: 3.8},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "1e2",
+			version_id:   "7",
+			question_ids: []string{"36"},
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {},
+		},
+		{
+			nameTest:     "query_error",
+			ctx:          context.Background(),
+			form_id:      "12",
+			version_id:   "7",
+			question_ids: []string{"36"},
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockPool.EXPECT().Query(ctx, selectSQL, 12, 7, 36, numericTypes).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.version_id)
+
+			got, err := r.GetNumeric(testCase.ctx, testCase.form_id, testCase.version_id, testCase.question_ids)
+
+			switch testCase.nameTest {
+			case "ok", "ok_type_changed_between_versions":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedNumeric, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
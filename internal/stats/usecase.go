@@ -0,0 +1,17 @@
+package stats
+
+import (
+	"context"
+	"quizapp/models"
+)
+
+type UseCase interface {
+	// Aggregates answers to questions of version, latest version and answers of all versions are used, if version id is empty.
+	// Returns stats & nil, if get.
+	// Returns nil & ErrContentNotFound, if no such form or version, or form is not published.
+	// Returns nil & ErrInvalidContent, if invalid inputs or version is not of form.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither form owner or member nor moderator or admin.
+	// Returns nil & other err else.
+	GetByFormId(ctx context.Context, form_id, version_id string) (*models.FormStats, error)
+}
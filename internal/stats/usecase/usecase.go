@@ -0,0 +1,153 @@
+package usecase
+
+import (
+	"context"
+	"quizapp/internal/authz"
+	"quizapp/internal/stats"
+	"quizapp/internal/version"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+)
+
+type statsUseCase struct {
+	statsRepo   stats.Repo
+	authorizer  authz.Authorizer
+	versionRepo version.Repo
+}
+
+func NewStatsUseCase(statsRepo stats.Repo, authorizer authz.Authorizer, versionRepo version.Repo) stats.UseCase {
+	return &statsUseCase{
+		statsRepo:   statsRepo,
+		authorizer:  authorizer,
+		versionRepo: versionRepo,
+	}
+}
+
+func (s *statsUseCase) GetByFormId(ctx context.Context, form_id, version_id string) (*models.FormStats, error) {
+	err := s.authorizer.AuthorizeForm(ctx, form_id, models.ActionResponseView)
+	if err != nil {
+		return nil, err
+	}
+
+	foundversion, err := s.getVersion(ctx, form_id, version_id)
+	if err != nil {
+		return nil, err
+	}
+
+	daily, err := s.statsRepo.CountByDay(ctx, form_id, version_id)
+	if err != nil {
+		return nil, err
+	}
+
+	answered, err := s.statsRepo.CountAnswered(ctx, form_id, version_id)
+	if err != nil {
+		return nil, err
+	}
+
+	var singleids, multipleids, numericids []string
+	for _, q := range foundversion.Questions {
+		switch {
+		case q.Type == models.QuestionTypeSingleChoice:
+			singleids = append(singleids, q.Id)
+		case q.Type == models.QuestionTypeMultipleChoice:
+			multipleids = append(multipleids, q.Id)
+		case q.IsNumeric():
+			numericids = append(numericids, q.Id)
+		}
+	}
+
+	choices := make(map[string][]*models.ChoiceCount)
+
+	if len(singleids) != 0 {
+		singles, err := s.statsRepo.CountChoices(ctx, form_id, version_id, singleids, false)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, counts := range singles {
+			choices[id] = counts
+		}
+	}
+
+	if len(multipleids) != 0 {
+		multiples, err := s.statsRepo.CountChoices(ctx, form_id, version_id, multipleids, true)
+		if err != nil {
+			return nil, err
+		}
+
+		for id, counts := range multiples {
+			choices[id] = counts
+		}
+	}
+
+	numerics := make(map[string]*models.NumericStats)
+
+	if len(numericids) != 0 {
+		numerics, err = s.statsRepo.GetNumeric(ctx, form_id, version_id, numericids)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := &models.FormStats{
+		Form_id:    form_id,
+		Version_id: version_id,
+		Daily:      daily,
+		Questions:  make([]*models.QuestionStats, len(foundversion.Questions)),
+	}
+
+	for _, d := range daily {
+		res.Total += d.Count
+	}
+
+	for i, q := range foundversion.Questions {
+		res.Questions[i] = &models.QuestionStats{
+			Question: q,
+			Answered: answered[q.Id],
+			Numeric:  numerics[q.Id],
+		}
+
+		if q.IsChoice() {
+			res.Questions[i].Choices = orderChoices(q, choices[q.Id])
+		}
+	}
+
+	return res, nil
+}
+
+// Returns version_id version of form or latest one, if version_id is empty.
+func (s *statsUseCase) getVersion(ctx context.Context, form_id, version_id string) (*models.FormVersion, error) {
+	if version_id == "" {
+		return s.versionRepo.GetLatestByFormId(ctx, form_id)
+	}
+
+	foundversion, err := s.versionRepo.GetById(ctx, version_id)
+	if err != nil {
+		return nil, err
+	}
+
+	if foundversion.Form_id != form_id {
+		return nil, errs.ErrInvalidContent
+	}
+
+	return foundversion, nil
+}
+
+// Orders counts as question choices, unanswered choices are counted as zero.
+// Values which are not choices of question anymore are skipped.
+func orderChoices(q *models.Question, counts []*models.ChoiceCount) []*models.ChoiceCount {
+	found := make(map[string]int, len(counts))
+	for _, c := range counts {
+		found[c.Value] = c.Count
+	}
+
+	res := make([]*models.ChoiceCount, len(q.Options.Choices))
+	for i, c := range q.Options.Choices {
+		res[i] = &models.ChoiceCount{
+			Value: c,
+			Count: found[c],
+		}
+	}
+
+	return res
+}
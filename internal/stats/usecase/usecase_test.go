@@ -0,0 +1,173 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	mockauthz "quizapp/internal/authz/mock"
+	mocks "quizapp/internal/stats/mock"
+	"quizapp/internal/stats/usecase"
+	mockv "quizapp/internal/version/mock"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestStatsUseCase_GetByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoS := mocks.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockRepoV := mockv.NewMockRepo(ctrl)
+
+	uc := usecase.NewStatsUseCase(mockRepoS, mockAuthz, mockRepoV)
+
+	type mockBehavior func(ctx context.Context, form_id, version_id string)
+
+	min, max := 1.0, 5.0
+	questions := []*models.Question{
+		{Id: "1", Form_id: "5", Header: "name", Type: models.QuestionTypeText},
+		{Id: "2", Form_id: "5", Header: "color", Type: models.QuestionTypeSingleChoice, Options: models.QuestionOptions{Choices: []string{"red", "green"}}},
+		{Id: "3", Form_id: "5", Header: "pets", Type: models.QuestionTypeMultipleChoice, Options: models.QuestionOptions{Choices: []string{"cat", "dog"}}},
+		{Id: "4", Form_id: "5", Header: "rate", Type: models.QuestionTypeScale, Options: models.QuestionOptions{Min: &min, Max: &max}},
+	}
+
+	foundversion := models.FormVersion{
+		Id:        "10",
+		Form_id:   "5",
+		Number:    2,
+		Questions: questions,
+	}
+
+	day := time.Date(2023, 5, 1, 0, 0, 0, 0, time.UTC)
+	daily := []*models.DailyCount{
+		{Day: day, Count: 3},
+		{Day: day.AddDate(0, 0, 1), Count: 2},
+	}
+
+	numeric := models.NumericStats{Min: 1, Max: 5, Mean: 3.5, Median: 4, P25: 2, P75: 5, P90: 5}
+
+	expectAggregates := func(ctx context.Context, form_id, version_id string) {
+		mockRepoS.EXPECT().CountByDay(ctx, form_id, version_id).Return(daily, nil)
+		mockRepoS.EXPECT().CountAnswered(ctx, form_id, version_id).Return(map[string]int{"1": 4, "2": 5, "3": 2, "4": 4}, nil)
+		mockRepoS.EXPECT().CountChoices(ctx, form_id, version_id, []string{"2"}, false).Return(map[string][]*models.ChoiceCount{
+			"2": {{Value: "green", Count: 4}, {Value: "red", Count: 1}},
+		}, nil)
+		mockRepoS.EXPECT().CountChoices(ctx, form_id, version_id, []string{"3"}, true).Return(map[string][]*models.ChoiceCount{
+			"3": {{Value: "dog", Count: 2}, {Value: "fish", Count: 1}},
+		}, nil)
+		mockRepoS.EXPECT().GetNumeric(ctx, form_id, version_id, []string{"4"}).Return(map[string]*models.NumericStats{
+			"4": &numeric,
+		}, nil)
+	}
+
+	expectedQuestions := []*models.QuestionStats{
+		{Question: questions[0], Answered: 4},
+		{Question: questions[1], Answered: 5, Choices: []*models.ChoiceCount{{Value: "red", Count: 1}, {Value: "green", Count: 4}}},
+		{Question: questions[2], Answered: 2, Choices: []*models.ChoiceCount{{Value: "cat", Count: 0}, {Value: "dog", Count: 2}}},
+		{Question: questions[3], Answered: 4, Numeric: &numeric},
+	}
+
+	testTable := []struct {
+		nameTest      string
+		ctx           context.Context
+		form_id       string
+		version_id    string
+		mockBehavior  mockBehavior
+		expectedStats *models.FormStats
+		expectedErr   error
+	}{
+		{
+			nameTest: "ok_latest",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(&foundversion, nil)
+				expectAggregates(ctx, form_id, version_id)
+			},
+			expectedStats: &models.FormStats{
+				Form_id:   "5",
+				Total:     5,
+				Daily:     daily,
+				Questions: expectedQuestions,
+			},
+		},
+		{
+			nameTest:   "ok_version",
+			ctx:        context.Background(),
+			form_id:    "5",
+			version_id: "10",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetById(ctx, version_id).Return(&foundversion, nil)
+				expectAggregates(ctx, form_id, version_id)
+			},
+			expectedStats: &models.FormStats{
+				Form_id:    "5",
+				Version_id: "10",
+				Total:      5,
+				Daily:      daily,
+				Questions:  expectedQuestions,
+			},
+		},
+		{
+			nameTest:   "version_of_other_form",
+			ctx:        context.Background(),
+			form_id:    "6",
+			version_id: "10",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetById(ctx, version_id).Return(&foundversion, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "not_published",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(nil, errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+		{
+			nameTest: "not_owner",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "aggregate_error",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, version_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionResponseView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(&foundversion, nil)
+				mockRepoS.EXPECT().CountByDay(ctx, form_id, version_id).Return(daily, nil)
+				mockRepoS.EXPECT().CountAnswered(ctx, form_id, version_id).Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.version_id)
+
+			got, err := uc.GetByFormId(testCase.ctx, testCase.form_id, testCase.version_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedStats, got)
+		})
+	}
+}
@@ -0,0 +1,11 @@
+package version
+
+import "github.com/gin-gonic/gin"
+
+// Version HTTP Handlers interface
+type Handlers interface {
+	Publish() gin.HandlerFunc
+	GetLatest() gin.HandlerFunc
+	GetByFormId() gin.HandlerFunc
+	GetById() gin.HandlerFunc
+}
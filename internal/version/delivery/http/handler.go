@@ -0,0 +1,254 @@
+package http
+
+import (
+	"net/http"
+	"quizapp/internal/version"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/questiondto"
+	"quizapp/pkg/types"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type conditionDTO struct {
+	Question_id string `json:"question_id"`
+	Op          string `json:"op" enums:"equals,not_equals,answered,not_answered"`
+	Value       string `json:"value,omitempty"`
+}
+
+type questionResponse struct {
+	Id         string              `json:"id"`
+	Header     string              `json:"header"`
+	Type       string              `json:"type"`
+	Options    questiondto.Options `json:"options"`
+	Section_id string              `json:"section_id,omitempty"`
+	Condition  *conditionDTO       `json:"condition,omitempty"`
+	Required   bool                `json:"required"`
+	Rules      questiondto.Rules   `json:"rules"`
+	Correct    string              `json:"correct,omitempty"`
+	Points     int                 `json:"points"`
+}
+
+type sectionResponse struct {
+	Id          string        `json:"id"`
+	Title       string        `json:"title"`
+	Description string        `json:"description"`
+	Condition   *conditionDTO `json:"condition,omitempty"`
+}
+
+type versionResponse struct {
+	Id          string              `json:"id"`
+	Form_id     string              `json:"form_id"`
+	Number      int                 `json:"number"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Questions   []*questionResponse `json:"questions"`
+	Sections    []*sectionResponse  `json:"sections"`
+	Created_at  time.Time           `json:"created_at"`
+}
+
+type versionsResponse struct {
+	Versions []*versionResponse `json:"versions"`
+}
+
+type versionHandlers struct {
+	versionUC  version.UseCase
+	ctxUserKey string
+}
+
+func NewVersionHandlers(versionUC version.UseCase, ctxUserKey string) version.Handlers {
+	return &versionHandlers{
+		versionUC:  versionUC,
+		ctxUserKey: ctxUserKey,
+	}
+}
+
+// Publish godoc
+// @Summary Publish form
+// @Description Publish current form title, description and questions as new immutable version. Answers are accepted for the latest version only
+// @Tags Versions
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Success 201 {object} versionResponse
+// @Failure 204   "No such form"
+// @Failure 400   "Invalid form id, form has no questions or condition refers to later question"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner nor editor or permission denied"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/versions [post]
+func (h *versionHandlers) Publish() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		createdversion, err := h.versionUC.Publish(c, c.Param("formid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, versionBLToResponse(createdversion))
+	}
+}
+
+// GetLatest godoc
+// @Summary Get latest version
+// @Description Get latest published version of form to be answered
+// @Tags Versions
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Success 200 {object} versionResponse "Found"
+// @Failure 204   "Form is not published"
+// @Failure 400   "Invalid form id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner or member nor moderator or admin"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/versions/latest [get]
+func (h *versionHandlers) GetLatest() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		foundversion, err := h.versionUC.GetLatestByFormId(c, c.Param("formid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, versionBLToResponse(foundversion))
+	}
+}
+
+// GetByFormId godoc
+// @Summary Get versions
+// @Description Get published versions of form ordered by number
+// @Tags Versions
+// @Security JWTToken
+// @Param limit query int true "limit" minimum(1)
+// @Param offset query int true "offset" minimum(0)
+// @Param formid path string true "form id"
+// @Success 200 {object} versionsResponse "Found"
+// @Failure 204 {object} versionsResponse "No versions"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner or member nor moderator or admin"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/versions [get]
+func (h *versionHandlers) GetByFormId() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, offset, ok := types.ValidateGetSets(c.Query("limit"), c.Query("offset"))
+		if !ok {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		foundversions, err := h.versionUC.GetByFormId(c, c.Param("formid"), types.GetSets{
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		status := http.StatusOK
+
+		if len(foundversions) == 0 {
+			status = http.StatusNoContent
+		}
+
+		c.JSON(status, &versionsResponse{
+			Versions: versionsBLToResponse(foundversions),
+		})
+	}
+}
+
+// GetById godoc
+// @Summary Get version
+// @Description Get published version by id
+// @Tags Versions
+// @Security JWTToken
+// @Param formid path string true "form id"
+// @Param versionid path string true "version id"
+// @Success 200 {object} versionResponse "Found"
+// @Failure 204   "No such version"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is neither the form owner or member nor moderator or admin"
+// @Failure 500   "Other err"
+// @Router /forms/{formid}/versions/{versionid} [get]
+func (h *versionHandlers) GetById() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		foundversion, err := h.versionUC.GetById(c, c.Param("versionid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		if foundversion.Form_id != c.Param("formid") {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		c.JSON(http.StatusOK, versionBLToResponse(foundversion))
+	}
+}
+
+func versionBLToResponse(versionBL *models.FormVersion) *versionResponse {
+	questions := make([]*questionResponse, len(versionBL.Questions))
+
+	for i, q := range versionBL.Questions {
+		questions[i] = &questionResponse{
+			Id:         q.Id,
+			Header:     q.Header,
+			Type:       q.Type,
+			Options:    questiondto.OptionsBLToDTO(&q.Options),
+			Section_id: q.Section_id,
+			Condition:  conditionBLToResponse(q.Condition),
+			Required:   q.Required,
+			Rules:      questiondto.RulesBLToDTO(&q.Rules),
+			Correct:    q.Correct,
+			Points:     q.Points,
+		}
+	}
+
+	sections := make([]*sectionResponse, len(versionBL.Sections))
+
+	for i, s := range versionBL.Sections {
+		sections[i] = &sectionResponse{
+			Id:          s.Id,
+			Title:       s.Title,
+			Description: s.Description,
+			Condition:   conditionBLToResponse(s.Condition),
+		}
+	}
+
+	return &versionResponse{
+		Id:          versionBL.Id,
+		Form_id:     versionBL.Form_id,
+		Number:      versionBL.Number,
+		Title:       versionBL.Title,
+		Description: versionBL.Description,
+		Questions:   questions,
+		Sections:    sections,
+		Created_at:  versionBL.Created_at,
+	}
+}
+
+func conditionBLToResponse(modelBL *models.Condition) *conditionDTO {
+	if modelBL == nil {
+		return nil
+	}
+
+	return &conditionDTO{
+		Question_id: modelBL.Question_id,
+		Op:          modelBL.Op,
+		Value:       modelBL.Value,
+	}
+}
+
+func versionsBLToResponse(versionsBL []*models.FormVersion) []*versionResponse {
+	res := make([]*versionResponse, len(versionsBL))
+
+	for i, v := range versionsBL {
+		res[i] = versionBLToResponse(v)
+	}
+
+	return res
+}
@@ -0,0 +1,15 @@
+package http
+
+import (
+	"quizapp/internal/version"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Map version routes
+func MapVersionRoutes(versionGroup *gin.RouterGroup, h version.Handlers) {
+	versionGroup.POST("", h.Publish())
+	versionGroup.GET("", h.GetByFormId())
+	versionGroup.GET("/latest", h.GetLatest())
+	versionGroup.GET("/:versionid", h.GetById())
+}
@@ -0,0 +1,34 @@
+package version
+
+import (
+	"context"
+	"quizapp/models"
+	"quizapp/pkg/types"
+)
+
+type Repo interface {
+	// Creates version with next number of form.
+	// Returns created model & nil, if created.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Create(ctx context.Context, modelBL *models.FormVersion) (*models.FormVersion, error)
+
+	// Returns found model, if get.
+	// Returns nil & ErrContentNotFound, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetById(ctx context.Context, id string) (*models.FormVersion, error)
+
+	// Returns version with greatest number, if get.
+	// Returns nil & ErrContentNotFound, if form has no versions.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetLatestByFormId(ctx context.Context, form_id string) (*models.FormVersion, error)
+
+	// Returns slice ordered by number & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.FormVersion, error)
+}
@@ -0,0 +1,288 @@
+package repo
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"quizapp/internal/version"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"quizapp/pkg/snapshot"
+	"quizapp/pkg/types"
+	"strconv"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type VersionDB struct {
+	Id, FormId, Number int
+	Title, Description string
+	Questions          []byte
+	Sections           []byte
+	CreatedAt          time.Time
+}
+
+// Section as stored in version snapshot
+type versionSectionDB struct {
+	Id          int                 `json:"id"`
+	Title       string              `json:"title"`
+	Description string              `json:"description"`
+	Condition   *snapshot.Condition `json:"condition,omitempty"`
+}
+
+type versionRepo struct {
+	*postgres.Postgres
+}
+
+func NewVersionRepo(db *postgres.Postgres) version.Repo {
+	return &versionRepo{db}
+}
+
+func (v *versionRepo) Create(ctx context.Context, modelBL *models.FormVersion) (*models.FormVersion, error) {
+	versionDB, err := versionBLToDB(modelBL)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := v.Builder.
+		Insert("form_version_").
+		Columns("form_id_, number_, title_, description_, questions_, sections_").
+		Values(
+			versionDB.FormId,
+			squirrel.Expr("(SELECT COALESCE(MAX(number_), 0) + 1 FROM form_version_ WHERE form_id_ = ?)", versionDB.FormId),
+			versionDB.Title,
+			versionDB.Description,
+			string(versionDB.Questions),
+			string(versionDB.Sections)).
+		Suffix("RETURNING \"id_\", \"number_\", \"created_at_\"").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&versionDB.Id, &versionDB.Number, &versionDB.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return nil, errs.ErrForbidden
+		}
+
+		return nil, err
+	}
+
+	return versionDBToBL(versionDB)
+}
+
+func (v *versionRepo) GetById(ctx context.Context, id string) (*models.FormVersion, error) {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := v.Builder.
+		Select("form_id_, number_, title_, description_, questions_, sections_, created_at_").
+		From("form_version_").
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	modelDB := VersionDB{Id: intid}
+	err = v.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.FormId, &modelDB.Number, &modelDB.Title,
+		&modelDB.Description, &modelDB.Questions, &modelDB.Sections, &modelDB.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return versionDBToBL(&modelDB)
+}
+
+func (v *versionRepo) GetLatestByFormId(ctx context.Context, form_id string) (*models.FormVersion, error) {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := v.Builder.
+		Select("id_, number_, title_, description_, questions_, sections_, created_at_").
+		From("form_version_").
+		Where(squirrel.Eq{"form_id_": intformid}).
+		OrderBy("number_ DESC").
+		Limit(1).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	modelDB := VersionDB{FormId: intformid}
+	err = v.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.Id, &modelDB.Number, &modelDB.Title,
+		&modelDB.Description, &modelDB.Questions, &modelDB.Sections, &modelDB.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return versionDBToBL(&modelDB)
+}
+
+func (v *versionRepo) GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.FormVersion, error) {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := v.Builder.
+		Select("id_, number_, title_, description_, questions_, sections_, created_at_").
+		From("form_version_").
+		Where(squirrel.Eq{"form_id_": intformid}).
+		OrderBy("number_").
+		Limit(sets.Limit).
+		Offset(sets.Offset).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := v.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.FormVersion, 0)
+
+	for rows.Next() {
+		modelDB := VersionDB{FormId: intformid}
+
+		err = rows.Scan(&modelDB.Id, &modelDB.Number, &modelDB.Title,
+			&modelDB.Description, &modelDB.Questions, &modelDB.Sections, &modelDB.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		versionBL, err := versionDBToBL(&modelDB)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, versionBL)
+	}
+
+	return res, nil
+}
+
+func versionDBToBL(versionDB *VersionDB) (*models.FormVersion, error) {
+	questions, err := snapshot.UnmarshalQuestions(versionDB.Questions)
+	if err != nil {
+		return nil, err
+	}
+
+	var sectionsDB []versionSectionDB
+	if len(versionDB.Sections) != 0 {
+		err := json.Unmarshal(versionDB.Sections, &sectionsDB)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	formid := strconv.Itoa(versionDB.FormId)
+
+	for _, q := range questions {
+		q.Form_id = formid
+	}
+
+	sections := make([]*models.Section, len(sectionsDB))
+	for i, s := range sectionsDB {
+		sections[i] = &models.Section{
+			Id:          strconv.Itoa(s.Id),
+			Form_id:     formid,
+			Title:       s.Title,
+			Description: s.Description,
+			Position:    i + 1,
+			Condition:   snapshot.ConditionDBToBL(s.Condition),
+		}
+	}
+
+	return &models.FormVersion{
+		Id:          strconv.Itoa(versionDB.Id),
+		Form_id:     formid,
+		Title:       versionDB.Title,
+		Description: versionDB.Description,
+		Number:      versionDB.Number,
+		Questions:   questions,
+		Sections:    sections,
+		Created_at:  versionDB.CreatedAt,
+	}, nil
+}
+
+func versionBLToDB(versionBL *models.FormVersion) (*VersionDB, error) {
+	var (
+		err error
+		id  int
+	)
+
+	if versionBL.Id != "" {
+		id, err = strconv.Atoi(versionBL.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	fid, err := strconv.Atoi(versionBL.Form_id)
+	if err != nil {
+		return nil, err
+	}
+
+	questions, err := snapshot.MarshalQuestions(versionBL.Questions)
+	if err != nil {
+		return nil, err
+	}
+
+	sectionsDB := make([]versionSectionDB, len(versionBL.Sections))
+	for i, s := range versionBL.Sections {
+		sid, err := strconv.Atoi(s.Id)
+		if err != nil {
+			return nil, err
+		}
+
+		condition, err := snapshot.ConditionBLToDB(s.Condition)
+		if err != nil {
+			return nil, err
+		}
+
+		sectionsDB[i] = versionSectionDB{
+			Id:          sid,
+			Title:       s.Title,
+			Description: s.Description,
+			Condition:   condition,
+		}
+	}
+
+	sections, err := json.Marshal(sectionsDB)
+	if err != nil {
+		return nil, err
+	}
+
+	return &VersionDB{
+		Id:          id,
+		FormId:      fid,
+		Number:      versionBL.Number,
+		Title:       versionBL.Title,
+		Description: versionBL.Description,
+		Questions:   questions,
+		Sections:    sections,
+		CreatedAt:   versionBL.Created_at,
+	}, nil
+}
@@ -0,0 +1,464 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/version/repo"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"quizapp/pkg/types"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_builder   = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	_createdAt = time.Date(2023, 5, 1, 12, 0, 0, 0, time.UTC)
+)
+
+func TestVersionRepo_Create(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewVersionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, version *models.FormVersion)
+
+	const insertSQL = "INSERT INTO form_version_ (form_id_, number_, title_, description_, questions_, sections_) VALUES ($1,(SELECT COALESCE(MAX(number_), 0) + 1 FROM form_version_ WHERE form_id_ = $2),$3,$4,$5,$6) RETURNING \"id_\", \"number_\", \"created_at_\""
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		version         models.FormVersion
+		mockBehavior    mockBehavior
+		expectedVersion models.FormVersion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			version: models.FormVersion{
+				Form_id:     "12",
+				Title:       "title",
+				Description: "descr",
+				Questions: []*models.Question{
+					{
+						Id:      "345",
+						Form_id: "12",
+						Header:  "sdcsd",
+						Type:    models.QuestionTypeSingleChoice,
+						Options: models.QuestionOptions{
+							Choices: []string{"a", "b"},
+						},
+						Correct: "a",
+						Points:  2,
+					},
+					{
+						Id:         "346",
+						Form_id:    "12",
+						Section_id: "9",
+						Header:     "why",
+						Type:       models.QuestionTypeText,
+					},
+				},
+				Sections: []*models.Section{
+					{
+						Id:       "9",
+						Form_id:  "12",
+						Title:    "page",
+						Position: 1,
+						Condition: &models.Condition{
+							Question_id: "345",
+							Op:          models.ConditionOpEquals,
+							Value:       "b",
+						},
+					},
+				},
+			},
+			mockBehavior: func(ctx context.Context, version *models.FormVersion) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "number_", "created_at_"}).AddRow(7, 2, _createdAt).ToPgxRows()
+				pgxRows.Next()
+				formidint, _ := strconv.Atoi(version.Form_id)
+				mockPool.EXPECT().QueryRow(ctx, insertSQL, formidint, formidint, version.Title, version.Description,
+					`[{"id":345,"header":"sdcsd","type":"single_choice","options":{"choices":["a","b"]},"correct":"a","points":2},{"id":346,"section_id":9,"header":"why","type":"text","options":{}}]`,
+					`[{"id":9,"title":"page","description":"","condition":{"question_id":345,"op":"equals","value":"b"}}]`).Return(pgxRows)
+			},
+			expectedVersion: models.FormVersion{
+				Id:          "7",
+				Form_id:     "12",
+				Title:       "title",
+				Description: "descr",
+				Number:      2,
+				Questions: []*models.Question{
+					{
+						Id:      "345",
+						Form_id: "12",
+						Header:  "sdcsd",
+						Type:    models.QuestionTypeSingleChoice,
+						Options: models.QuestionOptions{
+							Choices: []string{"a", "b"},
+						},
+						Correct: "a",
+						Points:  2,
+					},
+					{
+						Id:         "346",
+						Form_id:    "12",
+						Section_id: "9",
+						Header:     "why",
+						Type:       models.QuestionTypeText,
+					},
+				},
+				Sections: []*models.Section{
+					{
+						Id:       "9",
+						Form_id:  "12",
+						Title:    "page",
+						Position: 1,
+						Condition: &models.Condition{
+							Question_id: "345",
+							Op:          models.ConditionOpEquals,
+							Value:       "b",
+						},
+					},
+				},
+				Created_at: _createdAt,
+			},
+		},
+		{
+			nameTest: "invalid_inputs",
+			ctx:      context.Background(),
+			version: models.FormVersion{
+				Form_id: "5r4",
+			},
+			mockBehavior: func(ctx context.Context, version *models.FormVersion) {},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			version: models.FormVersion{
+				Form_id: "12",
+			},
+			mockBehavior: func(ctx context.Context, version *models.FormVersion) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				formidint, _ := strconv.Atoi(version.Form_id)
+				mockPool.EXPECT().QueryRow(ctx, insertSQL, formidint, formidint, version.Title, version.Description, "[]", "[]").Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.version)
+
+			got, err := r.Create(testCase.ctx, &testCase.version)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedVersion, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestVersionRepo_GetById(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewVersionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	const selectSQL = "SELECT form_id_, number_, title_, description_, questions_, sections_, created_at_ FROM form_version_ WHERE id_ = $1"
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		id              string
+		mockBehavior    mockBehavior
+		expectedVersion models.FormVersion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "7",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"form_id_", "number_", "title_", "description_", "questions_", "sections_", "created_at_"}).
+					AddRow(12, 1, "title", "descr", []byte(`[{"id":345,"header":"sdcsd","type":"text","options":{}}]`), []byte("[]"), _createdAt).ToPgxRows()
+				pgxRows.Next()
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().QueryRow(ctx, selectSQL, idint).Return(pgxRows)
+			},
+			expectedVersion: models.FormVersion{
+				Id:          "7",
+				Form_id:     "12",
+				Title:       "title",
+				Description: "descr",
+				Number:      1,
+				Questions: []*models.Question{
+					{
+						Id:      "345",
+						Form_id: "12",
+						Header:  "sdcsd",
+						Type:    models.QuestionTypeText,
+					},
+				},
+				Sections:   []*models.Section{},
+				Created_at: _createdAt,
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			id:       "7",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				idint, _ := strconv.Atoi(id)
+				mockPool.EXPECT().QueryRow(ctx, selectSQL, idint).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := r.GetById(testCase.ctx, testCase.id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedVersion, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestVersionRepo_GetLatestByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewVersionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	const selectSQL = "SELECT id_, number_, title_, description_, questions_, sections_, created_at_ FROM form_version_ WHERE form_id_ = $1 ORDER BY number_ DESC LIMIT 1"
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		form_id         string
+		mockBehavior    mockBehavior
+		expectedVersion models.FormVersion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "number_", "title_", "description_", "questions_", "sections_", "created_at_"}).
+					AddRow(8, 2, "title", "descr", []byte("[]"), []byte("[]"), _createdAt).ToPgxRows()
+				pgxRows.Next()
+				formidint, _ := strconv.Atoi(form_id)
+				mockPool.EXPECT().QueryRow(ctx, selectSQL, formidint).Return(pgxRows)
+			},
+			expectedVersion: models.FormVersion{
+				Id:          "8",
+				Form_id:     "12",
+				Title:       "title",
+				Description: "descr",
+				Number:      2,
+				Questions:   []*models.Question{},
+				Sections:    []*models.Section{},
+				Created_at:  _createdAt,
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			mockBehavior: func(ctx context.Context, form_id string) {},
+		},
+		{
+			nameTest: "no_rows",
+			ctx:      context.Background(),
+			form_id:  "12",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				formidint, _ := strconv.Atoi(form_id)
+				mockPool.EXPECT().QueryRow(ctx, selectSQL, formidint).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			got, err := r.GetLatestByFormId(testCase.ctx, testCase.form_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedVersion, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestVersionRepo_GetByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewVersionRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id string, sets types.GetSets)
+
+	const selectSQL = "SELECT id_, number_, title_, description_, questions_, sections_, created_at_ FROM form_version_ WHERE form_id_ = $1 ORDER BY number_ LIMIT 0 OFFSET 0"
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		form_id          string
+		sets             types.GetSets
+		mockBehavior     mockBehavior
+		expectedVersions []*models.FormVersion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "12",
+			sets:     types.GetSets{},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "number_", "title_", "description_", "questions_", "sections_", "created_at_"}).
+					AddRow(7, 1, "title", "descr", []byte("[]"), []byte("[]"), _createdAt).
+					AddRow(8, 2, "title2", "descr", []byte("[]"), []byte("[]"), _createdAt).ToPgxRows()
+				formidint, _ := strconv.Atoi(form_id)
+				mockPool.EXPECT().Query(ctx, selectSQL, formidint).Return(pgxRows, nil)
+			},
+			expectedVersions: []*models.FormVersion{
+				{
+					Id:          "7",
+					Form_id:     "12",
+					Title:       "title",
+					Description: "descr",
+					Number:      1,
+					Questions:   []*models.Question{},
+					Sections:    []*models.Section{},
+					Created_at:  _createdAt,
+				},
+				{
+					Id:          "8",
+					Form_id:     "12",
+					Title:       "title2",
+					Description: "descr",
+					Number:      2,
+					Questions:   []*models.Question{},
+					Sections:    []*models.Section{},
+					Created_at:  _createdAt,
+				},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "5r4",
+			sets:         types.GetSets{},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			form_id:  "12",
+			sets:     types.GetSets{},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				formidint, _ := strconv.Atoi(form_id)
+				mockPool.EXPECT().Query(ctx, selectSQL, formidint).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.sets)
+
+			got, err := r.GetByFormId(testCase.ctx, testCase.form_id, testCase.sets)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedVersions, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
@@ -0,0 +1,44 @@
+package version
+
+import (
+	"context"
+	"quizapp/models"
+	"quizapp/pkg/types"
+)
+
+type UseCase interface {
+	// Snapshots current form title, description, questions and sections as new version.
+	// Returns created model & nil, if published.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs, form has neither questions nor draws
+	// or condition does not refer to earlier question.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither form owner nor editor or permission denied.
+	// Returns nil & other err else.
+	Publish(ctx context.Context, form_id string) (*models.FormVersion, error)
+
+	// Returns version to be answered & nil, if get.
+	// Returns nil & ErrContentNotFound, if form has no versions.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither form owner or member nor moderator or admin.
+	// Returns nil & other err else.
+	GetLatestByFormId(ctx context.Context, form_id string) (*models.FormVersion, error)
+
+	// Returns slice & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrContentNotFound, if no such form.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither form owner or member nor moderator or admin.
+	// Returns nil & other err else.
+	GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.FormVersion, error)
+
+	// Returns found model & nil, if get.
+	// Returns nil & ErrContentNotFound, if no such version.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is neither form owner or member nor moderator or admin.
+	// Returns nil & other err else.
+	GetById(ctx context.Context, id string) (*models.FormVersion, error)
+}
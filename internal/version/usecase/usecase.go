@@ -0,0 +1,124 @@
+package usecase
+
+import (
+	"context"
+	"quizapp/internal/authz"
+	"quizapp/internal/form"
+	"quizapp/internal/question"
+	"quizapp/internal/section"
+	"quizapp/internal/version"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/transactor"
+	"quizapp/pkg/types"
+)
+
+type versionUseCase struct {
+	versionRepo  version.Repo
+	formRepo     form.Repo
+	authorizer   authz.Authorizer
+	questionRepo question.Repo
+	sectionRepo  section.Repo
+	transactor   transactor.Transactor
+}
+
+func NewVersionUseCase(versionRepo version.Repo, formRepo form.Repo, authorizer authz.Authorizer, questionRepo question.Repo, sectionRepo section.Repo, transactor transactor.Transactor) version.UseCase {
+	return &versionUseCase{
+		versionRepo:  versionRepo,
+		formRepo:     formRepo,
+		authorizer:   authorizer,
+		questionRepo: questionRepo,
+		sectionRepo:  sectionRepo,
+		transactor:   transactor,
+	}
+}
+
+func (v *versionUseCase) Publish(ctx context.Context, form_id string) (*models.FormVersion, error) {
+	err := v.authorizer.AuthorizeForm(ctx, form_id, models.ActionFormEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	var createdversion *models.FormVersion
+
+	err = v.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		// form lock serializes numbering of its versions
+		err := v.formRepo.LockById(ctx, form_id)
+		if err != nil {
+			return err
+		}
+
+		foundform, err := v.formRepo.GetById(ctx, form_id)
+		if err != nil {
+			return err
+		}
+
+		questions, err := v.questionRepo.GetAllByFormId(ctx, form_id)
+		if err != nil {
+			return err
+		}
+
+		// form with draws may take all questions from bank
+		if len(questions) == 0 && len(foundform.Draws) == 0 {
+			return errs.ErrInvalidContent
+		}
+
+		sections, err := v.sectionRepo.GetByFormId(ctx, form_id)
+		if err != nil {
+			return err
+		}
+
+		newversion := &models.FormVersion{
+			Form_id:     form_id,
+			Title:       foundform.Title,
+			Description: foundform.Description,
+			Questions:   questions,
+			Sections:    sections,
+		}
+
+		// draft reordering may leave condition on later question
+		if !newversion.ValidateConditions() {
+			return errs.ErrInvalidContent
+		}
+
+		createdversion, err = v.versionRepo.Create(ctx, newversion)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdversion, nil
+}
+
+func (v *versionUseCase) GetLatestByFormId(ctx context.Context, form_id string) (*models.FormVersion, error) {
+	err := v.authorizer.AuthorizeForm(ctx, form_id, models.ActionFormView)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.versionRepo.GetLatestByFormId(ctx, form_id)
+}
+
+func (v *versionUseCase) GetByFormId(ctx context.Context, form_id string, sets types.GetSets) ([]*models.FormVersion, error) {
+	err := v.authorizer.AuthorizeForm(ctx, form_id, models.ActionFormView)
+	if err != nil {
+		return nil, err
+	}
+
+	return v.versionRepo.GetByFormId(ctx, form_id, sets)
+}
+
+func (v *versionUseCase) GetById(ctx context.Context, id string) (*models.FormVersion, error) {
+	foundversion, err := v.versionRepo.GetById(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	err = v.authorizer.AuthorizeForm(ctx, foundversion.Form_id, models.ActionFormView)
+	if err != nil {
+		return nil, err
+	}
+
+	return foundversion, nil
+}
@@ -0,0 +1,468 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	mockauthz "quizapp/internal/authz/mock"
+	mockf "quizapp/internal/form/mock"
+	mockq "quizapp/internal/question/mock"
+	mocksec "quizapp/internal/section/mock"
+	mockv "quizapp/internal/version/mock"
+	"quizapp/internal/version/usecase"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	mocktx "quizapp/pkg/transactor/mock"
+	"quizapp/pkg/types"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestVersionUseCase_Publish(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewVersionUseCase(mockRepoV, mockRepoF, mockAuthz, mockRepoQ, mockRepoS, mockTx)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	foundform := models.Form{
+		Id:          "5",
+		User_id:     "1",
+		Title:       "title",
+		Description: "descr",
+	}
+
+	questions := []*models.Question{
+		{
+			Id:      "7",
+			Form_id: "5",
+			Header:  "header",
+			Type:    models.QuestionTypeText,
+		},
+	}
+
+	drawingform := foundform
+	drawingform.Draws = []*models.Draw{{Tag: "go", Count: 3}}
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		form_id         string
+		mockBehavior    mockBehavior
+		expectedVersion models.FormVersion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoF.EXPECT().GetById(ctx, form_id).Return(&foundform, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(questions, nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return([]*models.Section{}, nil)
+				mockRepoV.EXPECT().Create(ctx, &models.FormVersion{
+					Form_id:     form_id,
+					Title:       foundform.Title,
+					Description: foundform.Description,
+					Questions:   questions,
+					Sections:    []*models.Section{},
+				}).Return(&models.FormVersion{
+					Id:          "10",
+					Form_id:     form_id,
+					Title:       foundform.Title,
+					Description: foundform.Description,
+					Number:      1,
+					Questions:   questions,
+				}, nil)
+			},
+			expectedVersion: models.FormVersion{
+				Id:          "10",
+				Form_id:     "5",
+				Title:       "title",
+				Description: "descr",
+				Number:      1,
+				Questions:   questions,
+			},
+		},
+		{
+			nameTest: "user_not_an_owner",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(errs.ErrForbidden)
+			},
+		},
+		{
+			nameTest: "no_such_form",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "no_questions",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoF.EXPECT().GetById(ctx, form_id).Return(&foundform, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return([]*models.Question{}, nil)
+			},
+		},
+		{
+			nameTest: "ok_only_bank_questions",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoF.EXPECT().GetById(ctx, form_id).Return(&drawingform, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return([]*models.Question{}, nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return([]*models.Section{}, nil)
+				mockRepoV.EXPECT().Create(ctx, &models.FormVersion{
+					Form_id:     form_id,
+					Title:       drawingform.Title,
+					Description: drawingform.Description,
+					Questions:   []*models.Question{},
+					Sections:    []*models.Section{},
+				}).Return(&models.FormVersion{
+					Id:          "10",
+					Form_id:     form_id,
+					Title:       drawingform.Title,
+					Description: drawingform.Description,
+					Number:      1,
+					Questions:   []*models.Question{},
+				}, nil)
+			},
+			expectedVersion: models.FormVersion{
+				Id:          "10",
+				Form_id:     "5",
+				Title:       "title",
+				Description: "descr",
+				Number:      1,
+				Questions:   []*models.Question{},
+			},
+		},
+		{
+			nameTest: "condition_on_later_question",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoF.EXPECT().GetById(ctx, form_id).Return(&foundform, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return([]*models.Question{
+					{
+						Id:        "7",
+						Form_id:   "5",
+						Type:      models.QuestionTypeText,
+						Condition: &models.Condition{Question_id: "8", Op: models.ConditionOpAnswered},
+					},
+					{Id: "8", Form_id: "5", Type: models.QuestionTypeText},
+				}, nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return([]*models.Section{}, nil)
+			},
+		},
+		{
+			nameTest: "create_error",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormEdit).Return(nil)
+				expectTx(ctx, mockTx)
+				mockRepoF.EXPECT().LockById(ctx, form_id).Return(nil)
+				mockRepoF.EXPECT().GetById(ctx, form_id).Return(&foundform, nil)
+				mockRepoQ.EXPECT().GetAllByFormId(ctx, form_id).Return(questions, nil)
+				mockRepoS.EXPECT().GetByFormId(ctx, form_id).Return([]*models.Section{}, nil)
+				mockRepoV.EXPECT().Create(ctx, gomock.Any()).Return(nil, errors.New("create_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			got, err := uc.Publish(testCase.ctx, testCase.form_id)
+
+			switch testCase.nameTest {
+			case "ok", "ok_only_bank_questions":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedVersion, *got)
+			case "user_not_an_owner":
+				assert.Equal(t, errs.ErrForbidden, err)
+			case "no_questions", "condition_on_later_question":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_such_form":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			case "create_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestVersionUseCase_GetByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewVersionUseCase(mockRepoV, mockRepoF, mockAuthz, mockRepoQ, mockRepoS, mockTx)
+
+	type mockBehavior func(ctx context.Context, form_id string, sets types.GetSets)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		form_id          string
+		sets             types.GetSets
+		mockBehavior     mockBehavior
+		expectedVersions []*models.FormVersion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "5",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(nil)
+				mockRepoV.EXPECT().GetByFormId(ctx, form_id, sets).Return([]*models.FormVersion{
+					{
+						Id:      "10",
+						Form_id: form_id,
+						Number:  1,
+					},
+				}, nil)
+			},
+			expectedVersions: []*models.FormVersion{
+				{
+					Id:      "10",
+					Form_id: "5",
+					Number:  1,
+				},
+			},
+		},
+		{
+			nameTest: "user_not_an_owner",
+			ctx:      context.Background(),
+			form_id:  "5",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, form_id string, sets types.GetSets) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(errs.ErrForbidden)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.sets)
+
+			got, err := uc.GetByFormId(testCase.ctx, testCase.form_id, testCase.sets)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedVersions, got)
+			case "user_not_an_owner":
+				assert.Equal(t, errs.ErrForbidden, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestVersionUseCase_GetLatestByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewVersionUseCase(mockRepoV, mockRepoF, mockAuthz, mockRepoQ, mockRepoS, mockTx)
+
+	type mockBehavior func(ctx context.Context, form_id string)
+
+	latest := &models.FormVersion{
+		Id:      "10",
+		Form_id: "5",
+		Number:  2,
+		Questions: []*models.Question{
+			{Id: "7", Header: "capital", Type: models.QuestionTypeText, Correct: "paris", Points: 1},
+		},
+	}
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		form_id         string
+		mockBehavior    mockBehavior
+		expectedVersion *models.FormVersion
+		expectedErr     error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(latest, nil)
+			},
+			expectedVersion: latest,
+		},
+		{
+			// correct answers of quiz are not shown to respondents
+			nameTest: "user_is_not_a_member",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "not_published",
+			ctx:      context.Background(),
+			form_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id string) {
+				mockAuthz.EXPECT().AuthorizeForm(ctx, form_id, models.ActionFormView).Return(nil)
+				mockRepoV.EXPECT().GetLatestByFormId(ctx, form_id).Return(nil, errs.ErrContentNotFound)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id)
+
+			got, err := uc.GetLatestByFormId(testCase.ctx, testCase.form_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedVersion, got)
+		})
+	}
+}
+
+func TestVersionUseCase_GetById(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepoV := mockv.NewMockRepo(ctrl)
+	mockRepoF := mockf.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockRepoQ := mockq.NewMockRepo(ctrl)
+	mockRepoS := mocksec.NewMockRepo(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	uc := usecase.NewVersionUseCase(mockRepoV, mockRepoF, mockAuthz, mockRepoQ, mockRepoS, mockTx)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		id              string
+		mockBehavior    mockBehavior
+		expectedVersion models.FormVersion
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "10",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepoV.EXPECT().GetById(ctx, id).Return(&models.FormVersion{
+					Id:      id,
+					Form_id: "5",
+					Number:  1,
+				}, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, "5", models.ActionFormView).Return(nil)
+			},
+			expectedVersion: models.FormVersion{
+				Id:      "10",
+				Form_id: "5",
+				Number:  1,
+			},
+		},
+		{
+			nameTest: "not_found",
+			ctx:      context.Background(),
+			id:       "10",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepoV.EXPECT().GetById(ctx, id).Return(nil, errs.ErrContentNotFound)
+			},
+		},
+		{
+			nameTest: "user_not_an_owner",
+			ctx:      context.Background(),
+			id:       "10",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockRepoV.EXPECT().GetById(ctx, id).Return(&models.FormVersion{
+					Id:      id,
+					Form_id: "5",
+				}, nil)
+				mockAuthz.EXPECT().AuthorizeForm(ctx, "5", models.ActionFormView).Return(errs.ErrForbidden)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := uc.GetById(testCase.ctx, testCase.id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedVersion, *got)
+			case "not_found":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			case "user_not_an_owner":
+				assert.Equal(t, errs.ErrForbidden, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func expectTx(ctx context.Context, mockTx *mocktx.MockTransactor) {
+	mockTx.EXPECT().WithinTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+}
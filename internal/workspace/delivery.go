@@ -0,0 +1,14 @@
+package workspace
+
+import "github.com/gin-gonic/gin"
+
+// Workspace HTTP Handlers interface
+type Handlers interface {
+	Create() gin.HandlerFunc
+	GetById() gin.HandlerFunc
+	GetByUser() gin.HandlerFunc
+	Delete() gin.HandlerFunc
+	Invite() gin.HandlerFunc
+	GetMembers() gin.HandlerFunc
+	RemoveMember() gin.HandlerFunc
+}
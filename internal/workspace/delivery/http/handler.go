@@ -0,0 +1,334 @@
+package http
+
+import (
+	"net/http"
+	"quizapp/internal/workspace"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/types"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+type workspaceCreateRequest struct {
+	Title string `json:"title" binding:"required"`
+}
+
+type workspaceResponse struct {
+	Id         string    `json:"id"`
+	User_id    string    `json:"user_id"`
+	Title      string    `json:"title"`
+	Created_at time.Time `json:"created_at"`
+}
+
+type workspacesResponse struct {
+	Workspaces []*workspaceResponse `json:"workspaces"`
+}
+
+type workspaceInviteRequest struct {
+	User_id string `json:"user_id" binding:"required"`
+	Role    string `json:"role" binding:"required" enums:"viewer,editor,owner"`
+}
+
+type workspaceMemberResponse struct {
+	Workspace_id string    `json:"workspace_id"`
+	User_id      string    `json:"user_id"`
+	Role         string    `json:"role"`
+	Created_at   time.Time `json:"created_at"`
+}
+
+type workspaceMembersResponse struct {
+	Members []*workspaceMemberResponse `json:"members"`
+}
+
+type workspaceHandlers struct {
+	workspaceUC workspace.UseCase
+	ctxUserKey  string
+}
+
+func NewWorkspaceHandlers(workspaceUC workspace.UseCase, ctxUserKey string) workspace.Handlers {
+	return &workspaceHandlers{
+		workspaceUC: workspaceUC,
+		ctxUserKey:  ctxUserKey,
+	}
+}
+
+// Create godoc
+// @Summary Create workspace
+// @Description Create workspace with current user as its owner member, forms created in workspace are shared with its members
+// @Tags Workspaces
+// @Security JWTToken
+// @Accept json
+// @Param data body workspaceCreateRequest true "workspace title"
+// @Success 201 {object} workspaceResponse
+// @Failure 400   "Invalid json"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "Permission denied"
+// @Failure 500   "Other err"
+// @Router /workspaces [post]
+func (h *workspaceHandlers) Create() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(workspaceCreateRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		createdworkspace, err := h.workspaceUC.Create(c, &models.Workspace{
+			Title: request.Title,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusCreated, workspaceBLToResponse(createdworkspace))
+	}
+}
+
+// GetById godoc
+// @Summary Get workspace
+// @Description Get workspace by id, available to its members
+// @Tags Workspaces
+// @Security JWTToken
+// @Param workspaceid path string true "workspace id"
+// @Success 200 {object} workspaceResponse
+// @Failure 204   "No such workspace"
+// @Failure 400   "Invalid id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not member of workspace"
+// @Failure 500   "Other err"
+// @Router /workspaces/{workspaceid} [get]
+func (h *workspaceHandlers) GetById() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		foundworkspace, err := h.workspaceUC.GetById(c, c.Param("workspaceid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, workspaceBLToResponse(foundworkspace))
+	}
+}
+
+// GetByUser godoc
+// @Summary Get workspaces
+// @Description Get workspaces current user is member of
+// @Tags Workspaces
+// @Security JWTToken
+// @Param limit query int true "limit" minimum(1)
+// @Param offset query int true "offset" minimum(0)
+// @Success 200 {object} workspacesResponse "Found"
+// @Failure 204 {object} workspacesResponse "No workspaces"
+// @Failure 400   "Invalid limit and/or offset"
+// @Failure 401   "Unauthorized"
+// @Failure 500   "Other err"
+// @Router /workspaces [get]
+func (h *workspaceHandlers) GetByUser() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		currentuser, ok := c.Value(h.ctxUserKey).(*models.User)
+		if !ok {
+			c.AbortWithStatus(http.StatusUnauthorized)
+			return
+		}
+
+		limit, offset, ok := types.ValidateGetSets(c.Query("limit"), c.Query("offset"))
+		if !ok {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		foundworkspaces, err := h.workspaceUC.GetByUserId(c, currentuser.Id, types.GetSets{
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		status := http.StatusOK
+
+		if len(foundworkspaces) == 0 {
+			status = http.StatusNoContent
+		}
+
+		c.JSON(status, &workspacesResponse{
+			Workspaces: workspacesBLToResponse(foundworkspaces),
+		})
+	}
+}
+
+// Delete godoc
+// @Summary Delete workspace
+// @Description Delete workspace, its forms stay with their creators
+// @Tags Workspaces
+// @Security JWTToken
+// @Param workspaceid path string true "workspace id"
+// @Success 200   "Deleted"
+// @Failure 204   "No such workspace"
+// @Failure 400   "Invalid id"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not member of workspace with owner role or permission denied"
+// @Failure 500   "Other err"
+// @Router /workspaces/{workspaceid} [delete]
+func (h *workspaceHandlers) Delete() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.workspaceUC.Delete(c, c.Param("workspaceid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+// Invite godoc
+// @Summary Invite workspace member
+// @Description Add user to workspace as viewer, editor or owner, role of member is changed, if user is member already. Workspace role applies to all forms of workspace
+// @Tags Workspaces
+// @Security JWTToken
+// @Param workspaceid path string true "workspace id"
+// @Param data body workspaceInviteRequest true "user id and role"
+// @Success 200 {object} workspaceMemberResponse
+// @Failure 204   "No such workspace"
+// @Failure 400   "Invalid json, unknown role, no such user or user created workspace"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not member of workspace with owner role or permission denied"
+// @Failure 500   "Other err"
+// @Router /workspaces/{workspaceid}/members [post]
+func (h *workspaceHandlers) Invite() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		request := new(workspaceInviteRequest)
+
+		err := c.ShouldBindJSON(request)
+		if err != nil {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		savedmember, err := h.workspaceUC.Invite(c, &models.WorkspaceMember{
+			Workspace_id: c.Param("workspaceid"),
+			User_id:      request.User_id,
+			Role:         request.Role,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.JSON(http.StatusOK, memberBLToResponse(savedmember))
+	}
+}
+
+// GetMembers godoc
+// @Summary Get workspace members
+// @Description Get members of workspace with their roles
+// @Tags Workspaces
+// @Security JWTToken
+// @Param workspaceid path string true "workspace id"
+// @Param limit query int true "limit" minimum(1)
+// @Param offset query int true "offset" minimum(0)
+// @Success 200 {object} workspaceMembersResponse "Found"
+// @Failure 204 {object} workspaceMembersResponse "No members"
+// @Failure 400   "Invalid params"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not member of workspace"
+// @Failure 500   "Other err"
+// @Router /workspaces/{workspaceid}/members [get]
+func (h *workspaceHandlers) GetMembers() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		limit, offset, ok := types.ValidateGetSets(c.Query("limit"), c.Query("offset"))
+		if !ok {
+			c.AbortWithStatus(http.StatusBadRequest)
+			return
+		}
+
+		foundmembers, err := h.workspaceUC.GetMembers(c, c.Param("workspaceid"), types.GetSets{
+			Limit:  limit,
+			Offset: offset,
+		})
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		status := http.StatusOK
+
+		if len(foundmembers) == 0 {
+			status = http.StatusNoContent
+		}
+
+		c.JSON(status, &workspaceMembersResponse{
+			Members: membersBLToResponse(foundmembers),
+		})
+	}
+}
+
+// RemoveMember godoc
+// @Summary Remove workspace member
+// @Description Remove member from workspace, any member but creator may leave workspace
+// @Tags Workspaces
+// @Security JWTToken
+// @Param workspaceid path string true "workspace id"
+// @Param userid path string true "user id"
+// @Success 200   "Removed"
+// @Failure 204   "No such workspace or user is not a member"
+// @Failure 400   "Invalid params or user created workspace"
+// @Failure 401   "Unauthorized"
+// @Failure 403   "User is not member of workspace with owner role or permission denied"
+// @Failure 500   "Other err"
+// @Router /workspaces/{workspaceid}/members/{userid} [delete]
+func (h *workspaceHandlers) RemoveMember() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		err := h.workspaceUC.RemoveMember(c, c.Param("workspaceid"), c.Param("userid"))
+		if err != nil {
+			c.AbortWithStatus(errs.MatchHttpErr(err))
+			return
+		}
+
+		c.Status(http.StatusOK)
+	}
+}
+
+func workspaceBLToResponse(modelBL *models.Workspace) *workspaceResponse {
+	return &workspaceResponse{
+		Id:         modelBL.Id,
+		User_id:    modelBL.User_id,
+		Title:      modelBL.Title,
+		Created_at: modelBL.Created_at,
+	}
+}
+
+func workspacesBLToResponse(modelsBL []*models.Workspace) []*workspaceResponse {
+	res := make([]*workspaceResponse, len(modelsBL))
+
+	for i, w := range modelsBL {
+		res[i] = workspaceBLToResponse(w)
+	}
+
+	return res
+}
+
+func memberBLToResponse(modelBL *models.WorkspaceMember) *workspaceMemberResponse {
+	return &workspaceMemberResponse{
+		Workspace_id: modelBL.Workspace_id,
+		User_id:      modelBL.User_id,
+		Role:         modelBL.Role,
+		Created_at:   modelBL.Created_at,
+	}
+}
+
+func membersBLToResponse(modelsBL []*models.WorkspaceMember) []*workspaceMemberResponse {
+	res := make([]*workspaceMemberResponse, len(modelsBL))
+
+	for i, m := range modelsBL {
+		res[i] = memberBLToResponse(m)
+	}
+
+	return res
+}
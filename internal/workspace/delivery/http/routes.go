@@ -0,0 +1,22 @@
+package http
+
+import (
+	"quizapp/internal/workspace"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Map workspace routes
+func MapWorkspaceRoutes(workspaceGroup *gin.RouterGroup, h workspace.Handlers) {
+	workspaceGroup.POST("", h.Create())
+	workspaceGroup.GET("", h.GetByUser())
+	workspaceGroup.GET("/:workspaceid", h.GetById())
+	workspaceGroup.DELETE("/:workspaceid", h.Delete())
+}
+
+// Map workspace member routes
+func MapWorkspaceMemberRoutes(memberGroup *gin.RouterGroup, h workspace.Handlers) {
+	memberGroup.POST("", h.Invite())
+	memberGroup.GET("", h.GetMembers())
+	memberGroup.DELETE("/:userid", h.RemoveMember())
+}
@@ -0,0 +1,66 @@
+package workspace
+
+import (
+	"context"
+	"quizapp/models"
+	"quizapp/pkg/types"
+)
+
+type Repo interface {
+	// Returns created model & nil, if created.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Create(ctx context.Context, modelBL *models.Workspace) (*models.Workspace, error)
+
+	// Returns found model & nil, if get.
+	// Returns nil & ErrContentNotFound, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetById(ctx context.Context, id string) (*models.Workspace, error)
+
+	// Returns workspaces user is member of ordered by id & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.Workspace, error)
+
+	// Returns nil, if deleted.
+	// Returns ErrContentNotFound, if nothing to delete.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	Delete(ctx context.Context, id string) error
+
+	// Adds member to workspace or changes role of existing member.
+	// Returns saved model & nil, if saved.
+	// Returns nil & ErrInvalidContent, if invalid inputs or no such user or workspace.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	UpsertMember(ctx context.Context, member *models.WorkspaceMember) (*models.WorkspaceMember, error)
+
+	// Returns slice of workspace members & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetMembers(ctx context.Context, workspace_id string, sets types.GetSets) ([]*models.WorkspaceMember, error)
+
+	// Returns found model & nil, if user is member of workspace.
+	// Returns nil & ErrContentNotFound, if user is not member of workspace.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetMember(ctx context.Context, workspace_id, user_id string) (*models.WorkspaceMember, error)
+
+	// Returns membership of user in workspace form is created in & nil, if found.
+	// Returns nil & ErrContentNotFound, if form is personal or user is not member of its workspace.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetMemberByFormId(ctx context.Context, form_id, user_id string) (*models.WorkspaceMember, error)
+
+	// Returns nil, if deleted.
+	// Returns ErrContentNotFound, if user is not member of workspace.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrForbidden, if permission denied.
+	// Returns other errors else.
+	DeleteMember(ctx context.Context, workspace_id, user_id string) error
+}
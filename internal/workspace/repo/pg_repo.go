@@ -0,0 +1,394 @@
+package repo
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/workspace"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"quizapp/pkg/types"
+	"strconv"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type WorkspaceDB struct {
+	Id, UserId int
+	Title      string
+	CreatedAt  time.Time
+}
+
+type WorkspaceMemberDB struct {
+	WorkspaceId, UserId int
+	Role                string
+	CreatedAt           time.Time
+}
+
+type workspaceRepo struct {
+	*postgres.Postgres
+}
+
+func NewWorkspaceRepo(db *postgres.Postgres) workspace.Repo {
+	return &workspaceRepo{db}
+}
+
+func (w *workspaceRepo) Create(ctx context.Context, modelBL *models.Workspace) (*models.Workspace, error) {
+	modelDB, err := workspaceBLToDB(modelBL)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := w.Builder.
+		Insert("workspace_").
+		Columns("user_id_, title_").
+		Values(modelDB.UserId, modelDB.Title).
+		Suffix("RETURNING \"id_\", \"created_at_\"").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.Id, &modelDB.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return nil, errs.ErrForbidden
+		}
+
+		return nil, err
+	}
+
+	return workspaceDBToBL(modelDB), nil
+}
+
+func (w *workspaceRepo) GetById(ctx context.Context, id string) (*models.Workspace, error) {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := w.Builder.
+		Select("user_id_, title_, created_at_").
+		From("workspace_").
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	modelDB := WorkspaceDB{Id: intid}
+	err = w.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.UserId, &modelDB.Title, &modelDB.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return workspaceDBToBL(&modelDB), nil
+}
+
+func (w *workspaceRepo) GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.Workspace, error) {
+	intuserid, err := strconv.Atoi(user_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := w.Builder.
+		Select("w.id_, w.user_id_, w.title_, w.created_at_").
+		From("workspace_ w").
+		Join("workspace_member_ m ON m.workspace_id_ = w.id_").
+		Where(squirrel.Eq{"m.user_id_": intuserid}).
+		OrderBy("w.id_").
+		Limit(sets.Limit).
+		Offset(sets.Offset).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.Workspace, 0)
+
+	for rows.Next() {
+		modelDB := WorkspaceDB{}
+
+		err = rows.Scan(&modelDB.Id, &modelDB.UserId, &modelDB.Title, &modelDB.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, workspaceDBToBL(&modelDB))
+	}
+
+	return res, nil
+}
+
+func (w *workspaceRepo) Delete(ctx context.Context, id string) error {
+	intid, err := strconv.Atoi(id)
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := w.Builder.
+		Delete("workspace_").
+		Where(squirrel.Eq{"id_": intid}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := w.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func (w *workspaceRepo) UpsertMember(ctx context.Context, modelBL *models.WorkspaceMember) (*models.WorkspaceMember, error) {
+	modelDB, err := memberBLToDB(modelBL)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	// invited member keeps date of joining, if role is changed
+	sql, args, err := w.Builder.
+		Insert("workspace_member_").
+		Columns("workspace_id_, user_id_, role_").
+		Values(modelDB.WorkspaceId, modelDB.UserId, modelDB.Role).
+		Suffix("ON CONFLICT (workspace_id_, user_id_) DO UPDATE SET role_ = EXCLUDED.role_ RETURNING \"created_at_\"").
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.CreatedAt)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) {
+			switch pgErr.Code {
+			case postgres.PermDenied:
+				return nil, errs.ErrForbidden
+			case postgres.ForeignKeyViolation:
+				return nil, errs.ErrInvalidContent
+			}
+		}
+
+		return nil, err
+	}
+
+	return memberDBToBL(modelDB), nil
+}
+
+func (w *workspaceRepo) GetMembers(ctx context.Context, workspace_id string, sets types.GetSets) ([]*models.WorkspaceMember, error) {
+	intworkspaceid, err := strconv.Atoi(workspace_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := w.Builder.
+		Select("user_id_, role_, created_at_").
+		From("workspace_member_").
+		Where(squirrel.Eq{"workspace_id_": intworkspaceid}).
+		OrderBy("created_at_, user_id_").
+		Limit(sets.Limit).
+		Offset(sets.Offset).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := w.Conn(ctx).Query(ctx, sql, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	res := make([]*models.WorkspaceMember, 0)
+
+	for rows.Next() {
+		modelDB := WorkspaceMemberDB{WorkspaceId: intworkspaceid}
+
+		err = rows.Scan(&modelDB.UserId, &modelDB.Role, &modelDB.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+
+		res = append(res, memberDBToBL(&modelDB))
+	}
+
+	return res, nil
+}
+
+func (w *workspaceRepo) GetMember(ctx context.Context, workspace_id, user_id string) (*models.WorkspaceMember, error) {
+	modelDB, err := memberBLToDB(&models.WorkspaceMember{Workspace_id: workspace_id, User_id: user_id})
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := w.Builder.
+		Select("role_, created_at_").
+		From("workspace_member_").
+		Where(squirrel.Eq{"workspace_id_": modelDB.WorkspaceId, "user_id_": modelDB.UserId}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.Role, &modelDB.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return memberDBToBL(modelDB), nil
+}
+
+func (w *workspaceRepo) GetMemberByFormId(ctx context.Context, form_id, user_id string) (*models.WorkspaceMember, error) {
+	intformid, err := strconv.Atoi(form_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	intuserid, err := strconv.Atoi(user_id)
+	if err != nil {
+		return nil, errs.ErrInvalidContent
+	}
+
+	sql, args, err := w.Builder.
+		Select("m.workspace_id_, m.role_, m.created_at_").
+		From("workspace_member_ m").
+		Join("form_ f ON f.workspace_id_ = m.workspace_id_").
+		Where(squirrel.Eq{"f.id_": intformid, "m.user_id_": intuserid}).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+
+	modelDB := WorkspaceMemberDB{UserId: intuserid}
+	err = w.Conn(ctx).QueryRow(ctx, sql, args...).Scan(&modelDB.WorkspaceId, &modelDB.Role, &modelDB.CreatedAt)
+	if err != nil {
+		if err == pgx.ErrNoRows {
+			return nil, errs.ErrContentNotFound
+		}
+
+		return nil, err
+	}
+
+	return memberDBToBL(&modelDB), nil
+}
+
+func (w *workspaceRepo) DeleteMember(ctx context.Context, workspace_id, user_id string) error {
+	modelDB, err := memberBLToDB(&models.WorkspaceMember{Workspace_id: workspace_id, User_id: user_id})
+	if err != nil {
+		return errs.ErrInvalidContent
+	}
+
+	sql, args, err := w.Builder.
+		Delete("workspace_member_").
+		Where(squirrel.Eq{"workspace_id_": modelDB.WorkspaceId, "user_id_": modelDB.UserId}).
+		ToSql()
+	if err != nil {
+		return err
+	}
+
+	res, err := w.Conn(ctx).Exec(ctx, sql, args...)
+	if err != nil {
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == postgres.PermDenied {
+			return errs.ErrForbidden
+		}
+
+		return err
+	}
+
+	if res.RowsAffected() == 0 {
+		return errs.ErrContentNotFound
+	}
+
+	return nil
+}
+
+func workspaceDBToBL(modelDB *WorkspaceDB) *models.Workspace {
+	return &models.Workspace{
+		Id:         strconv.Itoa(modelDB.Id),
+		User_id:    strconv.Itoa(modelDB.UserId),
+		Title:      modelDB.Title,
+		Created_at: modelDB.CreatedAt,
+	}
+}
+
+func workspaceBLToDB(modelBL *models.Workspace) (*WorkspaceDB, error) {
+	var (
+		err error
+		id  int
+	)
+
+	if modelBL.Id != "" {
+		id, err = strconv.Atoi(modelBL.Id)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	user_id, err := strconv.Atoi(modelBL.User_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorkspaceDB{
+		Id:     id,
+		UserId: user_id,
+		Title:  modelBL.Title,
+	}, nil
+}
+
+func memberDBToBL(modelDB *WorkspaceMemberDB) *models.WorkspaceMember {
+	return &models.WorkspaceMember{
+		Workspace_id: strconv.Itoa(modelDB.WorkspaceId),
+		User_id:      strconv.Itoa(modelDB.UserId),
+		Role:         modelDB.Role,
+		Created_at:   modelDB.CreatedAt,
+	}
+}
+
+func memberBLToDB(modelBL *models.WorkspaceMember) (*WorkspaceMemberDB, error) {
+	workspace_id, err := strconv.Atoi(modelBL.Workspace_id)
+	if err != nil {
+		return nil, err
+	}
+
+	user_id, err := strconv.Atoi(modelBL.User_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WorkspaceMemberDB{
+		WorkspaceId: workspace_id,
+		UserId:      user_id,
+		Role:        modelBL.Role,
+	}, nil
+}
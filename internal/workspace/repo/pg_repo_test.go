@@ -0,0 +1,676 @@
+package repo_test
+
+import (
+	"context"
+	"errors"
+	"quizapp/internal/workspace/repo"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/postgres"
+	"quizapp/pkg/types"
+	"testing"
+	"time"
+
+	"github.com/Masterminds/squirrel"
+	"github.com/driftprogramming/pgxpoolmock"
+	"github.com/golang/mock/gomock"
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+	"github.com/pashagolub/pgxmock"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	_builder   = squirrel.StatementBuilder.PlaceholderFormat(squirrel.Dollar)
+	_createdAt = time.Date(2023, 3, 1, 10, 0, 0, 0, time.UTC)
+)
+
+func TestWorkspaceRepo_Create(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewWorkspaceRepo(&db)
+
+	type mockBehavior func(ctx context.Context, workspace *models.Workspace)
+
+	testTable := []struct {
+		nameTest          string
+		ctx               context.Context
+		workspace         models.Workspace
+		mockBehavior      mockBehavior
+		expectedWorkspace models.Workspace
+	}{
+		{
+			nameTest:  "ok",
+			ctx:       context.Background(),
+			workspace: models.Workspace{User_id: "5", Title: "team"},
+			mockBehavior: func(ctx context.Context, workspace *models.Workspace) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "created_at_"}).AddRow(8, _createdAt).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO workspace_ (user_id_, title_) VALUES ($1,$2) RETURNING \"id_\", \"created_at_\"", 5, workspace.Title).Return(pgxRows)
+			},
+			expectedWorkspace: models.Workspace{Id: "8", User_id: "5", Title: "team", Created_at: _createdAt},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			workspace:    models.Workspace{User_id: "5r4", Title: "team"},
+			mockBehavior: func(ctx context.Context, workspace *models.Workspace) {},
+		},
+		{
+			nameTest:  "no_rows",
+			ctx:       context.Background(),
+			workspace: models.Workspace{User_id: "5", Title: "team"},
+			mockBehavior: func(ctx context.Context, workspace *models.Workspace) {
+				pgxRows := pgxpoolmock.NewRows([]string{}).AddRow().ToPgxRows()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO workspace_ (user_id_, title_) VALUES ($1,$2) RETURNING \"id_\", \"created_at_\"", 5, workspace.Title).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.workspace)
+
+			got, err := r.Create(testCase.ctx, &testCase.workspace)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedWorkspace, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_rows":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestWorkspaceRepo_GetById(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewWorkspaceRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest          string
+		ctx               context.Context
+		id                string
+		mockBehavior      mockBehavior
+		expectedWorkspace models.Workspace
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "8",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"user_id_", "title_", "created_at_"}).AddRow(5, "team", _createdAt).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, title_, created_at_ FROM workspace_ WHERE id_ = $1", 8).Return(pgxRows)
+			},
+			expectedWorkspace: models.Workspace{Id: "8", User_id: "5", Title: "team", Created_at: _createdAt},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+		},
+		{
+			nameTest: "no_workspace",
+			ctx:      context.Background(),
+			id:       "8",
+			mockBehavior: func(ctx context.Context, id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"user_id_", "title_", "created_at_"}).AddRow(nil, nil, nil).RowError(0, pgx.ErrNoRows).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT user_id_, title_, created_at_ FROM workspace_ WHERE id_ = $1", 8).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := r.GetById(testCase.ctx, testCase.id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedWorkspace, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "no_workspace":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestWorkspaceRepo_GetByUserId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewWorkspaceRepo(&db)
+
+	type mockBehavior func(ctx context.Context, user_id string, sets types.GetSets)
+
+	testTable := []struct {
+		nameTest           string
+		ctx                context.Context
+		user_id            string
+		sets               types.GetSets
+		mockBehavior       mockBehavior
+		expectedWorkspaces []*models.Workspace
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			user_id:  "5",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"id_", "user_id_", "title_", "created_at_"}).
+					AddRow(8, 5, "team", _createdAt).
+					AddRow(9, 6, "other team", _createdAt).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, "SELECT w.id_, w.user_id_, w.title_, w.created_at_ FROM workspace_ w JOIN workspace_member_ m ON m.workspace_id_ = w.id_ WHERE m.user_id_ = $1 ORDER BY w.id_ LIMIT 10 OFFSET 0", 5).Return(pgxRows, nil)
+			},
+			expectedWorkspaces: []*models.Workspace{
+				{Id: "8", User_id: "5", Title: "team", Created_at: _createdAt},
+				{Id: "9", User_id: "6", Title: "other team", Created_at: _createdAt},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			user_id:      "5r4",
+			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {},
+		},
+		{
+			nameTest: "query_error",
+			ctx:      context.Background(),
+			user_id:  "5",
+			sets:     types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, user_id string, sets types.GetSets) {
+				mockPool.EXPECT().Query(ctx, "SELECT w.id_, w.user_id_, w.title_, w.created_at_ FROM workspace_ w JOIN workspace_member_ m ON m.workspace_id_ = w.id_ WHERE m.user_id_ = $1 ORDER BY w.id_ LIMIT 10 OFFSET 0", 5).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.user_id, testCase.sets)
+
+			got, err := r.GetByUserId(testCase.ctx, testCase.user_id, testCase.sets)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedWorkspaces, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestWorkspaceRepo_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewWorkspaceRepo(&db)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			id:       "8",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM workspace_ WHERE id_ = $1", 8).Return(pgxmock.NewResult("DELETE", 1), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			id:           "5r4",
+			mockBehavior: func(ctx context.Context, id string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "no_workspace_to_delete",
+			ctx:      context.Background(),
+			id:       "8",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM workspace_ WHERE id_ = $1", 8).Return(pgxmock.NewResult("DELETE", 0), nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			err := r.Delete(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestWorkspaceRepo_UpsertMember(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewWorkspaceRepo(&db)
+
+	type mockBehavior func(ctx context.Context, member *models.WorkspaceMember)
+
+	testTable := []struct {
+		nameTest       string
+		ctx            context.Context
+		member         models.WorkspaceMember
+		mockBehavior   mockBehavior
+		expectedMember models.WorkspaceMember
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			member:   models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleEditor},
+			mockBehavior: func(ctx context.Context, member *models.WorkspaceMember) {
+				pgxRows := pgxpoolmock.NewRows([]string{"created_at_"}).AddRow(_createdAt).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO workspace_member_ (workspace_id_, user_id_, role_) VALUES ($1,$2,$3) ON CONFLICT (workspace_id_, user_id_) DO UPDATE SET role_ = EXCLUDED.role_ RETURNING \"created_at_\"", 8, 5, member.Role).Return(pgxRows)
+			},
+			expectedMember: models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleEditor, Created_at: _createdAt},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			member:       models.WorkspaceMember{Workspace_id: "8r", User_id: "5", Role: models.MemberRoleEditor},
+			mockBehavior: func(ctx context.Context, member *models.WorkspaceMember) {},
+		},
+		{
+			nameTest: "no_user",
+			ctx:      context.Background(),
+			member:   models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleViewer},
+			mockBehavior: func(ctx context.Context, member *models.WorkspaceMember) {
+				pgxRows := pgxpoolmock.NewRows([]string{"created_at_"}).AddRow(nil).RowError(0, &pgconn.PgError{Code: postgres.ForeignKeyViolation}).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "INSERT INTO workspace_member_ (workspace_id_, user_id_, role_) VALUES ($1,$2,$3) ON CONFLICT (workspace_id_, user_id_) DO UPDATE SET role_ = EXCLUDED.role_ RETURNING \"created_at_\"", 8, 5, member.Role).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.member)
+
+			got, err := r.UpsertMember(testCase.ctx, &testCase.member)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedMember, *got)
+			case "invalid_inputs", "no_user":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestWorkspaceRepo_GetMembers(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewWorkspaceRepo(&db)
+
+	type mockBehavior func(ctx context.Context, workspace_id string, sets types.GetSets)
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		workspace_id    string
+		sets            types.GetSets
+		mockBehavior    mockBehavior
+		expectedMembers []*models.WorkspaceMember
+	}{
+		{
+			nameTest:     "ok",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			sets:         types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, workspace_id string, sets types.GetSets) {
+				pgxRows := pgxpoolmock.NewRows([]string{"user_id_", "role_", "created_at_"}).
+					AddRow(5, "owner", _createdAt).
+					AddRow(6, "viewer", _createdAt).ToPgxRows()
+				mockPool.EXPECT().Query(ctx, "SELECT user_id_, role_, created_at_ FROM workspace_member_ WHERE workspace_id_ = $1 ORDER BY created_at_, user_id_ LIMIT 10 OFFSET 0", 8).Return(pgxRows, nil)
+			},
+			expectedMembers: []*models.WorkspaceMember{
+				{Workspace_id: "8", User_id: "5", Role: models.MemberRoleOwner, Created_at: _createdAt},
+				{Workspace_id: "8", User_id: "6", Role: models.MemberRoleViewer, Created_at: _createdAt},
+			},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			workspace_id: "5r4",
+			mockBehavior: func(ctx context.Context, workspace_id string, sets types.GetSets) {},
+		},
+		{
+			nameTest:     "query_error",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			sets:         types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, workspace_id string, sets types.GetSets) {
+				mockPool.EXPECT().Query(ctx, "SELECT user_id_, role_, created_at_ FROM workspace_member_ WHERE workspace_id_ = $1 ORDER BY created_at_, user_id_ LIMIT 10 OFFSET 0", 8).Return(nil, errors.New("query_error"))
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.workspace_id, testCase.sets)
+
+			got, err := r.GetMembers(testCase.ctx, testCase.workspace_id, testCase.sets)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedMembers, got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "query_error":
+				assert.NotEqual(t, nil, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestWorkspaceRepo_GetMember(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewWorkspaceRepo(&db)
+
+	type mockBehavior func(ctx context.Context, workspace_id, user_id string)
+
+	testTable := []struct {
+		nameTest              string
+		ctx                   context.Context
+		workspace_id, user_id string
+		mockBehavior          mockBehavior
+		expectedMember        models.WorkspaceMember
+	}{
+		{
+			nameTest:     "ok",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"role_", "created_at_"}).AddRow("editor", _createdAt).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT role_, created_at_ FROM workspace_member_ WHERE user_id_ = $1 AND workspace_id_ = $2", 5, 8).Return(pgxRows)
+			},
+			expectedMember: models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleEditor, Created_at: _createdAt},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			user_id:      "5r4",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {},
+		},
+		{
+			nameTest:     "not_member",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"role_", "created_at_"}).AddRow(nil, nil).RowError(0, pgx.ErrNoRows).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT role_, created_at_ FROM workspace_member_ WHERE user_id_ = $1 AND workspace_id_ = $2", 5, 8).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.workspace_id, testCase.user_id)
+
+			got, err := r.GetMember(testCase.ctx, testCase.workspace_id, testCase.user_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedMember, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "not_member":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestWorkspaceRepo_GetMemberByFormId(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewWorkspaceRepo(&db)
+
+	type mockBehavior func(ctx context.Context, form_id, user_id string)
+
+	testTable := []struct {
+		nameTest         string
+		ctx              context.Context
+		form_id, user_id string
+		mockBehavior     mockBehavior
+		expectedMember   models.WorkspaceMember
+	}{
+		{
+			nameTest: "ok",
+			ctx:      context.Background(),
+			form_id:  "3",
+			user_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"workspace_id_", "role_", "created_at_"}).AddRow(8, "viewer", _createdAt).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT m.workspace_id_, m.role_, m.created_at_ FROM workspace_member_ m JOIN form_ f ON f.workspace_id_ = m.workspace_id_ WHERE f.id_ = $1 AND m.user_id_ = $2", 3, 5).Return(pgxRows)
+			},
+			expectedMember: models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleViewer, Created_at: _createdAt},
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			form_id:      "3r",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {},
+		},
+		{
+			nameTest: "personal_form",
+			ctx:      context.Background(),
+			form_id:  "3",
+			user_id:  "5",
+			mockBehavior: func(ctx context.Context, form_id, user_id string) {
+				pgxRows := pgxpoolmock.NewRows([]string{"workspace_id_", "role_", "created_at_"}).AddRow(nil, nil, nil).RowError(0, pgx.ErrNoRows).ToPgxRows()
+				pgxRows.Next()
+				mockPool.EXPECT().QueryRow(ctx, "SELECT m.workspace_id_, m.role_, m.created_at_ FROM workspace_member_ m JOIN form_ f ON f.workspace_id_ = m.workspace_id_ WHERE f.id_ = $1 AND m.user_id_ = $2", 3, 5).Return(pgxRows)
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			got, err := r.GetMemberByFormId(testCase.ctx, testCase.form_id, testCase.user_id)
+
+			switch testCase.nameTest {
+			case "ok":
+				assert.Equal(t, nil, err)
+				assert.Equal(t, testCase.expectedMember, *got)
+			case "invalid_inputs":
+				assert.Equal(t, errs.ErrInvalidContent, err)
+			case "personal_form":
+				assert.Equal(t, errs.ErrContentNotFound, err)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestWorkspaceRepo_DeleteMember(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockPool := pgxpoolmock.NewMockPgxPool(ctrl)
+
+	db := postgres.Postgres{
+		Builder: _builder,
+		Pool:    mockPool,
+	}
+
+	r := repo.NewWorkspaceRepo(&db)
+
+	type mockBehavior func(ctx context.Context, workspace_id, user_id string)
+
+	testTable := []struct {
+		nameTest              string
+		ctx                   context.Context
+		workspace_id, user_id string
+		mockBehavior          mockBehavior
+		expectedErr           error
+	}{
+		{
+			nameTest:     "ok",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM workspace_member_ WHERE user_id_ = $1 AND workspace_id_ = $2", 5, 8).Return(pgxmock.NewResult("DELETE", 1), nil)
+			},
+			expectedErr: nil,
+		},
+		{
+			nameTest:     "invalid_inputs",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			user_id:      "5r4",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "not_member",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {
+				mockPool.EXPECT().Exec(ctx, "DELETE FROM workspace_member_ WHERE user_id_ = $1 AND workspace_id_ = $2", 5, 8).Return(pgxmock.NewResult("DELETE", 0), nil)
+			},
+			expectedErr: errs.ErrContentNotFound,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.workspace_id, testCase.user_id)
+
+			err := r.DeleteMember(testCase.ctx, testCase.workspace_id, testCase.user_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
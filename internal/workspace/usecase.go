@@ -0,0 +1,65 @@
+package workspace
+
+import (
+	"context"
+	"quizapp/models"
+	"quizapp/pkg/types"
+)
+
+type UseCase interface {
+	// Creates workspace with current user as its owner member in one transaction.
+	// Returns created model & nil, if created.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if permission denied.
+	// Returns nil & other err else.
+	Create(ctx context.Context, model *models.Workspace) (*models.Workspace, error)
+
+	// Returns found model & nil, if get.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is not member of workspace.
+	// Returns nil & other err else.
+	GetById(ctx context.Context, id string) (*models.Workspace, error)
+
+	// Returns workspaces user is member of & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & other err else.
+	GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.Workspace, error)
+
+	// Forms of deleted workspace stay with their creators.
+	// Returns nil, if deleted.
+	// Returns ErrContentNotFound, if nothing to delete.
+	// Returns ErrInvalidContent, if invalid inputs.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if user is not member of workspace with owner role or permission denied.
+	// Returns other errors else.
+	Delete(ctx context.Context, id string) error
+
+	// Adds user to workspace with role or changes role of member.
+	// Returns saved model & nil, if saved.
+	// Returns nil & ErrContentNotFound, if no such workspace.
+	// Returns nil & ErrInvalidContent, if invalid inputs, unknown role, no such user or user created workspace.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is not member of workspace with owner role or permission denied.
+	// Returns nil & other err else.
+	Invite(ctx context.Context, member *models.WorkspaceMember) (*models.WorkspaceMember, error)
+
+	// Returns slice of workspace members & nil, if get smth.
+	// Returns empty slice & nil, if get nothing.
+	// Returns nil & ErrInvalidContent, if invalid inputs.
+	// Returns nil & ErrUnauthorized, if user unauthorized.
+	// Returns nil & ErrForbidden, if user is not member of workspace.
+	// Returns nil & other err else.
+	GetMembers(ctx context.Context, workspace_id string, sets types.GetSets) ([]*models.WorkspaceMember, error)
+
+	// Removes member from workspace, any member but creator may leave workspace.
+	// Returns nil, if removed.
+	// Returns ErrContentNotFound, if no such workspace or user is not member of workspace.
+	// Returns ErrInvalidContent, if invalid inputs or user created workspace.
+	// Returns ErrUnauthorized, if user unauthorized.
+	// Returns ErrForbidden, if user is not member of workspace with owner role or permission denied.
+	// Returns other errors else.
+	RemoveMember(ctx context.Context, workspace_id, user_id string) error
+}
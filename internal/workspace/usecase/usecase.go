@@ -0,0 +1,146 @@
+package usecase
+
+import (
+	"context"
+	"quizapp/internal/authz"
+	"quizapp/internal/workspace"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	"quizapp/pkg/transactor"
+	"quizapp/pkg/types"
+)
+
+type workspaceUseCase struct {
+	workspaceRepo workspace.Repo
+	authorizer    authz.Authorizer
+	transactor    transactor.Transactor
+	ctxUserKey    string
+}
+
+func NewWorkspaceUseCase(workspaceRepo workspace.Repo, authorizer authz.Authorizer, transactor transactor.Transactor, ctxUserKey string) workspace.UseCase {
+	return &workspaceUseCase{
+		workspaceRepo: workspaceRepo,
+		authorizer:    authorizer,
+		transactor:    transactor,
+		ctxUserKey:    ctxUserKey,
+	}
+}
+
+func (w *workspaceUseCase) Create(ctx context.Context, model *models.Workspace) (*models.Workspace, error) {
+	currentuser, ok := ctx.Value(w.ctxUserKey).(*models.User)
+	if !ok {
+		return nil, errs.ErrUnauthorized
+	}
+
+	model.User_id = currentuser.Id
+
+	var createdworkspace *models.Workspace
+
+	err := w.transactor.WithinTx(ctx, func(ctx context.Context) error {
+		var err error
+
+		createdworkspace, err = w.workspaceRepo.Create(ctx, model)
+		if err != nil {
+			return err
+		}
+
+		_, err = w.workspaceRepo.UpsertMember(ctx, &models.WorkspaceMember{
+			Workspace_id: createdworkspace.Id,
+			User_id:      currentuser.Id,
+			Role:         models.MemberRoleOwner,
+		})
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return createdworkspace, nil
+}
+
+func (w *workspaceUseCase) GetById(ctx context.Context, id string) (*models.Workspace, error) {
+	err := w.authorizer.AuthorizeWorkspace(ctx, id, models.ActionFormView)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.workspaceRepo.GetById(ctx, id)
+}
+
+func (w *workspaceUseCase) GetByUserId(ctx context.Context, user_id string, sets types.GetSets) ([]*models.Workspace, error) {
+	return w.workspaceRepo.GetByUserId(ctx, user_id, sets)
+}
+
+func (w *workspaceUseCase) Delete(ctx context.Context, id string) error {
+	err := w.authorizer.AuthorizeWorkspace(ctx, id, models.ActionWorkspaceManage)
+	if err != nil {
+		return err
+	}
+
+	return w.workspaceRepo.Delete(ctx, id)
+}
+
+func (w *workspaceUseCase) Invite(ctx context.Context, model *models.WorkspaceMember) (*models.WorkspaceMember, error) {
+	if !models.ValidateMemberRole(model.Role) {
+		return nil, errs.ErrInvalidContent
+	}
+
+	err := w.authorizer.AuthorizeWorkspace(ctx, model.Workspace_id, models.ActionMemberManage)
+	if err != nil {
+		return nil, err
+	}
+
+	err = w.validateNotCreator(ctx, model.Workspace_id, model.User_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.workspaceRepo.UpsertMember(ctx, model)
+}
+
+func (w *workspaceUseCase) GetMembers(ctx context.Context, workspace_id string, sets types.GetSets) ([]*models.WorkspaceMember, error) {
+	err := w.authorizer.AuthorizeWorkspace(ctx, workspace_id, models.ActionFormView)
+	if err != nil {
+		return nil, err
+	}
+
+	return w.workspaceRepo.GetMembers(ctx, workspace_id, sets)
+}
+
+func (w *workspaceUseCase) RemoveMember(ctx context.Context, workspace_id, user_id string) error {
+	currentuser, ok := ctx.Value(w.ctxUserKey).(*models.User)
+	if !ok {
+		return errs.ErrUnauthorized
+	}
+
+	// member leaves workspace on own will
+	if currentuser.Id != user_id {
+		err := w.authorizer.AuthorizeWorkspace(ctx, workspace_id, models.ActionMemberManage)
+		if err != nil {
+			return err
+		}
+	}
+
+	err := w.validateNotCreator(ctx, workspace_id, user_id)
+	if err != nil {
+		return err
+	}
+
+	return w.workspaceRepo.DeleteMember(ctx, workspace_id, user_id)
+}
+
+// Creator of workspace stays its owner, so workspace always has one.
+// Returns ErrInvalidContent, if user created workspace.
+func (w *workspaceUseCase) validateNotCreator(ctx context.Context, workspace_id, user_id string) error {
+	foundworkspace, err := w.workspaceRepo.GetById(ctx, workspace_id)
+	if err != nil {
+		return err
+	}
+
+	if foundworkspace.User_id == user_id {
+		return errs.ErrInvalidContent
+	}
+
+	return nil
+}
@@ -0,0 +1,427 @@
+package usecase_test
+
+import (
+	"context"
+	"errors"
+	mockauthz "quizapp/internal/authz/mock"
+	"quizapp/internal/workspace/mock"
+	"quizapp/internal/workspace/usecase"
+	"quizapp/models"
+	"quizapp/pkg/errs"
+	mocktx "quizapp/pkg/transactor/mock"
+	"quizapp/pkg/types"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+)
+
+func expectTx(ctx context.Context, mockTx *mocktx.MockTransactor) {
+	mockTx.EXPECT().WithinTx(ctx, gomock.Any()).DoAndReturn(func(ctx context.Context, fn func(ctx context.Context) error) error {
+		return fn(ctx)
+	})
+}
+
+func TestWorkspaceUseCase_Create(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewWorkspaceUseCase(mockRepo, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.Workspace)
+
+	ctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "4"})
+
+	testTable := []struct {
+		nameTest          string
+		ctx               context.Context
+		model             models.Workspace
+		mockBehavior      mockBehavior
+		expectedWorkspace *models.Workspace
+		expectedErr       error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      ctx,
+			model:    models.Workspace{Title: "team"},
+			mockBehavior: func(ctx context.Context, model *models.Workspace) {
+				expectTx(ctx, mockTx)
+				mockRepo.EXPECT().Create(ctx, &models.Workspace{User_id: "4", Title: "team"}).Return(&models.Workspace{Id: "8", User_id: "4", Title: "team"}, nil)
+				mockRepo.EXPECT().UpsertMember(ctx, &models.WorkspaceMember{Workspace_id: "8", User_id: "4", Role: models.MemberRoleOwner}).Return(&models.WorkspaceMember{}, nil)
+			},
+			expectedWorkspace: &models.Workspace{Id: "8", User_id: "4", Title: "team"},
+		},
+		{
+			nameTest:     "unauthorized",
+			ctx:          context.Background(),
+			model:        models.Workspace{Title: "team"},
+			mockBehavior: func(ctx context.Context, model *models.Workspace) {},
+			expectedErr:  errs.ErrUnauthorized,
+		},
+		{
+			nameTest: "member_error",
+			ctx:      ctx,
+			model:    models.Workspace{Title: "team"},
+			mockBehavior: func(ctx context.Context, model *models.Workspace) {
+				expectTx(ctx, mockTx)
+				mockRepo.EXPECT().Create(ctx, gomock.Any()).Return(&models.Workspace{Id: "8", User_id: "4", Title: "team"}, nil)
+				mockRepo.EXPECT().UpsertMember(ctx, gomock.Any()).Return(nil, errors.New("query_error"))
+			},
+			expectedErr: errors.New("query_error"),
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.Create(testCase.ctx, &testCase.model)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedWorkspace, got)
+		})
+	}
+}
+
+func TestWorkspaceUseCase_GetById(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewWorkspaceUseCase(mockRepo, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	ctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "4"})
+
+	testTable := []struct {
+		nameTest          string
+		ctx               context.Context
+		id                string
+		mockBehavior      mockBehavior
+		expectedWorkspace *models.Workspace
+		expectedErr       error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      ctx,
+			id:       "8",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, id, models.ActionFormView).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, id).Return(&models.Workspace{Id: "8", User_id: "5", Title: "team"}, nil)
+			},
+			expectedWorkspace: &models.Workspace{Id: "8", User_id: "5", Title: "team"},
+		},
+		{
+			nameTest: "forbidden",
+			ctx:      ctx,
+			id:       "8",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, id, models.ActionFormView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			got, err := uc.GetById(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedWorkspace, got)
+		})
+	}
+}
+
+func TestWorkspaceUseCase_Delete(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewWorkspaceUseCase(mockRepo, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, id string)
+
+	ctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "4"})
+
+	testTable := []struct {
+		nameTest     string
+		ctx          context.Context
+		id           string
+		mockBehavior mockBehavior
+		expectedErr  error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      ctx,
+			id:       "8",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, id, models.ActionWorkspaceManage).Return(nil)
+				mockRepo.EXPECT().Delete(ctx, id).Return(nil)
+			},
+		},
+		{
+			nameTest: "editor_forbidden",
+			ctx:      ctx,
+			id:       "8",
+			mockBehavior: func(ctx context.Context, id string) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, id, models.ActionWorkspaceManage).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.id)
+
+			err := uc.Delete(testCase.ctx, testCase.id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
+
+func TestWorkspaceUseCase_Invite(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewWorkspaceUseCase(mockRepo, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, model *models.WorkspaceMember)
+
+	ctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "4"})
+
+	testTable := []struct {
+		nameTest       string
+		ctx            context.Context
+		model          models.WorkspaceMember
+		mockBehavior   mockBehavior
+		expectedMember *models.WorkspaceMember
+		expectedErr    error
+	}{
+		{
+			nameTest: "ok",
+			ctx:      ctx,
+			model:    models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleEditor},
+			mockBehavior: func(ctx context.Context, model *models.WorkspaceMember) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, model.Workspace_id, models.ActionMemberManage).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Workspace_id).Return(&models.Workspace{Id: "8", User_id: "4"}, nil)
+				mockRepo.EXPECT().UpsertMember(ctx, model).Return(&models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleEditor}, nil)
+			},
+			expectedMember: &models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleEditor},
+		},
+		{
+			nameTest:     "unknown_role",
+			ctx:          ctx,
+			model:        models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: "admin"},
+			mockBehavior: func(ctx context.Context, model *models.WorkspaceMember) {},
+			expectedErr:  errs.ErrInvalidContent,
+		},
+		{
+			nameTest: "forbidden",
+			ctx:      ctx,
+			model:    models.WorkspaceMember{Workspace_id: "8", User_id: "5", Role: models.MemberRoleViewer},
+			mockBehavior: func(ctx context.Context, model *models.WorkspaceMember) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, model.Workspace_id, models.ActionMemberManage).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest: "invite_creator",
+			ctx:      ctx,
+			model:    models.WorkspaceMember{Workspace_id: "8", User_id: "4", Role: models.MemberRoleViewer},
+			mockBehavior: func(ctx context.Context, model *models.WorkspaceMember) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, model.Workspace_id, models.ActionMemberManage).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, model.Workspace_id).Return(&models.Workspace{Id: "8", User_id: "4"}, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, &testCase.model)
+
+			got, err := uc.Invite(testCase.ctx, &testCase.model)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedMember, got)
+		})
+	}
+}
+
+func TestWorkspaceUseCase_GetMembers(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewWorkspaceUseCase(mockRepo, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, workspace_id string, sets types.GetSets)
+
+	ctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "4"})
+
+	testTable := []struct {
+		nameTest        string
+		ctx             context.Context
+		workspace_id    string
+		sets            types.GetSets
+		mockBehavior    mockBehavior
+		expectedMembers []*models.WorkspaceMember
+		expectedErr     error
+	}{
+		{
+			nameTest:     "ok",
+			ctx:          ctx,
+			workspace_id: "8",
+			sets:         types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, workspace_id string, sets types.GetSets) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, workspace_id, models.ActionFormView).Return(nil)
+				mockRepo.EXPECT().GetMembers(ctx, workspace_id, sets).Return([]*models.WorkspaceMember{{Workspace_id: "8", User_id: "4", Role: models.MemberRoleOwner}}, nil)
+			},
+			expectedMembers: []*models.WorkspaceMember{{Workspace_id: "8", User_id: "4", Role: models.MemberRoleOwner}},
+		},
+		{
+			nameTest:     "forbidden",
+			ctx:          ctx,
+			workspace_id: "8",
+			sets:         types.GetSets{Limit: 10},
+			mockBehavior: func(ctx context.Context, workspace_id string, sets types.GetSets) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, workspace_id, models.ActionFormView).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.workspace_id, testCase.sets)
+
+			got, err := uc.GetMembers(testCase.ctx, testCase.workspace_id, testCase.sets)
+
+			assert.Equal(t, testCase.expectedErr, err)
+			assert.Equal(t, testCase.expectedMembers, got)
+		})
+	}
+}
+
+func TestWorkspaceUseCase_RemoveMember(t *testing.T) {
+	t.Parallel()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	mockRepo := mock.NewMockRepo(ctrl)
+	mockAuthz := mockauthz.NewMockAuthorizer(ctrl)
+	mockTx := mocktx.NewMockTransactor(ctrl)
+
+	ctxUserKey := "ctxuserkey"
+
+	uc := usecase.NewWorkspaceUseCase(mockRepo, mockAuthz, mockTx, ctxUserKey)
+
+	type mockBehavior func(ctx context.Context, workspace_id, user_id string)
+
+	ctx := context.WithValue(context.Background(), ctxUserKey, &models.User{Id: "4"})
+
+	testTable := []struct {
+		nameTest              string
+		ctx                   context.Context
+		workspace_id, user_id string
+		mockBehavior          mockBehavior
+		expectedErr           error
+	}{
+		{
+			nameTest:     "ok",
+			ctx:          ctx,
+			workspace_id: "8",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, workspace_id, models.ActionMemberManage).Return(nil)
+				mockRepo.EXPECT().GetById(ctx, workspace_id).Return(&models.Workspace{Id: "8", User_id: "4"}, nil)
+				mockRepo.EXPECT().DeleteMember(ctx, workspace_id, user_id).Return(nil)
+			},
+		},
+		{
+			nameTest:     "leave",
+			ctx:          ctx,
+			workspace_id: "8",
+			user_id:      "4",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {
+				mockRepo.EXPECT().GetById(ctx, workspace_id).Return(&models.Workspace{Id: "8", User_id: "6"}, nil)
+				mockRepo.EXPECT().DeleteMember(ctx, workspace_id, user_id).Return(nil)
+			},
+		},
+		{
+			nameTest:     "creator_leave",
+			ctx:          ctx,
+			workspace_id: "8",
+			user_id:      "4",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {
+				mockRepo.EXPECT().GetById(ctx, workspace_id).Return(&models.Workspace{Id: "8", User_id: "4"}, nil)
+			},
+			expectedErr: errs.ErrInvalidContent,
+		},
+		{
+			nameTest:     "forbidden",
+			ctx:          ctx,
+			workspace_id: "8",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {
+				mockAuthz.EXPECT().AuthorizeWorkspace(ctx, workspace_id, models.ActionMemberManage).Return(errs.ErrForbidden)
+			},
+			expectedErr: errs.ErrForbidden,
+		},
+		{
+			nameTest:     "unauthorized",
+			ctx:          context.Background(),
+			workspace_id: "8",
+			user_id:      "5",
+			mockBehavior: func(ctx context.Context, workspace_id, user_id string) {},
+			expectedErr:  errs.ErrUnauthorized,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			testCase.mockBehavior(testCase.ctx, testCase.workspace_id, testCase.user_id)
+
+			err := uc.RemoveMember(testCase.ctx, testCase.workspace_id, testCase.user_id)
+
+			assert.Equal(t, testCase.expectedErr, err)
+		})
+	}
+}
@@ -2,31 +2,164 @@ CREATE TABLE user_ (
 	id_ SERIAL PRIMARY KEY,
 	login_ VARCHAR(64) NOT NULL ,
 	password_ VARCHAR(64) NOT NULL,
+    role_ VARCHAR(16) NOT NULL DEFAULT 'user' CHECK (role_ IN ('user', 'moderator', 'admin')),
     UNIQUE (login_)
 );
 
+-- only hash_ of refresh token is kept, tokens rotated from one sign in share family_id_,
+-- access_jti_ is id of access token issued along, it is rejected once token is revoked_
+CREATE TABLE refresh_token_ (
+    id_ SERIAL PRIMARY KEY,
+    user_id_ INT REFERENCES user_ ON DELETE CASCADE NOT NULL,
+    family_id_ VARCHAR(64) NOT NULL,
+    hash_ VARCHAR(64) NOT NULL,
+    access_jti_ VARCHAR(64) NOT NULL,
+    expires_at_ TIMESTAMPTZ NOT NULL,
+    used_ BOOLEAN NOT NULL DEFAULT FALSE,
+    revoked_ BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at_ TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (hash_)
+);
+
+CREATE INDEX refresh_token_family_ ON refresh_token_ (family_id_);
+
+CREATE UNIQUE INDEX refresh_token_access_jti_ ON refresh_token_ (access_jti_);
+
+-- user_id_ is creator of workspace, it is kept as owner member
+CREATE TABLE workspace_ (
+    id_ SERIAL PRIMARY KEY,
+    user_id_ INT REFERENCES user_ ON DELETE CASCADE NOT NULL,
+    title_ VARCHAR(64) NOT NULL,
+    created_at_ TIMESTAMPTZ NOT NULL DEFAULT now()
+);
+
+CREATE TABLE workspace_member_ (
+    workspace_id_ INT REFERENCES workspace_ ON DELETE CASCADE NOT NULL,
+    user_id_ INT REFERENCES user_ ON DELETE CASCADE NOT NULL,
+    role_ VARCHAR(16) NOT NULL CHECK (role_ IN ('viewer', 'editor', 'owner')),
+    created_at_ TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (workspace_id_, user_id_)
+);
+
+CREATE INDEX workspace_member_user_ ON workspace_member_ (user_id_);
+
+-- workspace_id_ is null for personal forms, forms of deleted workspace stay with their creators
 CREATE TABLE form_ (
     id_ SERIAL PRIMARY KEY,
     user_id_ INT REFERENCES user_ ON DELETE CASCADE NOT NULL,
+    workspace_id_ INT REFERENCES workspace_ ON DELETE SET NULL,
     title_ VARCHAR(64) NOT NULL,
-    description_ TEXT NOT NULL
+    description_ TEXT NOT NULL,
+    status_ VARCHAR(16) NOT NULL DEFAULT 'draft' CHECK (status_ IN ('draft', 'open', 'closed', 'archived')),
+    opens_at_ TIMESTAMPTZ,
+    closes_at_ TIMESTAMPTZ,
+    one_response_ BOOLEAN NOT NULL DEFAULT FALSE,
+    max_responses_ INT NOT NULL DEFAULT 0 CHECK (max_responses_ >= 0),
+    edit_window_ INT NOT NULL DEFAULT 0 CHECK (edit_window_ >= 0),
+    access_ VARCHAR(16) NOT NULL DEFAULT 'authenticated' CHECK (access_ IN ('authenticated', 'anonymous', 'link')),
+    access_token_ VARCHAR(64) NOT NULL DEFAULT '',
+    is_template_ BOOLEAN NOT NULL DEFAULT FALSE,
+    is_quiz_ BOOLEAN NOT NULL DEFAULT FALSE,
+    show_correct_ VARCHAR(16) NOT NULL DEFAULT 'never' CHECK (show_correct_ IN ('never', 'after_submission', 'after_close')),
+    time_limit_ INT NOT NULL DEFAULT 0 CHECK (time_limit_ >= 0),
+    draws_ JSONB NOT NULL DEFAULT '[]',
+    CHECK (opens_at_ < closes_at_)
+);
+
+CREATE INDEX form_template_ ON form_ (id_) WHERE is_template_;
+
+CREATE INDEX form_workspace_ ON form_ (workspace_id_);
+
+-- collaborators of form, user_id_ of form_ is its owner without member row
+CREATE TABLE form_member_ (
+    form_id_ INT REFERENCES form_ ON DELETE CASCADE NOT NULL,
+    user_id_ INT REFERENCES user_ ON DELETE CASCADE NOT NULL,
+    role_ VARCHAR(16) NOT NULL CHECK (role_ IN ('viewer', 'editor', 'owner')),
+    created_at_ TIMESTAMPTZ NOT NULL DEFAULT now(),
+    PRIMARY KEY (form_id_, user_id_)
+);
+
+CREATE TABLE section_ (
+    id_ SERIAL PRIMARY KEY,
+    form_id_ INT REFERENCES form_ ON DELETE CASCADE NOT NULL,
+    title_ VARCHAR(64) NOT NULL,
+    description_ TEXT NOT NULL DEFAULT '',
+    position_ INT NOT NULL,
+    condition_ JSONB,
+    UNIQUE (form_id_, position_) DEFERRABLE INITIALLY DEFERRED
 );
 
 CREATE TABLE question_ (
     id_ SERIAL PRIMARY KEY,
     form_id_ INT REFERENCES form_ ON DELETE CASCADE NOT NULL,
-    header_ TEXT NOT NULL
+    section_id_ INT REFERENCES section_ ON DELETE SET NULL,
+    header_ TEXT NOT NULL,
+    type_ VARCHAR(32) NOT NULL DEFAULT 'text',
+    options_ JSONB NOT NULL DEFAULT '{}',
+    position_ INT NOT NULL,
+    condition_ JSONB,
+    required_ BOOLEAN NOT NULL DEFAULT FALSE,
+    rules_ JSONB NOT NULL DEFAULT '{}',
+    correct_ TEXT NOT NULL DEFAULT '',
+    points_ INT NOT NULL DEFAULT 0 CHECK (points_ >= 0),
+    UNIQUE (form_id_, position_) DEFERRABLE INITIALLY DEFERRED
 );
 
+-- bank questions share id sequence with form questions, so drawn and version questions never collide in answers
+CREATE TABLE bank_question_ (
+    id_ INT PRIMARY KEY DEFAULT nextval('question__id__seq'),
+    user_id_ INT REFERENCES user_ ON DELETE CASCADE NOT NULL,
+    header_ TEXT NOT NULL,
+    type_ VARCHAR(32) NOT NULL DEFAULT 'text',
+    options_ JSONB NOT NULL DEFAULT '{}',
+    required_ BOOLEAN NOT NULL DEFAULT FALSE,
+    rules_ JSONB NOT NULL DEFAULT '{}',
+    correct_ TEXT NOT NULL DEFAULT '',
+    points_ INT NOT NULL DEFAULT 0 CHECK (points_ >= 0),
+    tags_ TEXT[] NOT NULL DEFAULT '{}'
+);
+
+CREATE INDEX bank_question_tags_ ON bank_question_ USING GIN (tags_);
+
+CREATE TABLE form_version_ (
+    id_ SERIAL PRIMARY KEY,
+    form_id_ INT REFERENCES form_ ON DELETE CASCADE NOT NULL,
+    number_ INT NOT NULL,
+    title_ VARCHAR(64) NOT NULL,
+    description_ TEXT NOT NULL,
+    questions_ JSONB NOT NULL,
+    sections_ JSONB NOT NULL DEFAULT '[]',
+    created_at_ TIMESTAMPTZ NOT NULL DEFAULT now(),
+    UNIQUE (form_id_, number_)
+);
+
+-- user_id_ is null for pool answers of guests, score_ is null for pool answers of not quiz forms,
+-- attempts are pending_ from start till answers are submitted, attempts of timed forms before deadline_,
+-- questions_ keeps bank questions drawn into attempt
 CREATE TABLE pool_answer_ (
     id_ SERIAL PRIMARY KEY,
-    user_id_ INT REFERENCES user_ ON DELETE CASCADE NOT NULL,
-    form_id_ INT REFERENCES form_ ON DELETE CASCADE NOT NULL
+    user_id_ INT REFERENCES user_ ON DELETE CASCADE,
+    form_id_ INT REFERENCES form_ ON DELETE CASCADE NOT NULL,
+    version_id_ INT REFERENCES form_version_ ON DELETE CASCADE NOT NULL,
+    single_ BOOLEAN NOT NULL DEFAULT FALSE,
+    score_ INT,
+    pending_ BOOLEAN NOT NULL DEFAULT FALSE,
+    deadline_ TIMESTAMPTZ,
+    questions_ JSONB NOT NULL DEFAULT '[]',
+    submitted_at_ TIMESTAMPTZ,
+    created_at_ TIMESTAMPTZ NOT NULL DEFAULT now()
 );
 
+-- one response per user is enforced for pool answers of forms with one_response_ set,
+-- expired pending attempt is deleted in the same transaction as restarted one is inserted
+CREATE UNIQUE INDEX pool_answer_single_ ON pool_answer_ (form_id_, user_id_) WHERE single_;
+
+CREATE INDEX pool_answer_pending_ ON pool_answer_ (form_id_, user_id_) WHERE pending_;
+
+-- question_id_ refers to question in version snapshot, draft question may be deleted since
 CREATE TABLE answer_ (
     id_ SERIAL PRIMARY KEY,
-    question_id_ INT REFERENCES question_ ON DELETE CASCADE NOT NULL,
+    question_id_ INT NOT NULL,
     pool_answer_id_ INT REFERENCES pool_answer_ ON DELETE CASCADE NOT NULL,
     value_ TEXT NOT NULL
 );
@@ -35,11 +168,18 @@ CREATE ROLE db_readonly;
 GRANT CONNECT ON DATABASE quizapp TO db_readonly;
 GRANT USAGE ON SCHEMA public TO db_readonly;
 
+GRANT SELECT ON TABLE quizapp.public.workspace_ TO db_readonly;
+GRANT SELECT ON TABLE quizapp.public.workspace_member_ TO db_readonly;
 GRANT SELECT ON TABLE quizapp.public.form_ TO db_readonly;
+GRANT SELECT ON TABLE quizapp.public.form_member_ TO db_readonly;
+GRANT SELECT ON TABLE quizapp.public.section_ TO db_readonly;
 GRANT SELECT ON TABLE quizapp.public.question_ TO db_readonly;
+GRANT SELECT ON TABLE quizapp.public.bank_question_ TO db_readonly;
+GRANT SELECT ON TABLE quizapp.public.form_version_ TO db_readonly;
 GRANT SELECT ON TABLE quizapp.public.pool_answer_ TO db_readonly;
 GRANT SELECT ON TABLE quizapp.public.answer_ TO db_readonly;
 GRANT SELECT ON TABLE quizapp.public.user_ TO db_readonly;
+GRANT SELECT ON TABLE quizapp.public.refresh_token_ TO db_readonly;
 
 CREATE USER minotauro_readonly WITH PASSWORD 'Controcarro3_readonly';
 GRANT db_readonly TO minotauro_readonly;
@@ -2,4 +2,7 @@ package models
 
 type Answer struct {
 	Id, Question_id, Pool_answer_id, Value string
+
+	// Question as shown to respondent, if loaded
+	Question *Question
 }
@@ -0,0 +1,94 @@
+package models
+
+import "math/rand"
+
+// Question of user bank, drawn into attempts of forms of the user by its tags.
+type BankQuestion struct {
+	Id, User_id, Header, Type string
+	Options                   QuestionOptions
+	Required                  bool
+	Rules                     QuestionRules
+	Correct                   string
+	Points                    int
+	Tags                      []string
+}
+
+// Rule of form to draw Count random questions of form owner bank into each attempt.
+// Questions are drawn by Tag, from whole bank, if Tag is empty.
+type Draw struct {
+	Tag   string
+	Count int
+}
+
+// Returns question as shown in attempt, it keeps id of bank question.
+func (b *BankQuestion) ToQuestion() *Question {
+	return &Question{
+		Id:       b.Id,
+		Header:   b.Header,
+		Type:     b.Type,
+		Options:  b.Options,
+		Required: b.Required,
+		Rules:    b.Rules,
+		Correct:  b.Correct,
+		Points:   b.Points,
+	}
+}
+
+// Returns true, if question is valid as form question and tags are not empty and unique.
+func (b *BankQuestion) Validate() bool {
+	q := b.ToQuestion()
+	if !q.ValidateOptions() || !q.ValidateRules() || !q.ValidateScoring() {
+		return false
+	}
+
+	seen := make(map[string]bool, len(b.Tags))
+	for _, t := range b.Tags {
+		if t == "" || seen[t] {
+			return false
+		}
+		seen[t] = true
+	}
+
+	return true
+}
+
+// Returns true, if every draw takes at least one question and tags of draws are unique.
+func ValidateDraws(draws []*Draw) bool {
+	seen := make(map[string]bool, len(draws))
+	for _, d := range draws {
+		if d.Count <= 0 || seen[d.Tag] {
+			return false
+		}
+		seen[d.Tag] = true
+	}
+
+	return true
+}
+
+// Shuffles order of questions and choices of each question in place.
+func ShuffleQuestions(questions []*Question) {
+	rand.Shuffle(len(questions), func(i, j int) {
+		questions[i], questions[j] = questions[j], questions[i]
+	})
+
+	for _, q := range questions {
+		choices := q.Options.Choices
+		rand.Shuffle(len(choices), func(i, j int) {
+			choices[i], choices[j] = choices[j], choices[i]
+		})
+	}
+}
+
+// Returns version shown to attempt: version questions followed by questions drawn into it.
+func (v *FormVersion) WithDrawn(drawn []*Question) *FormVersion {
+	if len(drawn) == 0 {
+		return v
+	}
+
+	shown := *v
+	shown.Questions = make([]*Question, 0, len(v.Questions)+len(drawn))
+	shown.Questions = append(shown.Questions, v.Questions...)
+	shown.Questions = append(shown.Questions, drawn...)
+
+	return &shown
+}
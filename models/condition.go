@@ -0,0 +1,67 @@
+package models
+
+import "encoding/json"
+
+const (
+	ConditionOpEquals      = "equals"
+	ConditionOpNotEquals   = "not_equals"
+	ConditionOpAnswered    = "answered"
+	ConditionOpNotAnswered = "not_answered"
+)
+
+// Display condition on answer to earlier question.
+// Equals holds for multiple choice answer having Value among chosen.
+type Condition struct {
+	Question_id, Op, Value string
+}
+
+// Returns true, if op is known and value is a possible answer to question for comparing ops.
+func (c *Condition) Validate(q *Question) bool {
+	switch c.Op {
+	case ConditionOpAnswered, ConditionOpNotAnswered:
+		return c.Value == ""
+	case ConditionOpEquals, ConditionOpNotEquals:
+		if q.Type == QuestionTypeMultipleChoice {
+			return q.hasChoice(c.Value)
+		}
+		return q.ValidateValue(c.Value)
+	}
+
+	return false
+}
+
+// Returns true, if condition holds for answer to referred question.
+// Answered is false for skipped and hidden questions.
+func (c *Condition) Holds(q *Question, value string, answered bool) bool {
+	switch c.Op {
+	case ConditionOpAnswered:
+		return answered
+	case ConditionOpNotAnswered:
+		return !answered
+	case ConditionOpEquals:
+		return answered && conditionEquals(q, value, c.Value)
+	case ConditionOpNotEquals:
+		return !answered || !conditionEquals(q, value, c.Value)
+	}
+
+	return false
+}
+
+func conditionEquals(q *Question, value, expected string) bool {
+	if q.Type != QuestionTypeMultipleChoice {
+		return value == expected
+	}
+
+	var values []string
+	if json.Unmarshal([]byte(value), &values) != nil {
+		return false
+	}
+
+	for _, v := range values {
+		if v == expected {
+			return true
+		}
+	}
+
+	return false
+}
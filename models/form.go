@@ -1,5 +1,165 @@
 package models
 
+import (
+	"crypto/subtle"
+	"time"
+)
+
+const (
+	FormStatusDraft    = "draft"
+	FormStatusOpen     = "open"
+	FormStatusClosed   = "closed"
+	FormStatusArchived = "archived"
+)
+
+// When respondents of quiz see correct answers
+const (
+	FormShowCorrectNever           = "never"
+	FormShowCorrectAfterSubmission = "after_submission"
+	FormShowCorrectAfterClose      = "after_close"
+)
+
+// Who may answer form
+const (
+	FormAccessAuthenticated = "authenticated"
+	FormAccessAnonymous     = "anonymous"
+	FormAccessLink          = "link"
+)
+
 type Form struct {
-	Id, User_id, Title, Description string
+	Id, User_id, Title, Description, Status string
+
+	// Workspace form is created in, empty for personal form
+	Workspace_id string
+
+	// Optional bounds of answering period of open form
+	Opens_at, Closes_at *time.Time
+
+	// Response limits: one pool answer per user, total pool answers
+	// (0 is unlimited) and period after submission while respondent
+	// may edit own pool answer (0 disables editing)
+	One_response  bool
+	Max_responses int
+	Edit_window   time.Duration
+
+	// Time to submit answers after attempt start, 0 for attempts not limited in time
+	Time_limit time.Duration
+
+	// Rules to draw questions of owner bank into each attempt
+	Draws []*Draw
+
+	// Access mode and secret of link to form, set in link mode only
+	Access, Access_token string
+
+	// Template forms are listed to all users and may be cloned by anyone
+	Is_template bool
+
+	// Pool answers of quiz are scored, correct answers are shown
+	// to respondents as set by Show_correct
+	Is_quiz      bool
+	Show_correct string
+}
+
+// Returns true, if status is known.
+func ValidateFormStatus(status string) bool {
+	switch status {
+	case FormStatusDraft, FormStatusOpen, FormStatusClosed, FormStatusArchived:
+		return true
+	}
+	return false
+}
+
+// Returns true, if access mode is known.
+func ValidateFormAccess(access string) bool {
+	switch access {
+	case FormAccessAuthenticated, FormAccessAnonymous, FormAccessLink:
+		return true
+	}
+	return false
+}
+
+// Returns true, if correct answers visibility is known.
+func ValidateFormShowCorrect(show_correct string) bool {
+	switch show_correct {
+	case FormShowCorrectNever, FormShowCorrectAfterSubmission, FormShowCorrectAfterClose:
+		return true
+	}
+	return false
+}
+
+// Returns true, if form opens before it closes or any bound is not set.
+func (f *Form) ValidateSchedule() bool {
+	return f.Opens_at == nil || f.Closes_at == nil || f.Opens_at.Before(*f.Closes_at)
+}
+
+// Returns true, if limits are not negative.
+func (f *Form) ValidateLimits() bool {
+	return f.Max_responses >= 0 && f.Edit_window >= 0 && f.Time_limit >= 0
+}
+
+// Returns true, if answers are submitted within attempts limited in time.
+func (f *Form) IsTimed() bool {
+	return f.Time_limit > 0
+}
+
+// Returns true, if answers are submitted within started attempts only,
+// as attempts are timed or get questions drawn from bank.
+func (f *Form) UsesAttempts() bool {
+	return f.IsTimed() || len(f.Draws) > 0
+}
+
+// Returns deadline of attempt started at start, it is never after form closes.
+func (f *Form) AttemptDeadline(start time.Time) time.Time {
+	deadline := start.Add(f.Time_limit)
+
+	if f.Closes_at != nil && f.Closes_at.Before(deadline) {
+		return *f.Closes_at
+	}
+
+	return deadline
+}
+
+// Returns true, if form is open and t is within its answering period.
+func (f *Form) IsAcceptingAnswers(t time.Time) bool {
+	if f.Status != FormStatusOpen {
+		return false
+	}
+
+	if f.Opens_at != nil && t.Before(*f.Opens_at) {
+		return false
+	}
+
+	if f.Closes_at != nil && !t.Before(*f.Closes_at) {
+		return false
+	}
+
+	return true
+}
+
+// Returns true, if pool answer submitted at submitted_at may be edited at t.
+func (f *Form) IsEditable(submitted_at, t time.Time) bool {
+	return f.IsAcceptingAnswers(t) && t.Before(submitted_at.Add(f.Edit_window))
+}
+
+// Returns true, if respondent may see correct answers of quiz at t.
+func (f *Form) ShowsCorrect(t time.Time) bool {
+	switch f.Show_correct {
+	case FormShowCorrectAfterSubmission:
+		return true
+	case FormShowCorrectAfterClose:
+		return f.Status == FormStatusClosed || f.Status == FormStatusArchived ||
+			f.Closes_at != nil && !t.Before(*f.Closes_at)
+	}
+	return false
+}
+
+// Returns true, if user (empty for guest) holding token may answer form.
+func (f *Form) IsAccessible(user_id, token string) bool {
+	switch f.Access {
+	case FormAccessAnonymous:
+		return true
+	case FormAccessLink:
+		return f.Access_token != "" && subtle.ConstantTimeCompare([]byte(f.Access_token), []byte(token)) == 1
+	}
+	return user_id != ""
 }
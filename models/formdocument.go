@@ -0,0 +1,73 @@
+package models
+
+// Schema version of form documents written by export.
+// Version 2 adds sections, conditions and draws.
+const FormDocumentSchemaVersion = 2
+
+// Portable definition of form with ordered sections and questions.
+// Owner, status, schedule and access token are not part of document.
+// Ids of sections and questions are local to document: questions refer
+// to their sections and conditions to questions by them, created ones get new ids.
+type FormDocument struct {
+	Schema_version int
+	Form           *Form
+	Sections       []*Section
+	Questions      []*Question
+}
+
+// Returns true, if document schema version can be imported.
+func (d *FormDocument) ValidateSchemaVersion() bool {
+	return d.Schema_version >= 1 && d.Schema_version <= FormDocumentSchemaVersion
+}
+
+// Returns true, if section ids are set and unique, question ids are unique,
+// every question refers to section of document and every condition to other
+// question of document with valid value. Order of conditions is checked on publish.
+func (d *FormDocument) ValidateReferences() bool {
+	sections := make(map[string]bool, len(d.Sections))
+	for _, s := range d.Sections {
+		if s.Id == "" || sections[s.Id] {
+			return false
+		}
+		sections[s.Id] = true
+	}
+
+	questions := make(map[string]*Question, len(d.Questions))
+	for _, q := range d.Questions {
+		if q.Id == "" {
+			continue
+		}
+
+		if _, ok := questions[q.Id]; ok {
+			return false
+		}
+		questions[q.Id] = q
+	}
+
+	valid := func(c *Condition, self string) bool {
+		if c == nil {
+			return true
+		}
+
+		referred, ok := questions[c.Question_id]
+		return ok && c.Question_id != self && c.Validate(referred)
+	}
+
+	for _, q := range d.Questions {
+		if q.Section_id != "" && !sections[q.Section_id] {
+			return false
+		}
+
+		if !valid(q.Condition, q.Id) {
+			return false
+		}
+	}
+
+	for _, s := range d.Sections {
+		if !valid(s.Condition, "") {
+			return false
+		}
+	}
+
+	return true
+}
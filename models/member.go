@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+const (
+	MemberRoleViewer = "viewer"
+	MemberRoleEditor = "editor"
+	MemberRoleOwner  = "owner"
+)
+
+// Collaborator of form. User who created form is its owner without being a member.
+type FormMember struct {
+	Form_id, User_id string
+
+	// One of MemberRoleViewer, MemberRoleEditor, MemberRoleOwner
+	Role string
+
+	Created_at time.Time
+}
+
+// Actions granted to member role on form of membership or on workspace and its forms.
+// Viewers read form and results, editors change form and questions too, owners share all rights of form creator.
+var memberPermissions = map[string]map[string]bool{
+	MemberRoleViewer: {
+		ActionFormView:     true,
+		ActionResponseView: true,
+	},
+	MemberRoleEditor: {
+		ActionFormView:     true,
+		ActionFormEdit:     true,
+		ActionResponseView: true,
+	},
+	MemberRoleOwner: {
+		ActionFormView:         true,
+		ActionFormEdit:         true,
+		ActionFormClose:        true,
+		ActionFormDelete:       true,
+		ActionResponseView:     true,
+		ActionResponseModerate: true,
+		ActionMemberManage:     true,
+		ActionWorkspaceManage:  true,
+	},
+}
+
+func ValidateMemberRole(role string) bool {
+	_, ok := memberPermissions[role]
+	return ok
+}
+
+// Returns member roles granting action, from least to most privileged.
+func MemberRolesCan(action string) []string {
+	roles := make([]string, 0, len(memberPermissions))
+	for _, role := range []string{MemberRoleViewer, MemberRoleEditor, MemberRoleOwner} {
+		if memberPermissions[role][action] {
+			roles = append(roles, role)
+		}
+	}
+
+	return roles
+}
+
+// Returns true, if member role grants action on form.
+func (m *FormMember) Can(action string) bool {
+	return memberPermissions[m.Role][action]
+}
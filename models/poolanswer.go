@@ -1,5 +1,43 @@
 package models
 
+import "time"
+
+// Allowance for network delay of answers submitted right before attempt deadline
+const SubmitGrace = 5 * time.Second
+
 type PoolAnswer struct {
-	Id, Form_id, User_id string
+	Id, Form_id, Version_id, User_id string
+
+	// Limited to one per user and form
+	Single bool
+
+	// Points scored, nil for pool answers of not quiz forms
+	Score *int
+
+	// Attempt is pending from its start, set by Created_at, till answers are submitted,
+	// attempt of timed form has to be submitted before deadline
+	Pending  bool
+	Deadline *time.Time
+
+	// Bank questions drawn into attempt as shown to respondent,
+	// they follow questions of version
+	Questions []*Question
+
+	// Edit window of submitted pool answer runs from Submitted_at, nil while attempt is pending
+	Submitted_at *time.Time
+	Created_at   time.Time
+}
+
+// Returns time left till deadline at t, 0 for pool answers without deadline.
+func (p *PoolAnswer) Remaining(t time.Time) time.Duration {
+	if p.Deadline == nil || !t.Before(*p.Deadline) {
+		return 0
+	}
+
+	return p.Deadline.Sub(t)
+}
+
+// Returns true, if answers may not be submitted at t any more.
+func (p *PoolAnswer) IsExpired(t time.Time) bool {
+	return p.Deadline != nil && t.After(p.Deadline.Add(SubmitGrace))
 }
@@ -1,5 +1,162 @@
 package models
 
+import (
+	"encoding/json"
+	"math"
+	"strconv"
+	"time"
+)
+
+const (
+	QuestionTypeText           = "text"
+	QuestionTypeSingleChoice   = "single_choice"
+	QuestionTypeMultipleChoice = "multiple_choice"
+	QuestionTypeScale          = "scale"
+	QuestionTypeDate           = "date"
+	QuestionTypeNumber         = "number"
+
+	// Layout of date question values
+	DateLayout = "2006-01-02"
+
+	defaultScaleStep = 1
+	stepEpsilon      = 1e-9
+)
+
 type Question struct {
-	Id, Form_id, Header string
+	Id, Form_id, Header, Type string
+	// Empty, if question is not in section.
+	Section_id string
+	Options    QuestionOptions
+	// One based place of question in form, 0 on create appends question.
+	Position int
+	// Question is always shown, if nil.
+	Condition *Condition
+	// Shown required question has to be answered.
+	Required bool
+	Rules    QuestionRules
+	// Correct answer value and points for it in quiz, question is not scored, if Correct is empty.
+	Correct string
+	Points  int
+}
+
+// Per-type question settings.
+// Choices are used by single_choice and multiple_choice,
+// Min, Max and Step by scale and number.
+type QuestionOptions struct {
+	Choices        []string
+	Min, Max, Step *float64
+}
+
+// Returns true, if type is known and options are consistent with it.
+func (q *Question) ValidateOptions() (res bool) {
+	o := &q.Options
+
+	switch q.Type {
+	case QuestionTypeText, QuestionTypeDate:
+		res = len(o.Choices) == 0 && o.Min == nil && o.Max == nil && o.Step == nil
+	case QuestionTypeSingleChoice, QuestionTypeMultipleChoice:
+		res = o.Min == nil && o.Max == nil && o.Step == nil && validateChoices(o.Choices)
+	case QuestionTypeScale:
+		res = len(o.Choices) == 0 && o.Min != nil && o.Max != nil && *o.Min < *o.Max &&
+			(o.Step == nil || *o.Step > 0)
+	case QuestionTypeNumber:
+		res = len(o.Choices) == 0 && (o.Min == nil || o.Max == nil || *o.Min <= *o.Max) &&
+			(o.Step == nil || *o.Step > 0)
+	}
+
+	return
+}
+
+// Returns true, if value is a valid answer to the question.
+// Multiple choice values are JSON arrays of choices,
+// dates are formatted with DateLayout.
+func (q *Question) ValidateValue(value string) (res bool) {
+	switch q.Type {
+	case QuestionTypeText:
+		res = true
+	case QuestionTypeSingleChoice:
+		res = q.hasChoice(value)
+	case QuestionTypeMultipleChoice:
+		var values []string
+		if json.Unmarshal([]byte(value), &values) != nil || len(values) == 0 {
+			return
+		}
+
+		seen := make(map[string]bool, len(values))
+		for _, v := range values {
+			if seen[v] || !q.hasChoice(v) {
+				return
+			}
+			seen[v] = true
+		}
+		res = true
+	case QuestionTypeScale, QuestionTypeNumber:
+		number, err := strconv.ParseFloat(value, 64)
+		if err != nil || math.IsNaN(number) || math.IsInf(number, 0) {
+			return
+		}
+		res = q.inRange(number)
+	case QuestionTypeDate:
+		_, err := time.Parse(DateLayout, value)
+		res = err == nil
+	}
+
+	return
+}
+
+func (q *Question) hasChoice(value string) bool {
+	for _, c := range q.Options.Choices {
+		if c == value {
+			return true
+		}
+	}
+	return false
+}
+
+func (q *Question) inRange(number float64) bool {
+	o := &q.Options
+
+	if o.Min != nil && number < *o.Min {
+		return false
+	}
+
+	if o.Max != nil && number > *o.Max {
+		return false
+	}
+
+	step := o.Step
+	if step == nil && q.Type == QuestionTypeScale {
+		defstep := float64(defaultScaleStep)
+		step = &defstep
+	}
+
+	if step != nil {
+		var base float64
+		if o.Min != nil {
+			base = *o.Min
+		}
+
+		n := (number - base) / *step
+		if math.Abs(n-math.Round(n)) > stepEpsilon {
+			return false
+		}
+	}
+
+	return true
+}
+
+func validateChoices(choices []string) bool {
+	if len(choices) == 0 {
+		return false
+	}
+
+	seen := make(map[string]bool, len(choices))
+	for _, c := range choices {
+		if c == "" || seen[c] {
+			return false
+		}
+		seen[c] = true
+	}
+
+	return true
 }
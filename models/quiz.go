@@ -0,0 +1,119 @@
+package models
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+)
+
+// Answer to question shown to respondent, graded by its correct answer.
+type GradedAnswer struct {
+	Question *Question
+	// Empty, if question is not answered
+	Value      string
+	Is_correct bool
+	// Points scored by answer
+	Points int
+}
+
+// Graded pool answer of quiz.
+type QuizResult struct {
+	Pool_answer      *PoolAnswer
+	Score, Max_score int
+	// Shown questions in form order
+	Answers []*GradedAnswer
+}
+
+// Returns true, if points are not negative and correct answer is a valid value,
+// not scored question has no points.
+func (q *Question) ValidateScoring() bool {
+	if q.Points < 0 {
+		return false
+	}
+
+	if q.Correct == "" {
+		return q.Points == 0
+	}
+
+	return q.ValidateValue(q.Correct)
+}
+
+// Returns true, if value matches correct answer.
+// Text is compared ignoring case and surrounding spaces,
+// multiple choice has to have exactly the correct choices.
+func (q *Question) IsCorrect(value string) bool {
+	switch q.Type {
+	case QuestionTypeText:
+		return strings.EqualFold(strings.TrimSpace(value), strings.TrimSpace(q.Correct))
+	case QuestionTypeMultipleChoice:
+		var values, correct []string
+		if json.Unmarshal([]byte(value), &values) != nil || json.Unmarshal([]byte(q.Correct), &correct) != nil ||
+			len(values) != len(correct) {
+			return false
+		}
+
+		expected := make(map[string]bool, len(correct))
+		for _, c := range correct {
+			expected[c] = true
+		}
+
+		for _, v := range values {
+			if !expected[v] {
+				return false
+			}
+		}
+
+		return true
+	case QuestionTypeScale, QuestionTypeNumber:
+		number, errv := strconv.ParseFloat(value, 64)
+		correct, errc := strconv.ParseFloat(q.Correct, 64)
+		return errv == nil && errc == nil && number == correct
+	}
+
+	return value == q.Correct
+}
+
+// Returns result of answers by question id graded over questions shown for them.
+// Max score sums points of shown scored questions only.
+func (v *FormVersion) Grade(values map[string]string) *QuizResult {
+	visible := v.VisibleQuestions(values)
+
+	res := &QuizResult{
+		Answers: make([]*GradedAnswer, 0, len(v.Questions)),
+	}
+
+	for _, q := range v.Questions {
+		if !visible[q.Id] {
+			continue
+		}
+
+		value, answered := values[q.Id]
+		graded := &GradedAnswer{
+			Question: q,
+			Value:    value,
+		}
+
+		if q.Correct != "" {
+			res.Max_score += q.Points
+
+			if answered && q.IsCorrect(value) {
+				graded.Is_correct = true
+				graded.Points = q.Points
+				res.Score += q.Points
+			}
+		}
+
+		res.Answers = append(res.Answers, graded)
+	}
+
+	return res
+}
+
+// Clears correct answers of graded questions, correctness is kept.
+func (r *QuizResult) HideCorrect() {
+	for _, a := range r.Answers {
+		hidden := *a.Question
+		hidden.Correct = ""
+		a.Question = &hidden
+	}
+}
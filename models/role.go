@@ -0,0 +1,44 @@
+package models
+
+// Actions checked by authorizer
+const (
+	ActionFormView         = "form:view"
+	ActionFormEdit         = "form:edit"
+	ActionFormClose        = "form:close"
+	ActionFormDelete       = "form:delete"
+	ActionResponseView     = "response:view"
+	ActionResponseModerate = "response:moderate"
+	ActionMemberManage     = "member:manage"
+	ActionWorkspaceManage  = "workspace:manage"
+	ActionUserManage       = "user:manage"
+)
+
+// Actions granted to role on any form. Form owner may take every action on own form.
+// Admin is not granted form edit, so forms are not changed behind owner back.
+var rolePermissions = map[string]map[string]bool{
+	RoleUser: {},
+	RoleModerator: {
+		ActionFormView:         true,
+		ActionResponseView:     true,
+		ActionResponseModerate: true,
+	},
+	RoleAdmin: {
+		ActionFormView:         true,
+		ActionFormClose:        true,
+		ActionFormDelete:       true,
+		ActionResponseView:     true,
+		ActionResponseModerate: true,
+		ActionUserManage:       true,
+	},
+}
+
+// Returns true, if role is granted action regardless of ownership.
+func (u *User) Can(action string) bool {
+	return rolePermissions[u.Role][action]
+}
+
+// Returns true, if role is known.
+func IsValidRole(role string) bool {
+	_, ok := rolePermissions[role]
+	return ok
+}
@@ -0,0 +1,112 @@
+package models
+
+import (
+	"net/mail"
+	"net/url"
+	"regexp"
+	"unicode/utf8"
+)
+
+const (
+	RuleFormatEmail = "email"
+	RuleFormatURL   = "url"
+
+	// Names of rules broken by answer value
+	RulePattern   = "pattern"
+	RuleMinLength = "min_length"
+	RuleMaxLength = "max_length"
+	RuleFormat    = "format"
+
+	maxPatternLength = 256
+)
+
+// Validation rules of text question answers, zero value sets no rules.
+// Pattern has to match the whole value, lengths are counted in characters.
+// Numeric range is set by Min and Max options of number and scale questions.
+type QuestionRules struct {
+	Pattern                string
+	Min_length, Max_length *int
+	Format                 string
+}
+
+// Returns true, if rules are consistent with each other and question type.
+func (q *Question) ValidateRules() bool {
+	r := &q.Rules
+
+	if *r == (QuestionRules{}) {
+		return true
+	}
+
+	if q.Type != QuestionTypeText {
+		return false
+	}
+
+	if r.Min_length != nil && *r.Min_length < 0 ||
+		r.Max_length != nil && *r.Max_length < 1 ||
+		r.Min_length != nil && r.Max_length != nil && *r.Min_length > *r.Max_length {
+		return false
+	}
+
+	if r.Format != "" && r.Format != RuleFormatEmail && r.Format != RuleFormatURL {
+		return false
+	}
+
+	if r.Pattern != "" {
+		if len(r.Pattern) > maxPatternLength {
+			return false
+		}
+
+		_, err := compilePattern(r.Pattern)
+		if err != nil {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Returns name of the first rule broken by value.
+// Returns empty string, if value follows all rules.
+func (q *Question) BrokenRule(value string) string {
+	r := &q.Rules
+
+	length := utf8.RuneCountInString(value)
+
+	if r.Min_length != nil && length < *r.Min_length {
+		return RuleMinLength
+	}
+
+	if r.Max_length != nil && length > *r.Max_length {
+		return RuleMaxLength
+	}
+
+	if r.Format != "" && !matchesFormat(r.Format, value) {
+		return RuleFormat
+	}
+
+	if r.Pattern != "" {
+		re, err := compilePattern(r.Pattern)
+		if err != nil || !re.MatchString(value) {
+			return RulePattern
+		}
+	}
+
+	return ""
+}
+
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	return regexp.Compile("^(?:" + pattern + ")$")
+}
+
+func matchesFormat(format, value string) bool {
+	switch format {
+	case RuleFormatEmail:
+		addr, err := mail.ParseAddress(value)
+		return err == nil && addr.Address == value
+	case RuleFormatURL:
+		u, err := url.ParseRequestURI(value)
+		return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+	}
+
+	return false
+}
@@ -0,0 +1,11 @@
+package models
+
+// Page of form grouping its questions
+type Section struct {
+	Id, Form_id, Title, Description string
+	// One based place of section in form, 0 on create appends section.
+	Position int
+	// Section is always shown, if nil.
+	Condition *Condition
+	Questions []*Question
+}
@@ -0,0 +1,56 @@
+package models
+
+import "time"
+
+// Statistics of form answers
+type FormStats struct {
+	// Version_id is empty, if answers of all versions are aggregated
+	Form_id, Version_id string
+
+	// Number of pool answers
+	Total int
+
+	// Number of pool answers per day, ordered by day
+	Daily []*DailyCount
+
+	// Ordered as questions of version
+	Questions []*QuestionStats
+}
+
+type DailyCount struct {
+	Day   time.Time
+	Count int
+}
+
+type QuestionStats struct {
+	Question *Question
+
+	// Number of pool answers with answer to question
+	Answered int
+
+	// Set for single_choice and multiple_choice questions, ordered as question choices
+	Choices []*ChoiceCount
+
+	// Set for scale and number questions, if answered
+	Numeric *NumericStats
+}
+
+type ChoiceCount struct {
+	Value string
+	Count int
+}
+
+type NumericStats struct {
+	Min, Max, Mean, Median float64
+	P25, P75, P90          float64
+}
+
+// Returns true, if question answers are counted by choices.
+func (q *Question) IsChoice() bool {
+	return q.Type == QuestionTypeSingleChoice || q.Type == QuestionTypeMultipleChoice
+}
+
+// Returns true, if question answers are numbers.
+func (q *Question) IsNumeric() bool {
+	return q.Type == QuestionTypeScale || q.Type == QuestionTypeNumber
+}
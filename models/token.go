@@ -0,0 +1,30 @@
+package models
+
+import "time"
+
+// Refresh token of user session, only hash of token is kept.
+// Tokens rotated from one sign in make a family, which is revoked at once on logout or reuse.
+type RefreshToken struct {
+	Id, User_id, Family_id, Hash string
+
+	// Id of access token issued along, it is rejected once refresh token is revoked
+	Access_jti string
+
+	Expires_at time.Time
+
+	// Used is set on rotation, Revoked on logout or reuse of used token
+	Used, Revoked bool
+}
+
+// Tokens issued on sign in and refresh
+type TokenPair struct {
+	Access_token, Refresh_token string
+
+	// Lifetime of access token
+	Expires_in time.Duration
+}
+
+// Returns true, if token may not be refreshed at t any more.
+func (r *RefreshToken) IsExpired(t time.Time) bool {
+	return !t.Before(r.Expires_at)
+}
@@ -1,7 +1,16 @@
 package models
 
+const (
+	RoleUser      = "user"
+	RoleModerator = "moderator"
+	RoleAdmin     = "admin"
+)
+
 type User struct {
 	Id, Login, Password string
+
+	// One of RoleUser, RoleModerator, RoleAdmin
+	Role string
 }
 
 func (u *User) EqPasswords(password string) (res bool) {
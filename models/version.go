@@ -0,0 +1,104 @@
+package models
+
+import "time"
+
+// Immutable snapshot of form published for answering
+type FormVersion struct {
+	Id, Form_id, Title, Description string
+	Number                          int
+	Questions                       []*Question
+	// Sections with their conditions, questions are not nested
+	Sections   []*Section
+	Created_at time.Time
+}
+
+// Returns question shown in version & true, if found.
+// Returns nil & false else.
+func (v *FormVersion) GetQuestion(id string) (*Question, bool) {
+	for _, q := range v.Questions {
+		if q.Id == id {
+			return q, true
+		}
+	}
+	return nil, false
+}
+
+// Returns ids of questions shown to respondent with given answers by question id.
+// Conditions refer to earlier questions, so questions are evaluated in order
+// and answer to hidden question does not count.
+func (v *FormVersion) VisibleQuestions(answers map[string]string) map[string]bool {
+	sections := make(map[string]*Section, len(v.Sections))
+	for _, s := range v.Sections {
+		sections[s.Id] = s
+	}
+
+	questions := make(map[string]*Question, len(v.Questions))
+	for _, q := range v.Questions {
+		questions[q.Id] = q
+	}
+
+	holds := func(c *Condition, visible map[string]bool) bool {
+		if c == nil {
+			return true
+		}
+
+		q, ok := questions[c.Question_id]
+		if !ok {
+			return false
+		}
+
+		value, answered := answers[c.Question_id]
+		return c.Holds(q, value, answered && visible[c.Question_id])
+	}
+
+	visible := make(map[string]bool, len(v.Questions))
+	for _, q := range v.Questions {
+		var sectioncond *Condition
+		if s, ok := sections[q.Section_id]; ok {
+			sectioncond = s.Condition
+		}
+
+		visible[q.Id] = holds(sectioncond, visible) && holds(q.Condition, visible)
+	}
+
+	return visible
+}
+
+// Returns true, if every condition refers to question shown earlier,
+// section condition to question before the first one of section.
+func (v *FormVersion) ValidateConditions() bool {
+	sections := make(map[string]*Section, len(v.Sections))
+	for _, s := range v.Sections {
+		sections[s.Id] = s
+	}
+
+	index := make(map[string]int, len(v.Questions))
+	first := make(map[string]int, len(v.Sections))
+	for i, q := range v.Questions {
+		index[q.Id] = i
+		if _, ok := first[q.Section_id]; !ok {
+			first[q.Section_id] = i
+		}
+	}
+
+	valid := func(c *Condition, before int) bool {
+		if c == nil {
+			return true
+		}
+
+		i, ok := index[c.Question_id]
+		return ok && i < before && c.Validate(v.Questions[i])
+	}
+
+	for i, q := range v.Questions {
+		if s, ok := sections[q.Section_id]; ok && !valid(s.Condition, first[s.Id]) {
+			return false
+		}
+
+		if !valid(q.Condition, i) {
+			return false
+		}
+	}
+
+	return true
+}
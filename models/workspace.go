@@ -0,0 +1,24 @@
+package models
+
+import "time"
+
+// Group of users sharing forms created in it. User who created workspace is kept as its owner member.
+type Workspace struct {
+	Id, User_id, Title string
+
+	Created_at time.Time
+}
+
+type WorkspaceMember struct {
+	Workspace_id, User_id string
+
+	// One of MemberRoleViewer, MemberRoleEditor, MemberRoleOwner
+	Role string
+
+	Created_at time.Time
+}
+
+// Returns true, if member role grants action on workspace and its forms.
+func (m *WorkspaceMember) Can(action string) bool {
+	return memberPermissions[m.Role][action]
+}
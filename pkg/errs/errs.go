@@ -12,15 +12,73 @@ var (
 	ErrInvalidContent     = errors.New("invalid content")
 	ErrLoginExists        = errors.New("login already exists")
 	ErrInvalidAccessToken = errors.New("invalid access token")
+	ErrInvalidRefresh     = errors.New("invalid refresh token")
 	ErrInvalidPassword    = errors.New("invalid password")
+	ErrInvalidTransition  = errors.New("invalid form status transition")
+	ErrFormNotOpen        = errors.New("form is not accepting answers")
+	ErrAlreadyAnswered    = errors.New("user already answered form")
+	ErrNotEditable        = errors.New("pool answer is not editable")
+	ErrAttemptRequired    = errors.New("timed form is answered within started attempt")
+	ErrDeadlineExceeded   = errors.New("attempt deadline exceeded")
 )
 
+// Reasons of answer rejection
+const (
+	ReasonNoAnswers       = "no_answers"
+	ReasonUnknownQuestion = "unknown_question"
+	ReasonDuplicateAnswer = "duplicate_answer"
+	ReasonInvalidValue    = "invalid_value"
+	ReasonHiddenQuestion  = "hidden_question"
+	ReasonRequired        = "required"
+	ReasonRuleViolation   = "rule_violation"
+)
+
+// Rejected answer. Question_id is empty for errors of the whole pool answer.
+// Rule names broken question rule for rule violations.
+type AnswerErr struct {
+	Question_id, Reason, Rule string
+}
+
+// Lists every rejected answer of pool answer
+type AnswersErr struct {
+	Errs []*AnswerErr
+}
+
+func (e *AnswersErr) Error() string {
+	return "invalid answers"
+}
+
+func (e *AnswersErr) Add(question_id, reason string) {
+	e.Errs = append(e.Errs, &AnswerErr{
+		Question_id: question_id,
+		Reason:      reason,
+	})
+}
+
+func (e *AnswersErr) AddRule(question_id, rule string) {
+	e.Errs = append(e.Errs, &AnswerErr{
+		Question_id: question_id,
+		Reason:      ReasonRuleViolation,
+		Rule:        rule,
+	})
+}
+
+// Returns e, if smth was added.
+// Returns nil else.
+func (e *AnswersErr) OrNil() error {
+	if len(e.Errs) == 0 {
+		return nil
+	}
+	return e
+}
+
 func MatchHttpErr(err error) int {
 	if err == ErrContentNotFound {
 		return http.StatusNoContent
 	}
 
-	if err == ErrInvalidContent {
+	var answersErr *AnswersErr
+	if err == ErrInvalidContent || errors.As(err, &answersErr) {
 		return http.StatusBadRequest
 	}
 
@@ -30,11 +88,18 @@ func MatchHttpErr(err error) int {
 
 	if err == ErrUnauthorized ||
 		err == ErrInvalidAccessToken ||
+		err == ErrInvalidRefresh ||
 		err == ErrInvalidPassword {
 		return http.StatusUnauthorized
 	}
 
-	if err == ErrLoginExists {
+	if err == ErrLoginExists ||
+		err == ErrInvalidTransition ||
+		err == ErrFormNotOpen ||
+		err == ErrAlreadyAnswered ||
+		err == ErrNotEditable ||
+		err == ErrAttemptRequired ||
+		err == ErrDeadlineExceeded {
 		return http.StatusConflict
 	}
 
@@ -1,34 +1,80 @@
 package jwtgo
 
 import (
+	"errors"
+	"fmt"
+	"quizapp/config"
 	"quizapp/pkg/jwter"
+	"sort"
+	"time"
 
 	"github.com/dgrijalva/jwt-go"
 )
 
 type claims struct {
-	Id, Login string
+	Id, Login, Role string
 	jwt.StandardClaims
 }
 
 type jwtgo struct {
-	key string
+	signing *key
+	keys    map[string]*key
 }
 
-func NewJWTGO(secret_key string) jwter.JWTer {
-	return &jwtgo{secret_key}
+// Returns JWTer & nil, if keys of config are loaded.
+// Returns nil & some err else.
+func NewJWTGO(c *config.Config) (jwter.JWTer, error) {
+	keyconfigs := c.Jwt.Keys
+	if len(keyconfigs) == 0 {
+		keyconfigs = []config.JwtKeyConfig{{
+			Alg:    jwt.SigningMethodHS256.Alg(),
+			Secret: c.Server.JwtSecretKey,
+		}}
+	}
+
+	res := &jwtgo{
+		keys: make(map[string]*key, len(keyconfigs)),
+	}
+
+	for i := range keyconfigs {
+		k, err := loadKey(&keyconfigs[i])
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := res.keys[k.kid]; ok {
+			return nil, fmt.Errorf("key %q: duplicate kid", k.kid)
+		}
+
+		res.keys[k.kid] = k
+	}
+
+	res.signing = res.keys[c.Jwt.SigningKid]
+	if res.signing == nil || res.signing.sign == nil {
+		return nil, fmt.Errorf("key %q: no private key to sign", c.Jwt.SigningKid)
+	}
+
+	return res, nil
 }
 
-func (j *jwtgo) GenerateJWTToken(id, login string) (*string, error) {
+func (j *jwtgo) GenerateJWTToken(c *jwter.Claims) (*string, error) {
 	claims := &claims{
-		Id:             id,
-		Login:          login,
-		StandardClaims: jwt.StandardClaims{},
+		Id:    c.Id,
+		Login: c.Login,
+		Role:  c.Role,
+		StandardClaims: jwt.StandardClaims{
+			Id:        c.Jti,
+			IssuedAt:  time.Now().Unix(),
+			ExpiresAt: c.Expires_at.Unix(),
+		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	token := jwt.NewWithClaims(j.signing.method, claims)
+	if j.signing.kid != "" {
+		token.Header["kid"] = j.signing.kid
+	}
 
-	token_string, err := token.SignedString([]byte(j.key))
+	token_string, err := token.SignedString(j.signing.sign)
 	if err != nil {
 		return nil, err
 	}
@@ -36,14 +82,58 @@ func (j *jwtgo) GenerateJWTToken(id, login string) (*string, error) {
 	return &token_string, nil
 }
 
-func (j *jwtgo) ParseToken(access_token string) (*string, *string, error) {
+func (j *jwtgo) ParseToken(access_token string) (*jwter.Claims, error) {
 	token, err := jwt.ParseWithClaims(access_token, &claims{}, func(token *jwt.Token) (interface{}, error) {
-		return []byte(j.key), nil
+		// tokens without kid are verified by key without kid
+		kid, _ := token.Header["kid"].(string)
+
+		k, ok := j.keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key %q", kid)
+		}
+
+		// alg of token header is never trusted over alg of key
+		if token.Method.Alg() != k.method.Alg() {
+			return nil, errors.New("unexpected signing method")
+		}
+
+		return k.verify, nil
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	if claims, ok := token.Claims.(*claims); ok && token.Valid {
-		return &claims.Id, &claims.Login, nil
+	claims, ok := token.Claims.(*claims)
+	if !ok || !token.Valid {
+		return nil, errors.New("invalid token")
 	}
 
-	return nil, nil, err
+	// tokens issued without expiration or id can not be revoked
+	if claims.ExpiresAt == 0 || claims.StandardClaims.Id == "" {
+		return nil, errors.New("token without expiration or id")
+	}
+
+	return &jwter.Claims{
+		Id:         claims.Id,
+		Login:      claims.Login,
+		Role:       claims.Role,
+		Jti:        claims.StandardClaims.Id,
+		Expires_at: time.Unix(claims.ExpiresAt, 0),
+	}, nil
+}
+
+func (j *jwtgo) Keys() []*jwter.JWK {
+	res := make([]*jwter.JWK, 0, len(j.keys))
+
+	for _, k := range j.keys {
+		if jwk := k.jwk(); jwk != nil {
+			res = append(res, jwk)
+		}
+	}
+
+	sort.Slice(res, func(i, k int) bool {
+		return res[i].Kid < res[k].Kid
+	})
+
+	return res
 }
@@ -0,0 +1,369 @@
+package jwtgo_test
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"os"
+	"path/filepath"
+	"quizapp/config"
+	"quizapp/pkg/jwter"
+	jwtgo "quizapp/pkg/jwter/impl"
+	"testing"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+	"github.com/stretchr/testify/assert"
+)
+
+// Keys of tests written as PEM files
+type testKeys struct {
+	rsa                                 *rsa.PrivateKey
+	ed25519                             ed25519.PrivateKey
+	rsaFile, rsaPublicFile, ed25519File string
+	rsaPublicPEM                        []byte
+}
+
+func writePEM(t *testing.T, dir, name, blocktype string, der []byte) (string, []byte) {
+	data := pem.EncodeToMemory(&pem.Block{Type: blocktype, Bytes: der})
+
+	path := filepath.Join(dir, name)
+	if err := os.WriteFile(path, data, 0600); err != nil {
+		t.Fatal(err)
+	}
+
+	return path, data
+}
+
+func newTestKeys(t *testing.T) *testKeys {
+	dir := t.TempDir()
+
+	rsakey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, edkey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	edder, err := x509.MarshalPKCS8PrivateKey(edkey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	publicder, err := x509.MarshalPKIXPublicKey(&rsakey.PublicKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	res := &testKeys{rsa: rsakey, ed25519: edkey}
+	res.rsaFile, _ = writePEM(t, dir, "rsa.pem", "RSA PRIVATE KEY", x509.MarshalPKCS1PrivateKey(rsakey))
+	res.rsaPublicFile, res.rsaPublicPEM = writePEM(t, dir, "rsa.pub.pem", "PUBLIC KEY", publicder)
+	res.ed25519File, _ = writePEM(t, dir, "ed25519.pem", "PRIVATE KEY", edder)
+
+	return res
+}
+
+func newJWTer(t *testing.T, signingKid string, keys ...config.JwtKeyConfig) jwter.JWTer {
+	j, err := jwtgo.NewJWTGO(&config.Config{
+		Jwt: config.JwtConfig{SigningKid: signingKid, Keys: keys},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return j
+}
+
+// Signs claims as is, bypassing checks of generator
+func signRaw(t *testing.T, method jwt.SigningMethod, kid string, claims jwt.Claims, key interface{}) string {
+	token := jwt.NewWithClaims(method, claims)
+	token.Header["kid"] = kid
+
+	res, err := token.SignedString(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return res
+}
+
+func TestJWTGO_GenerateAndParse(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+
+	claims := &jwter.Claims{
+		Id:         "5",
+		Login:      "login",
+		Role:       "moderator",
+		Jti:        "jti",
+		Expires_at: time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+
+	testTable := []struct {
+		nameTest string
+		key      config.JwtKeyConfig
+	}{
+		{
+			nameTest: "rs256",
+			key:      config.JwtKeyConfig{Kid: "rsa", Alg: "RS256", KeyFile: keys.rsaFile},
+		},
+		{
+			nameTest: "eddsa",
+			key:      config.JwtKeyConfig{Kid: "ed", Alg: "EdDSA", KeyFile: keys.ed25519File},
+		},
+		{
+			nameTest: "hs256",
+			key:      config.JwtKeyConfig{Kid: "hs", Alg: "HS256", Secret: "secret"},
+		},
+		{
+			nameTest: "hs256_of_server_secret",
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			var j jwter.JWTer
+
+			switch testCase.nameTest {
+			case "hs256_of_server_secret":
+				var err error
+				j, err = jwtgo.NewJWTGO(&config.Config{Server: config.ServerConfig{JwtSecretKey: "secret"}})
+				assert.Equal(t, nil, err)
+			default:
+				j = newJWTer(t, testCase.key.Kid, testCase.key)
+			}
+
+			token, err := j.GenerateJWTToken(claims)
+			assert.Equal(t, nil, err)
+
+			parsed, _, err := new(jwt.Parser).ParseUnverified(*token, &jwt.StandardClaims{})
+			assert.Equal(t, nil, err)
+			alg := testCase.key.Alg
+			if alg == "" {
+				alg = "HS256"
+			}
+			assert.Equal(t, alg, parsed.Header["alg"])
+
+			got, err := j.ParseToken(*token)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, claims, got)
+		})
+	}
+}
+
+func TestJWTGO_ParseToken(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+
+	rsakey := config.JwtKeyConfig{Kid: "rsa", Alg: "RS256", KeyFile: keys.rsaFile}
+	hskey := config.JwtKeyConfig{Kid: "hs", Alg: "HS256", Secret: "secret"}
+
+	j := newJWTer(t, "hs", rsakey, hskey)
+
+	expires := time.Now().Add(time.Hour).Unix()
+
+	testTable := []struct {
+		nameTest string
+		token    func() string
+	}{
+		{
+			nameTest: "unknown_kid",
+			token: func() string {
+				other := newJWTer(t, "other", config.JwtKeyConfig{Kid: "other", Alg: "HS256", Secret: "secret"})
+				token, _ := other.GenerateJWTToken(&jwter.Claims{Id: "5", Jti: "jti", Expires_at: time.Now().Add(time.Hour)})
+				return *token
+			},
+		},
+		{
+			// public key is known to anyone, it must not be taken as HMAC secret
+			nameTest: "hs256_signed_with_rsa_public_key",
+			token: func() string {
+				return signRaw(t, jwt.SigningMethodHS256, "rsa", jwt.StandardClaims{Id: "jti", ExpiresAt: expires}, keys.rsaPublicPEM)
+			},
+		},
+		{
+			nameTest: "alg_none",
+			token: func() string {
+				return signRaw(t, jwt.SigningMethodNone, "hs", jwt.StandardClaims{Id: "jti", ExpiresAt: expires}, jwt.UnsafeAllowNoneSignatureType)
+			},
+		},
+		{
+			nameTest: "no_exp",
+			token: func() string {
+				return signRaw(t, jwt.SigningMethodHS256, "hs", jwt.StandardClaims{Id: "jti"}, []byte("secret"))
+			},
+		},
+		{
+			nameTest: "no_jti",
+			token: func() string {
+				return signRaw(t, jwt.SigningMethodHS256, "hs", jwt.StandardClaims{ExpiresAt: expires}, []byte("secret"))
+			},
+		},
+		{
+			nameTest: "expired",
+			token: func() string {
+				return signRaw(t, jwt.SigningMethodHS256, "hs", jwt.StandardClaims{Id: "jti", ExpiresAt: time.Now().Add(-time.Minute).Unix()}, []byte("secret"))
+			},
+		},
+		{
+			nameTest: "wrong_signature",
+			token: func() string {
+				return signRaw(t, jwt.SigningMethodHS256, "hs", jwt.StandardClaims{Id: "jti", ExpiresAt: expires}, []byte("other"))
+			},
+		},
+		{
+			nameTest: "malformed",
+			token: func() string {
+				return "not.a.token"
+			},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			got, err := j.ParseToken(testCase.token())
+
+			switch testCase.nameTest {
+			case "unknown_kid", "hs256_signed_with_rsa_public_key", "alg_none", "no_exp", "no_jti", "expired", "wrong_signature", "malformed":
+				assert.NotEqual(t, nil, err)
+				assert.Equal(t, (*jwter.Claims)(nil), got)
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+func TestJWTGO_Rotation(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+
+	oldkey := config.JwtKeyConfig{Kid: "old", Alg: "RS256", KeyFile: keys.rsaFile}
+	newkey := config.JwtKeyConfig{Kid: "new", Alg: "EdDSA", KeyFile: keys.ed25519File}
+
+	claims := &jwter.Claims{Id: "5", Jti: "jti", Expires_at: time.Now().Add(time.Hour).Truncate(time.Second)}
+
+	before := newJWTer(t, "old", oldkey)
+	oldtoken, err := before.GenerateJWTToken(claims)
+	assert.Equal(t, nil, err)
+
+	// new key signs, old one keeps verifying tokens issued before rotation
+	after := newJWTer(t, "new", newkey, config.JwtKeyConfig{Kid: "old", Alg: "RS256", KeyFile: keys.rsaPublicFile})
+
+	got, err := after.ParseToken(*oldtoken)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, claims, got)
+
+	newtoken, err := after.GenerateJWTToken(claims)
+	assert.Equal(t, nil, err)
+
+	parsed, _, err := new(jwt.Parser).ParseUnverified(*newtoken, &jwt.StandardClaims{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, "new", parsed.Header["kid"])
+
+	// old key is removed after lifetime of access tokens
+	_, err = before.ParseToken(*newtoken)
+	assert.NotEqual(t, nil, err)
+}
+
+func TestJWTGO_Keys(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+
+	j := newJWTer(t, "b-ed",
+		config.JwtKeyConfig{Kid: "c-rsa", Alg: "RS256", KeyFile: keys.rsaPublicFile},
+		config.JwtKeyConfig{Kid: "b-ed", Alg: "EdDSA", KeyFile: keys.ed25519File},
+		config.JwtKeyConfig{Kid: "a-hs", Alg: "HS256", Secret: "secret"},
+	)
+
+	// symmetric keys are never listed, others are ordered by kid
+	assert.Equal(t, []*jwter.JWK{
+		{
+			Kty: "OKP",
+			Kid: "b-ed",
+			Alg: "EdDSA",
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(keys.ed25519.Public().(ed25519.PublicKey)),
+		},
+		{
+			Kty: "RSA",
+			Kid: "c-rsa",
+			Alg: "RS256",
+			N:   base64.RawURLEncoding.EncodeToString(keys.rsa.N.Bytes()),
+			E:   "AQAB",
+		},
+	}, j.Keys())
+}
+
+func TestNewJWTGO(t *testing.T) {
+	t.Parallel()
+
+	keys := newTestKeys(t)
+
+	testTable := []struct {
+		nameTest   string
+		signingKid string
+		keys       []config.JwtKeyConfig
+	}{
+		{
+			nameTest:   "duplicate_kid",
+			signingKid: "a",
+			keys: []config.JwtKeyConfig{
+				{Kid: "a", Alg: "HS256", Secret: "secret"},
+				{Kid: "a", Alg: "HS256", Secret: "other"},
+			},
+		},
+		{
+			nameTest:   "unknown_signing_kid",
+			signingKid: "b",
+			keys:       []config.JwtKeyConfig{{Kid: "a", Alg: "HS256", Secret: "secret"}},
+		},
+		{
+			nameTest:   "signing_key_without_private_key",
+			signingKid: "a",
+			keys:       []config.JwtKeyConfig{{Kid: "a", Alg: "RS256", KeyFile: keys.rsaPublicFile}},
+		},
+		{
+			nameTest:   "key_type_does_not_match_alg",
+			signingKid: "a",
+			keys:       []config.JwtKeyConfig{{Kid: "a", Alg: "EdDSA", KeyFile: keys.rsaFile}},
+		},
+		{
+			nameTest:   "empty_secret",
+			signingKid: "a",
+			keys:       []config.JwtKeyConfig{{Kid: "a", Alg: "HS256"}},
+		},
+		{
+			nameTest:   "unsupported_alg",
+			signingKid: "a",
+			keys:       []config.JwtKeyConfig{{Kid: "a", Alg: "ES256", KeyFile: keys.rsaFile}},
+		},
+		{
+			nameTest:   "no_key_file",
+			signingKid: "a",
+			keys:       []config.JwtKeyConfig{{Kid: "a", Alg: "RS256", KeyFile: filepath.Join(t.TempDir(), "none.pem")}},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			got, err := jwtgo.NewJWTGO(&config.Config{
+				Jwt: config.JwtConfig{SigningKid: testCase.signingKid, Keys: testCase.keys},
+			})
+
+			assert.NotEqual(t, nil, err)
+			assert.Equal(t, nil, got)
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package jwtgo
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"quizapp/config"
+	"quizapp/pkg/jwter"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// Key of tokens, sign is nil for keys only verifying tokens
+type key struct {
+	kid    string
+	method jwt.SigningMethod
+	sign   interface{}
+	verify interface{}
+}
+
+// Returns loaded key & nil, if key config is valid.
+// Returns nil & some err else.
+func loadKey(c *config.JwtKeyConfig) (*key, error) {
+	switch c.Alg {
+	case jwt.SigningMethodHS256.Alg():
+		if c.Secret == "" {
+			return nil, fmt.Errorf("key %q: empty secret", c.Kid)
+		}
+
+		return &key{
+			kid:    c.Kid,
+			method: jwt.SigningMethodHS256,
+			sign:   []byte(c.Secret),
+			verify: []byte(c.Secret),
+		}, nil
+	case jwt.SigningMethodRS256.Alg(), SigningMethodEdDSA.Alg():
+		sign, verify, err := loadPEM(c.KeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("key %q: %w", c.Kid, err)
+		}
+
+		_, isrsa := verify.(*rsa.PublicKey)
+		_, ised25519 := verify.(ed25519.PublicKey)
+		if isrsa != (c.Alg == jwt.SigningMethodRS256.Alg()) || ised25519 != (c.Alg == SigningMethodEdDSA.Alg()) {
+			return nil, fmt.Errorf("key %q: key type does not match %s", c.Kid, c.Alg)
+		}
+
+		return &key{
+			kid:    c.Kid,
+			method: jwt.GetSigningMethod(c.Alg),
+			sign:   sign,
+			verify: verify,
+		}, nil
+	default:
+		return nil, fmt.Errorf("key %q: unsupported alg %q", c.Kid, c.Alg)
+	}
+}
+
+// Returns private key, if file keeps it, and public key & nil, if parsed.
+// Returns nil, nil & some err else.
+func loadPEM(path string) (crypto.PrivateKey, crypto.PublicKey, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, nil, errors.New("no PEM data")
+	}
+
+	switch block.Type {
+	case "PUBLIC KEY":
+		public, err := x509.ParsePKIXPublicKey(block.Bytes)
+		return nil, public, err
+	case "RSA PRIVATE KEY":
+		private, err := x509.ParsePKCS1PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		return private, &private.PublicKey, nil
+	case "PRIVATE KEY":
+		private, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		switch k := private.(type) {
+		case *rsa.PrivateKey:
+			return k, &k.PublicKey, nil
+		case ed25519.PrivateKey:
+			return k, k.Public(), nil
+		}
+
+		return nil, nil, errors.New("unsupported private key type")
+	default:
+		return nil, nil, fmt.Errorf("unsupported PEM block %q", block.Type)
+	}
+}
+
+// Returns public key as JWK, nil for symmetric keys.
+func (k *key) jwk() *jwter.JWK {
+	switch public := k.verify.(type) {
+	case *rsa.PublicKey:
+		return &jwter.JWK{
+			Kty: "RSA",
+			Kid: k.kid,
+			Alg: k.method.Alg(),
+			N:   base64.RawURLEncoding.EncodeToString(public.N.Bytes()),
+			E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(public.E)).Bytes()),
+		}
+	case ed25519.PublicKey:
+		return &jwter.JWK{
+			Kty: "OKP",
+			Kid: k.kid,
+			Alg: k.method.Alg(),
+			Crv: "Ed25519",
+			X:   base64.RawURLEncoding.EncodeToString(public),
+		}
+	}
+
+	return nil
+}
@@ -1,11 +1,30 @@
 package jwter
 
+import "time"
+
+// Claims of access token, Jti identifies token for revocation
+type Claims struct {
+	Id, Login, Role, Jti string
+	Expires_at           time.Time
+}
+
+// Public key verifying tokens as JSON Web Key, RFC 7517.
+// N and E are set for RSA keys, Crv and X for OKP ones, all base64url encoded.
+type JWK struct {
+	Kty, Kid, Alg string
+	N, E          string
+	Crv, X        string
+}
+
 type JWTer interface {
 	// Returns generated token & nil, if generated.
 	// Returns nil & some err else.
-	GenerateJWTToken(id, login string) (*string, error)
+	GenerateJWTToken(claims *Claims) (*string, error)
 
-	// Returns user model & nil, if parsed.
+	// Returns claims & nil, if parsed and not expired.
 	// Returns nil & some err else.
-	ParseToken(access_token string) (*string, *string, error)
+	ParseToken(access_token string) (*Claims, error)
+
+	// Returns public keys verifying tokens, symmetric keys are never listed.
+	Keys() []*JWK
 }
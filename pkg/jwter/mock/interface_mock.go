@@ -5,7 +5,7 @@
 package mock
 
 import (
-	models "quizapp/models"
+	jwter "quizapp/pkg/jwter"
 	reflect "reflect"
 
 	gomock "github.com/golang/mock/gomock"
@@ -35,25 +35,39 @@ func (m *MockJWTer) EXPECT() *MockJWTerMockRecorder {
 }
 
 // GenerateJWTToken mocks base method.
-func (m *MockJWTer) GenerateJWTToken(user *models.User) (*string, error) {
+func (m *MockJWTer) GenerateJWTToken(claims *jwter.Claims) (*string, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GenerateJWTToken", user)
+	ret := m.ctrl.Call(m, "GenerateJWTToken", claims)
 	ret0, _ := ret[0].(*string)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GenerateJWTToken indicates an expected call of GenerateJWTToken.
-func (mr *MockJWTerMockRecorder) GenerateJWTToken(user interface{}) *gomock.Call {
+func (mr *MockJWTerMockRecorder) GenerateJWTToken(claims interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateJWTToken", reflect.TypeOf((*MockJWTer)(nil).GenerateJWTToken), user)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GenerateJWTToken", reflect.TypeOf((*MockJWTer)(nil).GenerateJWTToken), claims)
+}
+
+// Keys mocks base method.
+func (m *MockJWTer) Keys() []*jwter.JWK {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Keys")
+	ret0, _ := ret[0].([]*jwter.JWK)
+	return ret0
+}
+
+// Keys indicates an expected call of Keys.
+func (mr *MockJWTerMockRecorder) Keys() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Keys", reflect.TypeOf((*MockJWTer)(nil).Keys))
 }
 
 // ParseToken mocks base method.
-func (m *MockJWTer) ParseToken(access_token string) (*models.User, error) {
+func (m *MockJWTer) ParseToken(access_token string) (*jwter.Claims, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "ParseToken", access_token)
-	ret0, _ := ret[0].(*models.User)
+	ret0, _ := ret[0].(*jwter.Claims)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -17,7 +17,9 @@ const (
 	_defaultConnAttempts = 10
 	_defaultConnTimeout  = time.Second
 
-	PermDenied = "42501"
+	PermDenied          = "42501"
+	UniqueViolation     = "23505"
+	ForeignKeyViolation = "23503"
 )
 
 type Postgres struct {
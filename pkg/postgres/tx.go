@@ -0,0 +1,51 @@
+package postgres
+
+import (
+	"context"
+
+	"github.com/jackc/pgconn"
+	"github.com/jackc/pgx/v4"
+)
+
+type txKey struct{}
+
+// Common part of pool and transaction used by repos
+type Querier interface {
+	Exec(ctx context.Context, sql string, arguments ...interface{}) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...interface{}) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...interface{}) pgx.Row
+	SendBatch(ctx context.Context, b *pgx.Batch) pgx.BatchResults
+}
+
+// Returns transaction started by WithinTx, if ctx has one.
+// Returns pool else.
+func (p *Postgres) Conn(ctx context.Context) Querier {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+
+	return p.Pool
+}
+
+// Runs fn within transaction passed to repos through ctx.
+// Commits, if fn returns nil. Rolls back & returns fn err else.
+// Nested calls join the outer transaction.
+func (p *Postgres) WithinTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	if _, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return fn(ctx)
+	}
+
+	tx, err := p.Pool.Begin(ctx)
+	if err != nil {
+		return err
+	}
+	// no-op after commit
+	defer tx.Rollback(ctx)
+
+	err = fn(context.WithValue(ctx, txKey{}, tx))
+	if err != nil {
+		return err
+	}
+
+	return tx.Commit(ctx)
+}
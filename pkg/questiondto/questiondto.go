@@ -0,0 +1,56 @@
+package questiondto
+
+import "quizapp/models"
+
+// Question options in requests and responses
+type Options struct {
+	Choices []string `json:"choices,omitempty"`
+	Min     *float64 `json:"min,omitempty"`
+	Max     *float64 `json:"max,omitempty"`
+	Step    *float64 `json:"step,omitempty"`
+}
+
+// Answer rules of question in requests and responses
+type Rules struct {
+	// Regular expression, whole answer has to match it
+	Pattern    string `json:"pattern,omitempty"`
+	Min_length *int   `json:"min_length,omitempty" minimum:"0"`
+	Max_length *int   `json:"max_length,omitempty" minimum:"1"`
+	Format     string `json:"format,omitempty" enums:"email,url"`
+}
+
+func OptionsToBL(dto *Options) models.QuestionOptions {
+	return models.QuestionOptions{
+		Choices: dto.Choices,
+		Min:     dto.Min,
+		Max:     dto.Max,
+		Step:    dto.Step,
+	}
+}
+
+func OptionsBLToDTO(modelBL *models.QuestionOptions) Options {
+	return Options{
+		Choices: modelBL.Choices,
+		Min:     modelBL.Min,
+		Max:     modelBL.Max,
+		Step:    modelBL.Step,
+	}
+}
+
+func RulesToBL(dto *Rules) models.QuestionRules {
+	return models.QuestionRules{
+		Pattern:    dto.Pattern,
+		Min_length: dto.Min_length,
+		Max_length: dto.Max_length,
+		Format:     dto.Format,
+	}
+}
+
+func RulesBLToDTO(modelBL *models.QuestionRules) Rules {
+	return Rules{
+		Pattern:    modelBL.Pattern,
+		Min_length: modelBL.Min_length,
+		Max_length: modelBL.Max_length,
+		Format:     modelBL.Format,
+	}
+}
@@ -0,0 +1,281 @@
+package snapshot
+
+import (
+	"encoding/json"
+	"quizapp/models"
+	"strconv"
+)
+
+// Question as stored in JSON snapshots of versions and attempts.
+// Questions drawn into attempt have no section and condition.
+type Question struct {
+	Id        int        `json:"id"`
+	SectionId *int       `json:"section_id,omitempty"`
+	Header    string     `json:"header"`
+	Type      string     `json:"type"`
+	Options   Options    `json:"options"`
+	Condition *Condition `json:"condition,omitempty"`
+	Required  bool       `json:"required,omitempty"`
+	Rules     *Rules     `json:"rules,omitempty"`
+	Correct   string     `json:"correct,omitempty"`
+	Points    int        `json:"points,omitempty"`
+}
+
+// Question options as stored in options_ columns and in snapshots
+type Options struct {
+	Choices []string `json:"choices,omitempty"`
+	Min     *float64 `json:"min,omitempty"`
+	Max     *float64 `json:"max,omitempty"`
+	Step    *float64 `json:"step,omitempty"`
+}
+
+// Answer rules as stored in rules_ columns and in snapshots
+type Rules struct {
+	Pattern   string `json:"pattern,omitempty"`
+	MinLength *int   `json:"min_length,omitempty"`
+	MaxLength *int   `json:"max_length,omitempty"`
+	Format    string `json:"format,omitempty"`
+}
+
+// Condition as stored in condition_ columns and in snapshots
+type Condition struct {
+	QuestionId int    `json:"question_id"`
+	Op         string `json:"op"`
+	Value      string `json:"value,omitempty"`
+}
+
+func OptionsDBToBL(optionsDB Options) models.QuestionOptions {
+	return models.QuestionOptions{
+		Choices: optionsDB.Choices,
+		Min:     optionsDB.Min,
+		Max:     optionsDB.Max,
+		Step:    optionsDB.Step,
+	}
+}
+
+func OptionsBLToDB(optionsBL models.QuestionOptions) Options {
+	return Options{
+		Choices: optionsBL.Choices,
+		Min:     optionsBL.Min,
+		Max:     optionsBL.Max,
+		Step:    optionsBL.Step,
+	}
+}
+
+func RulesDBToBL(rulesDB *Rules) models.QuestionRules {
+	if rulesDB == nil {
+		return models.QuestionRules{}
+	}
+
+	return models.QuestionRules{
+		Pattern:    rulesDB.Pattern,
+		Min_length: rulesDB.MinLength,
+		Max_length: rulesDB.MaxLength,
+		Format:     rulesDB.Format,
+	}
+}
+
+// question with no rules has no rules in snapshot
+func RulesBLToDB(rulesBL models.QuestionRules) *Rules {
+	if rulesBL == (models.QuestionRules{}) {
+		return nil
+	}
+
+	return &Rules{
+		Pattern:   rulesBL.Pattern,
+		MinLength: rulesBL.Min_length,
+		MaxLength: rulesBL.Max_length,
+		Format:    rulesBL.Format,
+	}
+}
+
+func ConditionDBToBL(conditionDB *Condition) *models.Condition {
+	if conditionDB == nil {
+		return nil
+	}
+
+	return &models.Condition{
+		Question_id: strconv.Itoa(conditionDB.QuestionId),
+		Op:          conditionDB.Op,
+		Value:       conditionDB.Value,
+	}
+}
+
+func ConditionBLToDB(conditionBL *models.Condition) (*Condition, error) {
+	if conditionBL == nil {
+		return nil, nil
+	}
+
+	qid, err := strconv.Atoi(conditionBL.Question_id)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Condition{
+		QuestionId: qid,
+		Op:         conditionBL.Op,
+		Value:      conditionBL.Value,
+	}, nil
+}
+
+// Form id and position are not kept in snapshot, they are set by its owner.
+func QuestionDBToBL(questionDB *Question) *models.Question {
+	var sid string
+	if questionDB.SectionId != nil {
+		sid = strconv.Itoa(*questionDB.SectionId)
+	}
+
+	return &models.Question{
+		Id:         strconv.Itoa(questionDB.Id),
+		Section_id: sid,
+		Header:     questionDB.Header,
+		Type:       questionDB.Type,
+		Options:    OptionsDBToBL(questionDB.Options),
+		Condition:  ConditionDBToBL(questionDB.Condition),
+		Required:   questionDB.Required,
+		Rules:      RulesDBToBL(questionDB.Rules),
+		Correct:    questionDB.Correct,
+		Points:     questionDB.Points,
+	}
+}
+
+func QuestionBLToDB(questionBL *models.Question) (*Question, error) {
+	qid, err := strconv.Atoi(questionBL.Id)
+	if err != nil {
+		return nil, err
+	}
+
+	var sid *int
+	if questionBL.Section_id != "" {
+		intsid, err := strconv.Atoi(questionBL.Section_id)
+		if err != nil {
+			return nil, err
+		}
+		sid = &intsid
+	}
+
+	condition, err := ConditionBLToDB(questionBL.Condition)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Question{
+		Id:        qid,
+		SectionId: sid,
+		Header:    questionBL.Header,
+		Type:      questionBL.Type,
+		Options:   OptionsBLToDB(questionBL.Options),
+		Condition: condition,
+		Required:  questionBL.Required,
+		Rules:     RulesBLToDB(questionBL.Rules),
+		Correct:   questionBL.Correct,
+		Points:    questionBL.Points,
+	}, nil
+}
+
+// Returns questions of snapshot & nil, empty snapshot has no questions.
+// Returns nil & err, if snapshot is not valid JSON.
+func UnmarshalQuestions(questionsJSON []byte) ([]*models.Question, error) {
+	var questionsDB []Question
+	if len(questionsJSON) != 0 {
+		err := json.Unmarshal(questionsJSON, &questionsDB)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	res := make([]*models.Question, len(questionsDB))
+	for i := range questionsDB {
+		res[i] = QuestionDBToBL(&questionsDB[i])
+	}
+
+	return res, nil
+}
+
+// Returns snapshot of questions & nil, no questions are stored with empty array.
+// Returns nil & err, if ids are not numbers.
+func MarshalQuestions(questionsBL []*models.Question) ([]byte, error) {
+	questionsDB := make([]*Question, len(questionsBL))
+	for i, q := range questionsBL {
+		questionDB, err := QuestionBLToDB(q)
+		if err != nil {
+			return nil, err
+		}
+
+		questionsDB[i] = questionDB
+	}
+
+	return json.Marshal(questionsDB)
+}
+
+// Returns options stored in column & nil, empty column keeps no options.
+func UnmarshalOptions(optionsJSON []byte) (models.QuestionOptions, error) {
+	var optionsDB Options
+	if len(optionsJSON) != 0 {
+		err := json.Unmarshal(optionsJSON, &optionsDB)
+		if err != nil {
+			return models.QuestionOptions{}, err
+		}
+	}
+
+	return OptionsDBToBL(optionsDB), nil
+}
+
+func MarshalOptions(optionsBL models.QuestionOptions) ([]byte, error) {
+	optionsDB := OptionsBLToDB(optionsBL)
+	return json.Marshal(&optionsDB)
+}
+
+// Returns rules stored in column & nil, empty column keeps no rules.
+func UnmarshalRules(rulesJSON []byte) (models.QuestionRules, error) {
+	var rulesDB Rules
+	if len(rulesJSON) != 0 {
+		err := json.Unmarshal(rulesJSON, &rulesDB)
+		if err != nil {
+			return models.QuestionRules{}, err
+		}
+	}
+
+	return RulesDBToBL(&rulesDB), nil
+}
+
+// question with no rules is stored with empty object, not NULL
+func MarshalRules(rulesBL models.QuestionRules) ([]byte, error) {
+	rulesDB := RulesBLToDB(rulesBL)
+	if rulesDB == nil {
+		rulesDB = &Rules{}
+	}
+
+	return json.Marshal(rulesDB)
+}
+
+// Returns condition stored in column & nil, NULL column keeps no condition.
+func UnmarshalCondition(conditionJSON *string) (*models.Condition, error) {
+	if conditionJSON == nil {
+		return nil, nil
+	}
+
+	var conditionDB Condition
+	err := json.Unmarshal([]byte(*conditionJSON), &conditionDB)
+	if err != nil {
+		return nil, err
+	}
+
+	return ConditionDBToBL(&conditionDB), nil
+}
+
+// unconditional question or section has NULL condition
+func MarshalCondition(conditionBL *models.Condition) (*string, error) {
+	conditionDB, err := ConditionBLToDB(conditionBL)
+	if err != nil || conditionDB == nil {
+		return nil, err
+	}
+
+	condition, err := json.Marshal(conditionDB)
+	if err != nil {
+		return nil, err
+	}
+
+	res := string(condition)
+	return &res, nil
+}
@@ -0,0 +1,105 @@
+package snapshot_test
+
+import (
+	"quizapp/models"
+	"quizapp/pkg/snapshot"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func floatRef(f float64) *float64 {
+	return &f
+}
+
+func intRef(i int) *int {
+	return &i
+}
+
+func TestSnapshot_Questions(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		nameTest     string
+		questions    []*models.Question
+		expectedJSON string
+	}{
+		{
+			nameTest: "ok",
+			questions: []*models.Question{
+				{
+					Id:         "7",
+					Section_id: "2",
+					Header:     "age",
+					Type:       models.QuestionTypeNumber,
+					Options:    models.QuestionOptions{Min: floatRef(0), Max: floatRef(120)},
+					Required:   true,
+				},
+				{
+					Id:        "8",
+					Header:    "email",
+					Type:      models.QuestionTypeText,
+					Condition: &models.Condition{Question_id: "7", Op: models.ConditionOpAnswered},
+					Rules:     models.QuestionRules{Format: "email", Max_length: intRef(64)},
+				},
+				{
+					Id:      "901",
+					Header:  "q",
+					Type:    models.QuestionTypeSingleChoice,
+					Options: models.QuestionOptions{Choices: []string{"b", "a"}},
+					Correct: "a",
+					Points:  1,
+				},
+			},
+			expectedJSON: `[{"id":7,"section_id":2,"header":"age","type":"number","options":{"min":0,"max":120},"required":true},` +
+				`{"id":8,"header":"email","type":"text","options":{},"condition":{"question_id":7,"op":"answered"},"rules":{"max_length":64,"format":"email"}},` +
+				`{"id":901,"header":"q","type":"single_choice","options":{"choices":["b","a"]},"correct":"a","points":1}]`,
+		},
+		{
+			nameTest:     "no_questions",
+			expectedJSON: `[]`,
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			got, err := snapshot.MarshalQuestions(testCase.questions)
+			assert.Equal(t, nil, err)
+			assert.JSONEq(t, testCase.expectedJSON, string(got))
+
+			questions, err := snapshot.UnmarshalQuestions(got)
+			assert.Equal(t, nil, err)
+			assert.Equal(t, len(testCase.questions), len(questions))
+			for i := range questions {
+				assert.Equal(t, testCase.questions[i], questions[i])
+			}
+		})
+	}
+}
+
+func TestSnapshot_Columns(t *testing.T) {
+	t.Parallel()
+
+	options, err := snapshot.MarshalOptions(models.QuestionOptions{Min: floatRef(1), Max: floatRef(5)})
+	assert.Equal(t, nil, err)
+	assert.JSONEq(t, `{"min":1,"max":5}`, string(options))
+
+	// question with no rules is stored with empty object
+	rules, err := snapshot.MarshalRules(models.QuestionRules{})
+	assert.Equal(t, nil, err)
+	assert.Equal(t, `{}`, string(rules))
+
+	gotrules, err := snapshot.UnmarshalRules(nil)
+	assert.Equal(t, nil, err)
+	assert.Equal(t, models.QuestionRules{}, gotrules)
+
+	condition, err := snapshot.MarshalCondition(nil)
+	assert.Equal(t, nil, err)
+	assert.Nil(t, condition)
+
+	_, err = snapshot.MarshalCondition(&models.Condition{Question_id: "7r", Op: models.ConditionOpAnswered})
+	assert.NotEqual(t, nil, err)
+
+	_, err = snapshot.UnmarshalQuestions([]byte(`{`))
+	assert.NotEqual(t, nil, err)
+}
@@ -0,0 +1,11 @@
+package transactor
+
+import "context"
+
+type Transactor interface {
+	// Runs fn within transaction passed through ctx.
+	// Returns nil, if fn returns nil and transaction committed.
+	// Returns fn err, if fn failed, rolling transaction back.
+	// Returns other err else.
+	WithinTx(ctx context.Context, fn func(ctx context.Context) error) error
+}
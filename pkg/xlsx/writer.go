@@ -0,0 +1,153 @@
+package xlsx
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"strconv"
+)
+
+const (
+	contentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types">` +
+		`<Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/>` +
+		`<Default Extension="xml" ContentType="application/xml"/>` +
+		`<Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/>` +
+		`<Override PartName="/xl/worksheets/sheet1.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>` +
+		`</Types>`
+
+	rootRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/>` +
+		`</Relationships>`
+
+	workbook = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships">` +
+		`<sheets><sheet name="Sheet1" sheetId="1" r:id="rId1"/></sheets>` +
+		`</workbook>`
+
+	workbookRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` +
+		`<Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet1.xml"/>` +
+		`</Relationships>`
+
+	sheetHeader = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData>`
+
+	sheetFooter = `</sheetData></worksheet>`
+)
+
+// Writes single sheet workbook of string cells row by row,
+// rows are not kept in memory.
+type Writer struct {
+	zw    *zip.Writer
+	sheet io.Writer
+	row   int
+	err   error
+}
+
+func NewWriter(w io.Writer) *Writer {
+	return &Writer{zw: zip.NewWriter(w)}
+}
+
+// Writes row of cells, workbook parts are written before first row.
+func (w *Writer) Write(record []string) error {
+	if w.err != nil {
+		return w.err
+	}
+
+	if w.sheet == nil {
+		w.err = w.begin()
+		if w.err != nil {
+			return w.err
+		}
+	}
+
+	w.row++
+	rownum := strconv.Itoa(w.row)
+
+	w.writeString(`<row r="` + rownum + `">`)
+	for i, cell := range record {
+		w.writeString(`<c r="` + columnName(i) + rownum + `" t="inlineStr"><is><t xml:space="preserve">`)
+		if w.err == nil {
+			w.err = xml.EscapeText(w.sheet, []byte(cell))
+		}
+		w.writeString(`</t></is></c>`)
+	}
+	w.writeString(`</row>`)
+
+	return w.err
+}
+
+// Completes workbook, writer is not usable after.
+func (w *Writer) Close() error {
+	if w.err != nil {
+		return w.err
+	}
+
+	if w.sheet == nil {
+		w.err = w.begin()
+		if w.err != nil {
+			return w.err
+		}
+	}
+
+	w.writeString(sheetFooter)
+	if w.err != nil {
+		return w.err
+	}
+
+	return w.zw.Close()
+}
+
+func (w *Writer) begin() error {
+	parts := []struct{ name, content string }{
+		{"[Content_Types].xml", contentTypes},
+		{"_rels/.rels", rootRels},
+		{"xl/workbook.xml", workbook},
+		{"xl/_rels/workbook.xml.rels", workbookRels},
+	}
+
+	for _, p := range parts {
+		pw, err := w.zw.Create(p.name)
+		if err != nil {
+			return err
+		}
+
+		_, err = io.WriteString(pw, p.content)
+		if err != nil {
+			return err
+		}
+	}
+
+	sheet, err := w.zw.Create("xl/worksheets/sheet1.xml")
+	if err != nil {
+		return err
+	}
+
+	_, err = io.WriteString(sheet, sheetHeader)
+	if err != nil {
+		return err
+	}
+
+	w.sheet = sheet
+
+	return nil
+}
+
+func (w *Writer) writeString(s string) {
+	if w.err != nil {
+		return
+	}
+
+	_, w.err = io.WriteString(w.sheet, s)
+}
+
+// Returns spreadsheet column name of zero based index: A, B, ..., Z, AA, ...
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
@@ -0,0 +1,189 @@
+package xlsx_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"io"
+	"quizapp/pkg/xlsx"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type sheetXML struct {
+	Rows []struct {
+		R     string `xml:"r,attr"`
+		Cells []struct {
+			R    string `xml:"r,attr"`
+			T    string `xml:"t,attr"`
+			Text string `xml:"is>t"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+type cell struct {
+	ref, value string
+}
+
+// Reads workbook back, returns parts names & cells of sheet by rows
+func readWorkbook(t *testing.T, data []byte) ([]string, [][]cell) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var (
+		names []string
+		sheet sheetXML
+	)
+
+	for _, f := range zr.File {
+		names = append(names, f.Name)
+
+		rc, err := f.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		content, err := io.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// every part has to be well-formed xml
+		var part struct{}
+		if err = xml.Unmarshal(content, &part); err != nil {
+			t.Fatalf("part %s: %v", f.Name, err)
+		}
+
+		if f.Name == "xl/worksheets/sheet1.xml" {
+			if err = xml.Unmarshal(content, &sheet); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+
+	rows := make([][]cell, len(sheet.Rows))
+	for i, r := range sheet.Rows {
+		for _, c := range r.Cells {
+			assert.Equal(t, "inlineStr", c.T)
+			rows[i] = append(rows[i], cell{ref: c.R, value: c.Text})
+		}
+	}
+
+	return names, rows
+}
+
+func TestWriter_Write(t *testing.T) {
+	t.Parallel()
+
+	wide := make([]string, 28)
+	for i := range wide {
+		wide[i] = "v"
+	}
+
+	testTable := []struct {
+		nameTest     string
+		records      [][]string
+		expectedRows [][]cell
+	}{
+		{
+			nameTest: "ok",
+			records:  [][]string{{"id", "answer"}, {"1", "yes"}},
+			expectedRows: [][]cell{
+				{{"A1", "id"}, {"B1", "answer"}},
+				{{"A2", "1"}, {"B2", "yes"}},
+			},
+		},
+		{
+			nameTest: "markup_escaped",
+			records:  [][]string{{`<b>&amp;"it's"</b>`, `]]><x/>`}},
+			expectedRows: [][]cell{
+				{{"A1", `<b>&amp;"it's"</b>`}, {"B1", `]]><x/>`}},
+			},
+		},
+		{
+			nameTest: "whitespace_kept",
+			records:  [][]string{{"  two\nlines\tand\r", " "}},
+			expectedRows: [][]cell{
+				{{"A1", "  two\nlines\tand\r"}, {"B1", " "}},
+			},
+		},
+		{
+			// control characters are not allowed in xml, so they are replaced
+			nameTest: "control_characters",
+			records:  [][]string{{"a\x00b\x01c\x1f", "\x0b"}},
+			expectedRows: [][]cell{
+				{{"A1", "a�b�c�"}, {"B1", "�"}},
+			},
+		},
+		{
+			nameTest:     "no_rows",
+			records:      nil,
+			expectedRows: [][]cell{},
+		},
+		{
+			nameTest: "column_after_z",
+			records:  [][]string{wide},
+		},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.nameTest, func(t *testing.T) {
+			var buf bytes.Buffer
+
+			w := xlsx.NewWriter(&buf)
+			for _, record := range testCase.records {
+				err := w.Write(record)
+				assert.Equal(t, nil, err)
+			}
+			err := w.Close()
+			assert.Equal(t, nil, err)
+
+			names, rows := readWorkbook(t, buf.Bytes())
+
+			assert.Equal(t, []string{
+				"[Content_Types].xml",
+				"_rels/.rels",
+				"xl/workbook.xml",
+				"xl/_rels/workbook.xml.rels",
+				"xl/worksheets/sheet1.xml",
+			}, names)
+
+			switch testCase.nameTest {
+			case "ok", "markup_escaped", "whitespace_kept", "control_characters", "no_rows":
+				assert.Equal(t, testCase.expectedRows, rows)
+			case "column_after_z":
+				assert.Equal(t, 28, len(rows[0]))
+				assert.Equal(t, cell{"Z1", "v"}, rows[0][25])
+				assert.Equal(t, cell{"AA1", "v"}, rows[0][26])
+				assert.Equal(t, cell{"AB1", "v"}, rows[0][27])
+			default:
+				assert.Error(t, errors.New("No case"), "No case")
+			}
+		})
+	}
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write_error")
+}
+
+func TestWriter_WriteError(t *testing.T) {
+	t.Parallel()
+
+	w := xlsx.NewWriter(failingWriter{})
+
+	// zip buffers parts, so error is surfaced at latest on close
+	err := w.Write([]string{"a"})
+	if err == nil {
+		err = w.Close()
+	}
+
+	assert.NotEqual(t, nil, err)
+}